@@ -0,0 +1,75 @@
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// TemplateConfig describes an Interest template of a fetch procedure.
+type TemplateConfig struct {
+	Prefix           ndn.Name
+	InterestLifetime nnduration.Milliseconds
+	CanBePrefix      bool
+}
+
+// BenchmarkConfig contains benchmark parameters.
+type BenchmarkConfig struct {
+	Templates []TemplateConfig
+	Interval  nnduration.Milliseconds // interval between counter readings
+	Count     int                     // number of counter readings
+}
+
+// BenchmarkResult contains benchmark result of a fetch procedure.
+type BenchmarkResult struct {
+	Counters []Counters
+	Goodput  float64
+}
+
+// Benchmark runs fetch procedures with specified templates, and reads counters periodically.
+// This blocks until Count counter readings are taken, and then stops the Fetcher.
+func (fetcher *Fetcher) Benchmark(cfg BenchmarkConfig) (list []BenchmarkResult, e error) {
+	if fetcher.Thread(0).IsRunning() {
+		return nil, errors.New("Fetcher is running")
+	}
+	if cfg.Count <= 0 {
+		return nil, errors.New("Count must be positive")
+	}
+
+	var logics []*Logic
+	fetcher.Reset()
+	for i, tpl := range cfg.Templates {
+		tplArgs := []interface{}{tpl.Prefix}
+		if tpl.CanBePrefix {
+			tplArgs = append(tplArgs, ndn.CanBePrefixFlag)
+		}
+		if d := tpl.InterestLifetime.Duration(); d > 0 {
+			tplArgs = append(tplArgs, d)
+		}
+		j, e := fetcher.AddTemplate(tplArgs...)
+		if e != nil {
+			return nil, fmt.Errorf("AddTemplate[%d]: %w", i, e)
+		}
+		logics = append(logics, fetcher.Logic(j))
+	}
+
+	list = make([]BenchmarkResult, len(logics))
+	fetcher.Launch()
+	ticker := time.NewTicker(cfg.Interval.DurationOr(1000))
+	defer ticker.Stop()
+	for c := 0; c < cfg.Count; c++ {
+		<-ticker.C
+		for i, logic := range logics {
+			list[i].Counters = append(list[i].Counters, logic.ReadCounters())
+		}
+	}
+	fetcher.Stop()
+
+	for i, res := range list {
+		list[i].Goodput = res.Counters[len(res.Counters)-1].ComputeGoodput(res.Counters[0])
+	}
+	return list, nil
+}
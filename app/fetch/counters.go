@@ -1,8 +1,16 @@
 package fetch
 
+/*
+#include "../../csrc/fetch/logic.h"
+*/
+import "C"
 import (
 	"fmt"
 	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/runningstat"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 )
 
 // Counters contains counters of Logic.
@@ -11,10 +19,13 @@ type Counters struct {
 	LastRtt   time.Duration
 	SRtt      time.Duration
 	Rto       time.Duration
+	Rtt       runningstat.QuantileSnapshot // RTT quantiles in nanoseconds
 	Cwnd      int
 	NInFlight uint32 // number of in-flight Interests
 	NTxRetx   uint64 // number of retransmitted Interests
 	NRxData   uint64 // number of Data satisfying pending Interests
+
+	NRxCongNacks uint64 // number of Nacks indicating congestion
 }
 
 // ReadCounters retrieves counters.
@@ -24,17 +35,19 @@ func (fl *Logic) ReadCounters() (cnt Counters) {
 	cnt.LastRtt = rtte.LastRtt()
 	cnt.SRtt = rtte.SRtt()
 	cnt.Rto = rtte.Rto()
+	cnt.Rtt = runningstat.QuantileSketchFromPtr(unsafe.Pointer(&fl.ptr().rttQuantiles)).Read().Scale(eal.GetNanosInTscUnit())
 	cnt.Cwnd = fl.Cubic().Cwnd()
 	cnt.NInFlight = uint32(fl.ptr().nInFlight)
 	cnt.NTxRetx = uint64(fl.ptr().nTxRetx)
 	cnt.NRxData = uint64(fl.ptr().nRxData)
+	cnt.NRxCongNacks = uint64(fl.ptr().nRxCongNacks)
 	return cnt
 }
 
 func (cnt Counters) String() string {
-	return fmt.Sprintf("rtt=%dms srtt=%dms rto=%dms cwnd=%d %dP %dR %dD",
+	return fmt.Sprintf("rtt=%dms srtt=%dms rto=%dms cwnd=%d %dP %dR %dD %dN",
 		cnt.LastRtt.Milliseconds(), cnt.SRtt.Milliseconds(), cnt.Rto.Milliseconds(),
-		cnt.Cwnd, cnt.NInFlight, cnt.NTxRetx, cnt.NRxData)
+		cnt.Cwnd, cnt.NInFlight, cnt.NTxRetx, cnt.NRxData, cnt.NRxCongNacks)
 }
 
 // ComputeGoodput returns average number of Data per second.
@@ -93,3 +93,14 @@ func (fl *Logic) RxData(segNum uint64, hasCongMark bool) {
 	}
 	C.FetchLogic_RxDataBurst(fl.ptr(), &pkt, 1)
 }
+
+// RxNack notifies about Nack arrival.
+func (fl *Logic) RxNack(segNum uint64, reason uint8, hasCongMark bool) {
+	var pkt C.FetchLogicRxData
+	pkt.segNum = C.uint64_t(segNum)
+	pkt.nackReason = C.uint8_t(reason)
+	if hasCongMark {
+		pkt.congMark = 1
+	}
+	C.FetchLogic_RxDataBurst(fl.ptr(), &pkt, 1)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/usnistgov/ndn-dpdk/app/fetch"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
 )
 
 func TestLogic(t *testing.T) {
@@ -73,3 +74,48 @@ func TestLogic(t *testing.T) {
 	assert.Greater(txCountFreq[1], 1700)
 	assert.Less(txCountFreq[9], 20)
 }
+
+func TestLogicCongestion(t *testing.T) {
+	assert, require := makeAR(t)
+
+	flPtr := eal.Zmalloc("FetchLogic", unsafe.Sizeof(fetch.Logic{}), eal.NumaSocket{})
+	defer eal.Free(flPtr)
+	fl := fetch.LogicFromPtr(flPtr)
+	fl.Init(64, eal.NumaSocket{})
+	defer fl.Close()
+	fl.SetFinalSegNum(99)
+
+	txInterests := func() (segNums []uint64) {
+		for {
+			needTx, txSegNum := fl.TxInterest()
+			if !needTx {
+				return segNums
+			}
+			segNums = append(segNums, txSegNum)
+		}
+	}
+
+	segNums := txInterests()
+	require.GreaterOrEqual(len(segNums), 2)
+	fl.RxData(segNums[0], false)
+	segNums = append(segNums[1:], txInterests()...)
+	cwnd := fl.ReadCounters().Cwnd
+
+	fl.RxNack(segNums[0], an.NackNoRoute, false)
+	cnt := fl.ReadCounters()
+	assert.Zero(cnt.NRxCongNacks)
+	assert.Equal(cwnd, cnt.Cwnd)
+
+	fl.RxNack(segNums[0], an.NackCongestion, false)
+	cnt = fl.ReadCounters()
+	assert.EqualValues(1, cnt.NRxCongNacks)
+	assert.Less(cnt.Cwnd, cwnd)
+	assert.EqualValues(len(segNums)-1, cnt.NInFlight)
+
+	for _, segNum := range segNums[1:] {
+		fl.RxData(segNum, false)
+	}
+	retx := txInterests()
+	assert.Contains(retx, segNums[0])
+	assert.EqualValues(1, fl.ReadCounters().NTxRetx)
+}
@@ -1,7 +1,13 @@
 package fwdp
 
+/*
+#include "../../csrc/fwdp/fwd.h"
+*/
+import "C"
 import (
 	"fmt"
+	"sync"
+	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
@@ -33,6 +39,8 @@ type Config struct {
 	FwdDataQueue      iface.PktQueueConfig
 	FwdNackQueue      iface.PktQueueConfig
 	LatencySampleFreq int // latency sample frequency, between 0 and 30
+
+	NdtBalancer ndt.BalancerConfig // NDT balancer config
 }
 
 // DataPlane represents the forwarder data plane.
@@ -42,6 +50,10 @@ type DataPlane struct {
 	inputs []*Input
 	crypto *Crypto
 	fwds   []*Fwd
+
+	ndtBalancer  *ndt.Balancer
+	limiterMutex sync.Mutex
+	limiter      *C.FwLimiter
 }
 
 // New creates and launches forwarder data plane.
@@ -79,6 +91,13 @@ func New(cfg Config) (dp *DataPlane, e error) {
 		dp.fwds = append(dp.fwds, fwd)
 		fibFwds = append(fibFwds, fwd)
 	}
+	for _, fwd := range dp.fwds {
+		fwd.c.ndt = (*C.Ndt)(dp.ndt.Ptr())
+		transitDemux := iface.InputDemuxFromPtr(unsafe.Pointer(&fwd.c.transitDemux))
+		for i, dest := range dp.fwds {
+			transitDemux.SetDest(i, dest.queueI)
+		}
+	}
 
 	if dp.fib, e = fib.New(cfg.Fib, fibFwds); e != nil {
 		dp.Close()
@@ -104,11 +123,27 @@ func New(cfg Config) (dp *DataPlane, e error) {
 		fwi.rxl.Launch()
 	}
 
+	if cfg.NdtBalancer.Enabled {
+		dp.ndtBalancer = ndt.NewBalancer(dp.ndt, len(dp.fwds), cfg.NdtBalancer, dp.readInterestQueueOccupancy)
+		dp.ndtBalancer.Launch()
+	}
+
 	return dp, nil
 }
 
+func (dp *DataPlane) readInterestQueueOccupancy() (list []float64) {
+	for _, fwd := range dp.fwds {
+		ring := fwd.queueI.Ring()
+		list = append(list, float64(ring.CountInUse())/float64(ring.Capacity()))
+	}
+	return list
+}
+
 // Close stops the data plane and releases resources.
 func (dp *DataPlane) Close() error {
+	if dp.ndtBalancer != nil {
+		dp.ndtBalancer.Close()
+	}
 	iface.CloseAll()
 	if dp.crypto != nil {
 		dp.crypto.Close()
@@ -119,6 +154,9 @@ func (dp *DataPlane) Close() error {
 	for _, fwi := range dp.inputs {
 		fwi.Close()
 	}
+	if dp.limiter != nil {
+		eal.Free(dp.limiter)
+	}
 	if dp.fib != nil {
 		dp.fib.Close()
 	}
@@ -0,0 +1,21 @@
+package fwdp
+
+//go:generate go run ../../mk/enumgen/ -guard=NDNDPDK_FWDP_ENUM_H -out=../../csrc/fwdp/enum.h .
+
+// LimiterAction determines what happens to an Interest that exceeds a rate limit.
+type LimiterAction int
+
+// LimiterAction values.
+const (
+	LimiterDrop LimiterAction = iota // drop the Interest
+	LimiterNack                      // reply a Nack of reason Congestion
+
+	_ = "enumgen:FwLimiterAction:Fw"
+)
+
+// MaxLimiterRules is the maximum number of Interest rate limiters.
+const (
+	MaxLimiterRules = 64
+
+	_ = "enumgen::Fw"
+)
@@ -0,0 +1,228 @@
+package fwdp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs"
+	"github.com/usnistgov/ndn-dpdk/container/fib"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibreplica"
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/container/pit"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// ExplainInterest describes an Interest to be explained.
+type ExplainInterest struct {
+	Name        ndn.Name
+	CanBePrefix bool
+	MustBeFresh bool
+	FwHint      []ndn.Name
+	Face        iface.ID // incoming face, zero if unspecified
+}
+
+// ExplainNexthop describes a FIB nexthop.
+type ExplainNexthop struct {
+	Face iface.ID
+
+	// Reason indicates why the nexthop is ineligible, empty if eligible.
+	// "downstream" means the nexthop is the incoming face.
+	// "face-down" means the face does not exist or is down.
+	Reason string
+}
+
+// Eligible determines whether the strategy may forward the Interest to this nexthop.
+func (nh ExplainNexthop) Eligible() bool {
+	return nh.Reason == ""
+}
+
+// Nexthop ineligible reasons.
+const (
+	ExplainNexthopDownstream = "downstream"
+	ExplainNexthopFaceDown   = "face-down"
+)
+
+// ExplainFibLookup describes a FIB longest prefix match.
+type ExplainFibLookup struct {
+	Name     ndn.Name // Interest name or forwarding hint
+	Lpm      fibreplica.LpmTrace
+	Entry    *fib.Entry // matched entry, nil if no match
+	Nexthops []ExplainNexthop
+}
+
+// NEligible returns number of eligible nexthops.
+func (lookup ExplainFibLookup) NEligible() (n int) {
+	for _, nh := range lookup.Nexthops {
+		if nh.Eligible() {
+			n++
+		}
+	}
+	return n
+}
+
+// Explain verdicts.
+const (
+	ExplainNoRoute  = "no-route"
+	ExplainCsHit    = "cs-hit"
+	ExplainPitFound = "pit-found"
+	ExplainPitNew   = "pit-new"
+)
+
+// Explanation reports how the forwarder would process an Interest.
+type Explanation struct {
+	NdtIndex int // NDT table index
+	Fwd      int // forwarding thread index
+
+	// FibLookups lists the FIB lookups in order.
+	// The first lookup uses the Interest name if there is no forwarding hint; otherwise, each lookup
+	// uses a forwarding hint, and stops at the first FIB entry with an eligible nexthop.
+	FibLookups []ExplainFibLookup
+
+	// FibLookup is the FIB lookup whose entry would be used, nil if there is no route.
+	FibLookup *ExplainFibLookup
+
+	// FwHint is the active forwarding hint, which is part of the PIT-CS key.
+	FwHint ndn.Name
+
+	Strategy *strategycode.Strategy
+	CsEntry  *cs.EntryInfo  // CS entry that would satisfy the Interest
+	PitEntry *pit.EntryInfo // existing PIT entry that the Interest would join
+
+	Verdict string
+}
+
+var errExplainName = errors.New("Interest name must not be empty")
+
+// Explain reports how the forwarder would process an Interest, without forwarding anything.
+//
+// The FIB lookup is emulated on the FIB replica of the chosen forwarding thread.
+// The FibNexthopFilter of the forwarding thread rejects the incoming face only;
+// face-down nexthops are reported here as ineligible, but the strategy would see them.
+// The CS and PIT are inspected with PCCT walks, so that the forwarding thread is not blocked.
+func (dp *DataPlane) Explain(interest ExplainInterest) (x Explanation, e error) {
+	if len(interest.Name) == 0 {
+		return x, errExplainName
+	}
+
+	index, value := dp.ndt.Lookup(interest.Name)
+	x.NdtIndex, x.Fwd = int(index), int(value)
+	if x.Fwd >= len(dp.fwds) {
+		return x, fmt.Errorf("NDT element %d refers to nonexistent forwarding thread %d", x.NdtIndex, x.Fwd)
+	}
+	fwd := dp.fwds[x.Fwd]
+
+	lookupNames := interest.FwHint
+	if len(lookupNames) == 0 {
+		lookupNames = []ndn.Name{interest.Name}
+	}
+	replica := dp.fib.Replica(fwd.NumaSocket())
+	eal.CallMain(func() {
+		for _, name := range lookupNames {
+			lookup := ExplainFibLookup{Name: name}
+			entryR, trace := replica.LpmTrace(name)
+			lookup.Lpm = trace
+			if entryR != nil {
+				lookup.Entry = dp.fib.Find(entryR.Read().Name)
+			}
+			x.FibLookups = append(x.FibLookups, lookup)
+		}
+	})
+
+	for i := range x.FibLookups {
+		lookup := &x.FibLookups[i]
+		if lookup.Entry == nil {
+			continue
+		}
+		nDownstream := 0
+		for _, nh := range lookup.Entry.Nexthops {
+			enh := ExplainNexthop{Face: nh}
+			switch {
+			case nh == interest.Face:
+				enh.Reason = ExplainNexthopDownstream
+				nDownstream++
+			case iface.IsDown(nh):
+				enh.Reason = ExplainNexthopFaceDown
+			}
+			lookup.Nexthops = append(lookup.Nexthops, enh)
+		}
+		if len(lookup.Nexthops) > nDownstream {
+			x.FibLookups, x.FibLookup = x.FibLookups[:i+1], lookup
+			if len(interest.FwHint) > 0 {
+				x.FwHint = lookup.Name
+			}
+			break
+		}
+	}
+	if x.FibLookup == nil {
+		x.Verdict = ExplainNoRoute
+		return x, nil
+	}
+	x.Strategy = strategycode.Get(x.FibLookup.Entry.Strategy)
+
+	if x.CsEntry, e = dp.explainCs(fwd, interest, x.FwHint); e != nil {
+		return x, e
+	}
+	if x.CsEntry != nil {
+		x.Verdict = ExplainCsHit
+		return x, nil
+	}
+
+	if x.PitEntry, e = dp.explainPit(fwd, interest, x.FwHint); e != nil {
+		return x, e
+	}
+	if x.PitEntry != nil {
+		x.Verdict = ExplainPitFound
+	} else {
+		x.Verdict = ExplainPitNew
+	}
+	return x, nil
+}
+
+// explainWalk walks PCC entries under the Interest name, passing each page to visit until it returns true.
+func explainWalk(fwd *Fwd, kind pcct.WalkKind, prefix ndn.Name, visit func(w *pcct.Walk) bool) error {
+	for offset := 0; ; offset += pcct.MaxWalkLimit {
+		w, e := pcct.NewWalk(kind, prefix, offset, pcct.MaxWalkLimit, fwd.NumaSocket())
+		if e != nil {
+			return e
+		}
+		fwd.Walk(w)
+		done := visit(w) || w.Len() < pcct.MaxWalkLimit
+		w.Close()
+		if done {
+			return nil
+		}
+	}
+}
+
+func (dp *DataPlane) explainCs(fwd *Fwd, interest ExplainInterest, fwHint ndn.Name) (found *cs.EntryInfo, e error) {
+	e = explainWalk(fwd, pcct.WalkCs, interest.Name, func(w *pcct.Walk) bool {
+		for _, info := range cs.ListEntries(w) {
+			switch {
+			case !info.Name.Equal(interest.Name), !info.FwHint.Equal(fwHint):
+			case !info.IsDirect && !interest.CanBePrefix: // indirect entry refers to Data with longer name
+			case interest.MustBeFresh && !info.IsFresh():
+			default:
+				found = &info
+				return true
+			}
+		}
+		return false
+	})
+	return found, e
+}
+
+func (dp *DataPlane) explainPit(fwd *Fwd, interest ExplainInterest, fwHint ndn.Name) (found *pit.EntryInfo, e error) {
+	e = explainWalk(fwd, pcct.WalkPit, interest.Name, func(w *pcct.Walk) bool {
+		for _, info := range pit.ListEntries(w) {
+			if info.Name.Equal(interest.Name) && info.FwHint.Equal(fwHint) && info.MustBeFresh == interest.MustBeFresh {
+				found = &info
+				return true
+			}
+		}
+		return false
+	})
+	return found, e
+}
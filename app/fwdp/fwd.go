@@ -8,6 +8,8 @@ import "C"
 
 import (
 	"fmt"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/container/pcct"
@@ -22,6 +24,12 @@ import (
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
+// Polling interval bounds while waiting for a forwarding thread to complete a PCCT walk.
+const (
+	walkPollMin = 10 * time.Microsecond
+	walkPollMax = 10 * time.Millisecond
+)
+
 // Fwd represents a forwarding thread.
 type Fwd struct {
 	ealthread.Thread
@@ -31,6 +39,8 @@ type Fwd struct {
 	queueI *iface.PktQueue
 	queueD *iface.PktQueue
 	queueN *iface.PktQueue
+
+	walkLock sync.Mutex
 }
 
 func newFwd(id int) *Fwd {
@@ -101,6 +111,39 @@ func (fwd *Fwd) Close() error {
 	return nil
 }
 
+// Walk performs a PCCT walk, collecting PIT or CS entries or erasing CS entries of this forwarding thread.
+// If the thread is running, the walk is performed incrementally by the thread between packet bursts,
+// and this function blocks until it completes.
+// If the thread stops before completing the walk, the walk resumes from its saved position on the
+// calling thread.
+func (fwd *Fwd) Walk(w *pcct.Walk) {
+	fwd.walkLock.Lock()
+	defer fwd.walkLock.Unlock()
+
+	if !fwd.IsRunning() {
+		w.Run(fwd.pcct)
+		return
+	}
+
+	C.FwFwd_PostWalk(fwd.c, (*C.PcctWalk)(w.Ptr()))
+	for delay := walkPollMin; C.FwFwd_HasWalk(fwd.c); {
+		if !fwd.IsRunning() { // thread stopped before completing the walk
+			C.FwFwd_ClearWalk(fwd.c)
+			w.Run(fwd.pcct)
+			return
+		}
+		time.Sleep(delay)
+		if delay *= 2; delay > walkPollMax {
+			delay = walkPollMax
+		}
+	}
+}
+
+// ThreadLoadStat implements ealthread.ThreadWithLoadStat.
+func (fwd *Fwd) ThreadLoadStat() ealthread.LoadStat {
+	return ealthread.LoadStatFromPtr(unsafe.Pointer(&fwd.c.loadStat))
+}
+
 // NumaSocket implements fib.LookupThread.
 func (fwd *Fwd) NumaSocket() eal.NumaSocket {
 	return fwd.Thread.LCore().NumaSocket()
@@ -0,0 +1,66 @@
+package fwdptest
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+func TestAcl(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3)
+	fixture.SetFibEntry("/A", "multicast", face2.ID, face3.ID)
+
+	assert.Error(face1.D.SetAclRules(iface.AclInbound, []iface.AclRule{
+		{Prefix: ndn.ParseName("/A"), PktTypes: []ndni.PktType{ndni.PktFragment}},
+	}))
+	assert.Len(face1.D.AclRules(iface.AclInbound), 0)
+
+	require.NoError(face1.D.SetAclRules(iface.AclInbound, []iface.AclRule{
+		{Prefix: ndn.ParseName("/A/public"), Allow: true},
+		{Prefix: ndn.ParseName("/A"), PktTypes: []ndni.PktType{ndni.PktInterest}},
+	}))
+	require.NoError(face3.D.SetAclRules(iface.AclOutbound, []iface.AclRule{
+		{Prefix: ndn.ParseName("/A")},
+	}))
+
+	face1.Tx <- ndn.MakeInterest("/A/private/1")
+	face1.Tx <- ndn.MakeInterest("/A/public/1")
+	fixture.StepDelay()
+	assert.Equal(0, collect1.Count())
+	assert.Equal(1, collect2.Count())
+	assert.Equal(0, collect3.Count())
+
+	face2.Tx <- ndn.MakeData(collect2.Get(-1).Interest)
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+
+	inRules := face1.D.AclRules(iface.AclInbound)
+	require.Len(inRules, 2)
+	assert.True(inRules[0].Prefix.Equal(ndn.ParseName("/A/public")))
+	assert.Len(inRules[0].PktTypes, 3)
+	assert.True(inRules[0].Allow)
+	assert.EqualValues(1, inRules[0].NMatches)
+	assert.Equal([]ndni.PktType{ndni.PktInterest}, inRules[1].PktTypes)
+	assert.False(inRules[1].Allow)
+	assert.EqualValues(1, inRules[1].NMatches)
+
+	outRules := face3.D.AclRules(iface.AclOutbound)
+	require.Len(outRules, 1)
+	assert.EqualValues(1, outRules[0].NMatches)
+
+	assert.Equal(uint64(2), fixture.SumCounter(func(dp *fwdp.DataPlane, i int) uint64 {
+		return dp.ReadFwdInfo(i).NAclDrops
+	}))
+
+	require.NoError(face1.D.SetAclRules(iface.AclInbound, nil))
+	assert.Len(face1.D.AclRules(iface.AclInbound), 0)
+}
@@ -0,0 +1,93 @@
+package fwdptest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+func TestCongMarkPropagate(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2 := intface.MustNew(), intface.MustNew()
+	collect1, collect2 := intface.Collect(face1), intface.Collect(face2)
+	fixture.SetFibEntry("/A", "multicast", face2.ID)
+
+	// upstream Data carries CongestionMark
+	face1.Tx <- ndn.MakeInterest("/A/1", lphToken(0x2f1cbd9c0ed6ed04))
+	fixture.StepDelay()
+	if packet := collect2.Get(-1); assert.NotNil(packet) {
+		lp := packet.Lp
+		lp.CongMark = 1
+		face2.Tx <- ndn.MakeData(packet.Interest, lp)
+	}
+	fixture.StepDelay()
+	if packet := collect1.Get(-1); assert.NotNil(packet) && assert.NotNil(packet.Data) {
+		assert.Equal(1, packet.Lp.CongMark)
+	}
+
+	// upstream Nack carries CongestionMark
+	face1.Tx <- ndn.MakeInterest("/A/2", lphToken(0x5d8f2e9b3c4a1706))
+	fixture.StepDelay()
+	if packet := collect2.Get(-1); assert.NotNil(packet) {
+		lp := packet.Lp
+		lp.CongMark = 1
+		face2.Tx <- ndn.MakeNack(packet.Interest, an.NackNoRoute, lp)
+	}
+	fixture.StepDelay()
+	if packet := collect1.Get(-1); assert.NotNil(packet) && assert.NotNil(packet.Nack) {
+		assert.Equal(1, packet.Lp.CongMark)
+	}
+}
+
+func TestCongMarkTxQueue(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	const nInterests = 32
+	var cfg1 socketface.Config
+	cfg1.CongMarkThreshold = 4
+	cfg1.TxQueueSize = 2 * nInterests
+	face1, face2 := intface.Must(intface.New(cfg1)), intface.MustNew()
+	collect1, collect2 := intface.Collect(face1), intface.Collect(face2)
+	fixture.SetFibEntry("/A", "multicast", face2.ID)
+
+	for i := 0; i < nInterests; i++ {
+		face1.Tx <- ndn.MakeInterest(fmt.Sprintf("/A/%d", i))
+	}
+	fixture.StepDelay()
+	require.Equal(nInterests, collect2.Count())
+
+	// stop draining face1 before-Tx queue, so that Data accumulate beyond CongMarkThreshold
+	iface.DeactivateTxFace(face1.D)
+	collect2.Peek(func(received []*ndn.Packet) {
+		for _, packet := range received {
+			face2.Tx <- ndn.MakeData(packet.Interest)
+		}
+	})
+	fixture.StepDelay()
+	iface.ActivateTxFace(face1.D)
+	fixture.StepDelay()
+
+	require.Equal(nInterests, collect1.Count())
+	nMarked := 0
+	collect1.Peek(func(received []*ndn.Packet) {
+		for _, packet := range received {
+			assert.NotNil(packet.Data)
+			if packet.Lp.CongMark != 0 {
+				nMarked++
+			}
+		}
+	})
+	assert.NotZero(nMarked)
+	assert.Less(nMarked, nInterests)
+	assert.EqualValues(nMarked, face1.D.ReadCounters().TxClasses[0].NCongMarks)
+}
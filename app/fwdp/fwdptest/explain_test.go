@@ -0,0 +1,61 @@
+package fwdptest
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestExplain(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect2 := intface.Collect(face2)
+	fixture.SetFibEntry("/A", "multicast", face1.ID, face2.ID)
+	fixture.SetFibEntry("/B", "multicast", face3.ID)
+
+	x, e := fixture.DataPlane.Explain(fwdp.ExplainInterest{Name: ndn.ParseName("/Z/1")})
+	require.NoError(e)
+	assert.Equal(fwdp.ExplainNoRoute, x.Verdict)
+	assert.Nil(x.FibLookup)
+
+	x, e = fixture.DataPlane.Explain(fwdp.ExplainInterest{Name: ndn.ParseName("/A/1"), Face: face1.ID})
+	require.NoError(e)
+	assert.Equal(fwdp.ExplainPitNew, x.Verdict)
+	if assert.NotNil(x.FibLookup) {
+		assert.Equal(1, x.FibLookup.Lpm.MatchLength)
+		assert.Equal(1, x.FibLookup.NEligible())
+		if assert.Len(x.FibLookup.Nexthops, 2) {
+			assert.Equal(fwdp.ExplainNexthopDownstream, x.FibLookup.Nexthops[0].Reason)
+			assert.True(x.FibLookup.Nexthops[1].Eligible())
+		}
+	}
+	assert.Equal("multicast", x.Strategy.Name())
+
+	x, e = fixture.DataPlane.Explain(fwdp.ExplainInterest{
+		Name:   ndn.ParseName("/A/1"),
+		FwHint: []ndn.Name{ndn.ParseName("/Z"), ndn.ParseName("/B")},
+	})
+	require.NoError(e)
+	assert.Len(x.FibLookups, 2)
+	assert.True(x.FwHint.Equal(ndn.ParseName("/B")))
+
+	face1.Tx <- ndn.MakeInterest("/A/1", lphToken(0xc8e6cbe8d0b6a4e4))
+	fixture.StepDelay()
+	require.Equal(1, collect2.Count())
+	x, e = fixture.DataPlane.Explain(fwdp.ExplainInterest{Name: ndn.ParseName("/A/1"), Face: face3.ID})
+	require.NoError(e)
+	assert.Equal(fwdp.ExplainPitFound, x.Verdict)
+	assert.NotNil(x.PitEntry)
+
+	face2.Tx <- ndn.MakeData(collect2.Get(-1).Interest)
+	fixture.StepDelay()
+	x, e = fixture.DataPlane.Explain(fwdp.ExplainInterest{Name: ndn.ParseName("/A/1"), Face: face3.ID})
+	require.NoError(e)
+	assert.Equal(fwdp.ExplainCsHit, x.Verdict)
+	assert.NotNil(x.CsEntry)
+}
@@ -56,6 +56,7 @@ func NewFixture(t *testing.T) (fixture *Fixture) {
 	dpCfg.Pcct.MaxEntries = 65535
 	dpCfg.Pcct.CsCapMd = 16384
 	dpCfg.Pcct.CsCapMi = 16384
+	dpCfg.Pcct.DeadNonceCapacity = 4096
 
 	dpCfg.LatencySampleFreq = 0
 
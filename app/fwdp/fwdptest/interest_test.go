@@ -74,6 +74,52 @@ func TestInterestDupNonce(t *testing.T) {
 	assert.Equal(0, collect2.Count())
 }
 
+func TestInterestDeadNonce(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3)
+	fixture.SetFibEntry("/A", "multicast", face3.ID)
+
+	face1.Tx <- ndn.MakeInterest("/A/1", ndn.NonceFromUint(0x1b2c8d3e), 100*time.Millisecond)
+	fixture.StepDelay()
+	assert.Equal(1, collect3.Count())
+
+	// PIT entry expires unsatisfied, and the looping Interest is detected by Dead Nonce List
+	time.Sleep(200 * time.Millisecond)
+	face2.Tx <- ndn.MakeInterest("/A/1", ndn.NonceFromUint(0x1b2c8d3e), lphToken(0x5e4ab2f8e0a5c0d1))
+	fixture.StepDelay()
+	assert.Equal(1, collect3.Count())
+	assert.Equal(1, collect2.Count())
+	if packet := collect2.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(an.NackDuplicate, packet.Nack.Reason)
+		assert.Equal(uint64(0x5e4ab2f8e0a5c0d1), ndn.PitTokenToUint(packet.Lp.PitToken))
+	}
+	assert.Equal(uint64(1), fixture.SumCounter(func(dp *fwdp.DataPlane, i int) uint64 {
+		return dp.GetFwdPit(i).ReadCounters().NDeadNonceHit
+	}))
+
+	// satisfied PIT entry does not insert into Dead Nonce List, and the Interest is answered from CS
+	face1.Tx <- ndn.MakeInterest("/A/2", ndn.NonceFromUint(0x7d04c1a9))
+	fixture.StepDelay()
+	assert.Equal(2, collect3.Count())
+	face3.Tx <- ndn.MakeData(collect3.Get(-1).Interest)
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+	assert.NotNil(collect1.Get(-1).Data)
+
+	face2.Tx <- ndn.MakeInterest("/A/2", ndn.NonceFromUint(0x7d04c1a9))
+	fixture.StepDelay()
+	assert.Equal(2, collect3.Count())
+	assert.Equal(2, collect2.Count())
+	assert.NotNil(collect2.Get(-1).Data)
+	assert.Equal(uint64(1), fixture.SumCounter(func(dp *fwdp.DataPlane, i int) uint64 {
+		return dp.GetFwdPit(i).ReadCounters().NDeadNonceHit
+	}))
+}
+
 func TestInterestSuppress(t *testing.T) {
 	assert, _ := makeAR(t)
 	fixture := NewFixture(t)
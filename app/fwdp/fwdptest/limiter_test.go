@@ -0,0 +1,124 @@
+package fwdptest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+func TestLimiter(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3)
+	fixture.SetFibEntry("/A", "multicast", face3.ID)
+	fixture.SetFibEntry("/B", "multicast", face3.ID)
+
+	dp := fixture.DataPlane
+	assert.Error(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Prefix: ndn.ParseName("/A"), Rate: 1, Burst: 1},
+	}))
+	assert.Error(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Rate: 0, Burst: 1},
+	}))
+	assert.Error(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Rate: 1, Burst: math.MaxInt64},
+	}))
+	assert.Error(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Rate: math.MaxInt64, Burst: 1},
+	}))
+	assert.Error(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Prefix: ndn.ParseName("/B"), Rate: 1, Burst: 1},
+		{Prefix: ndn.ParseName("/B"), Rate: 2, Burst: 2},
+	}))
+	assert.Len(dp.LimiterRules(), 0)
+
+	require.NoError(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Prefix: ndn.ParseName("/B"), Rate: 1, Burst: 4, Action: fwdp.LimiterDrop},
+		{Face: face1.ID, Rate: 1, Burst: 6, Action: fwdp.LimiterNack},
+	}))
+
+	for i := 0; i < 10; i++ {
+		face1.Tx <- ndn.MakeInterest(fmt.Sprintf("/A/%d", i))
+		face2.Tx <- ndn.MakeInterest(fmt.Sprintf("/B/%d", i))
+	}
+	fixture.StepDelay()
+
+	assert.Equal(4, collect1.Count())
+	collect1.Peek(func(received []*ndn.Packet) {
+		for _, packet := range received {
+			if assert.NotNil(packet.Nack) {
+				assert.EqualValues(an.NackCongestion, packet.Nack.Reason)
+			}
+		}
+	})
+	assert.Equal(0, collect2.Count())
+	assert.Equal(10, collect3.Count())
+
+	rules := dp.LimiterRules()
+	require.Len(rules, 2)
+	assert.Equal(face1.ID, rules[0].Face)
+	assert.EqualValues(6, rules[0].NPassed)
+	assert.EqualValues(4, rules[0].NShed)
+	assert.True(rules[1].Prefix.Equal(ndn.ParseName("/B")))
+	assert.Equal(1, rules[1].Rate)
+	assert.Equal(4, rules[1].Burst)
+	assert.EqualValues(4, rules[1].NPassed)
+	assert.EqualValues(6, rules[1].NShed)
+
+	require.NoError(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Rate: 1, Burst: 1, Action: fwdp.LimiterNack},
+	}))
+	rules = dp.LimiterRules()
+	require.Len(rules, 1)
+	assert.EqualValues(6, rules[0].NPassed)
+	assert.EqualValues(4, rules[0].NShed)
+
+	require.NoError(dp.SetLimiterRules(nil))
+	assert.Len(dp.LimiterRules(), 0)
+}
+
+func TestLimiterRefund(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2 := intface.MustNew(), intface.MustNew()
+	collect2 := intface.Collect(face2)
+	fixture.SetFibEntry("/B", "multicast", face2.ID)
+
+	dp := fixture.DataPlane
+	require.NoError(dp.SetLimiterRules([]fwdp.LimiterRule{
+		{Face: face1.ID, Rate: 1, Burst: 6, Action: fwdp.LimiterDrop},
+		{Prefix: ndn.ParseName("/B"), Rate: 1, Burst: 4, Action: fwdp.LimiterDrop},
+	}))
+	defer dp.SetLimiterRules(nil)
+
+	for i := 0; i < 10; i++ {
+		face1.Tx <- ndn.MakeInterest(fmt.Sprintf("/B/%d", i))
+	}
+	fixture.StepDelay()
+	assert.Equal(4, collect2.Count())
+
+	rules := dp.LimiterRules()
+	require.Len(rules, 2)
+	assert.EqualValues(4, rules[0].NPassed) // tokens are refunded when prefix limiter rejects
+	assert.EqualValues(0, rules[0].NShed)
+	assert.EqualValues(4, rules[1].NPassed)
+	assert.EqualValues(6, rules[1].NShed)
+
+	for i := 10; i < 12; i++ {
+		face1.Tx <- ndn.MakeInterest(fmt.Sprintf("/A/%d", i))
+	}
+	fixture.StepDelay()
+	rules = dp.LimiterRules()
+	assert.EqualValues(6, rules[0].NPassed)
+	assert.EqualValues(0, rules[0].NShed)
+}
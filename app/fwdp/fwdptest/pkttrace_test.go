@@ -0,0 +1,64 @@
+package fwdptest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/mgmt/pkttrace"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestPktTrace(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	require.NoError(pkttrace.Init())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records, e := pkttrace.Subscribe(ctx)
+	require.NoError(e)
+
+	face1, face2 := intface.MustNew(), intface.MustNew()
+	collect2 := intface.Collect(face2)
+	fixture.SetFibEntry("/P", "multicast", face2.ID)
+
+	require.NoError(pkttrace.Start(pkttrace.Filter{Prefix: ndn.ParseName("/P/A")}))
+	defer pkttrace.Stop()
+
+	face1.Tx <- ndn.MakeInterest("/P/A/1")
+	face1.Tx <- ndn.MakeInterest("/P/B/1")
+	fixture.StepDelay()
+	assert.Equal(2, collect2.Count())
+
+	stages := map[uint32]map[pkttrace.Stage]bool{}
+	nameA, nameB := ndn.ParseName("/P/A/1"), ndn.ParseName("/P/B/1")
+	timeout := time.After(fixture.StepUnit)
+COLLECT:
+	for {
+		select {
+		case value := <-records:
+			rec := value.(pkttrace.Record)
+			assert.False(rec.Name.Equal(nameB))
+			if rec.Stage == pkttrace.StageRx {
+				assert.True(rec.Name.Equal(nameA))
+			}
+			if stages[rec.ID] == nil {
+				stages[rec.ID] = map[pkttrace.Stage]bool{}
+			}
+			stages[rec.ID][rec.Stage] = true
+		case <-timeout:
+			break COLLECT
+		}
+	}
+
+	require.Len(stages, 1)
+	for _, m := range stages {
+		for _, stage := range []pkttrace.Stage{pkttrace.StageRx, pkttrace.StageDemux, pkttrace.StageFwd, pkttrace.StagePitCs, pkttrace.StageTx} {
+			assert.True(m[stage], stage)
+		}
+	}
+	assert.NotZero(pkttrace.ReadCounters().NRecords)
+}
@@ -0,0 +1,54 @@
+package fwdptest
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestScope(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	var nonLocalCfg socketface.Config
+	nonLocalCfg.Scope = iface.ScopeNonLocal
+	local1, local2 := intface.MustNew(), intface.MustNew()
+	remote1, remote2 := intface.Must(intface.New(nonLocalCfg)), intface.Must(intface.New(nonLocalCfg))
+	assert.True(local1.D.IsLocal())
+	assert.False(remote1.D.IsLocal())
+	collectL2, collectR2 := intface.Collect(local2), intface.Collect(remote2)
+	fixture.SetFibEntry("/localhost", "multicast", local2.ID, remote2.ID)
+	fixture.SetFibEntry("/localhop", "multicast", local2.ID, remote2.ID)
+
+	remote1.Tx <- ndn.MakeInterest("/localhost/1")
+	fixture.StepDelay()
+	assert.Equal(0, collectL2.Count())
+	assert.Equal(0, collectR2.Count())
+
+	local1.Tx <- ndn.MakeInterest("/localhost/2")
+	fixture.StepDelay()
+	assert.Equal(1, collectL2.Count())
+	assert.Equal(0, collectR2.Count())
+
+	remote1.Tx <- ndn.MakeInterest("/localhop/3")
+	fixture.StepDelay()
+	assert.Equal(2, collectL2.Count())
+	assert.Equal(0, collectR2.Count())
+
+	local1.Tx <- ndn.MakeInterest("/localhop/4")
+	fixture.StepDelay()
+	assert.Equal(3, collectL2.Count())
+	assert.Equal(1, collectR2.Count())
+
+	assert.Equal(uint64(2), fixture.SumCounter(func(dp *fwdp.DataPlane, i int) uint64 {
+		return dp.ReadFwdInfo(i).NLocalhostViolations
+	}))
+	assert.Equal(uint64(1), fixture.SumCounter(func(dp *fwdp.DataPlane, i int) uint64 {
+		return dp.ReadFwdInfo(i).NLocalhopViolations
+	}))
+}
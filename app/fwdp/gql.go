@@ -0,0 +1,683 @@
+package fwdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/container/cs"
+	"github.com/usnistgov/ndn-dpdk/container/fib"
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/container/pit"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GqlDataPlane is the DataPlane instance accessible via GraphQL.
+var GqlDataPlane *DataPlane
+
+var errNoGqlDataPlane = errors.New("DataPlane unavailable")
+
+// GraphQL types.
+var (
+	GqlInputNodeType        *gqlserver.NodeType
+	GqlInputType            *graphql.Object
+	GqlFwdNodeType          *gqlserver.NodeType
+	GqlFwdType              *graphql.Object
+	GqlDataPlaneType        *graphql.Object
+	GqlLimiterActionType    *graphql.Enum
+	GqlLimiterRuleType      *graphql.Object
+	GqlLimiterRuleInputType *graphql.InputObject
+	GqlExplainNexthopType   *graphql.Object
+	GqlExplainFibLookupType *graphql.Object
+	GqlExplanationType      *graphql.Object
+)
+
+func gqlWalk(p graphql.ResolveParams, kind pcct.WalkKind) (*pcct.Walk, error) {
+	if GqlDataPlane == nil {
+		return nil, errNoGqlDataPlane
+	}
+
+	index := p.Args["fwd"].(int)
+	if index < 0 || index >= len(GqlDataPlane.fwds) {
+		return nil, fmt.Errorf("fwd %d does not exist", index)
+	}
+	fwd := GqlDataPlane.fwds[index]
+
+	prefix, _ := p.Args["prefix"].(ndn.Name)
+	w, e := pcct.NewWalk(kind, prefix, p.Args["offset"].(int), p.Args["limit"].(int), fwd.NumaSocket())
+	if e != nil {
+		return nil, e
+	}
+	fwd.Walk(w)
+	return w, nil
+}
+
+func makeGqlWalkArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"fwd": &graphql.ArgumentConfig{
+			Description: "Forwarding thread index.",
+			Type:        gqlserver.NonNullInt,
+		},
+		"prefix": &graphql.ArgumentConfig{
+			Description: "Filter by name prefix.",
+			Type:        ndni.GqlNameType,
+		},
+		"offset": &graphql.ArgumentConfig{
+			Description:  "Number of matching entries to skip.",
+			Type:         graphql.Int,
+			DefaultValue: 0,
+		},
+		"limit": &graphql.ArgumentConfig{
+			Description:  fmt.Sprintf("Maximum number of entries to return, up to %d.", pcct.MaxWalkLimit),
+			Type:         graphql.Int,
+			DefaultValue: 100,
+		},
+	}
+}
+
+func parseGqlLimiterRules(arg interface{}) (rules []LimiterRule, e error) {
+	for i, item := range arg.([]interface{}) {
+		m := item.(map[string]interface{})
+		rule := LimiterRule{
+			Rate:   m["rate"].(int),
+			Burst:  m["burst"].(int),
+			Action: m["action"].(LimiterAction),
+		}
+		if id, ok := m["face"]; ok && id != nil {
+			face, e := gqlserver.RetrieveNodeOfType(iface.GqlFaceNodeType, id)
+			if face == nil || e != nil {
+				return nil, fmt.Errorf("rules[%d].face not found: %w", i, e)
+			}
+			rule.Face = face.(iface.Face).ID()
+		}
+		rule.Prefix, _ = m["prefix"].(ndn.Name)
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseGqlExplainInterest(args map[string]interface{}) (interest ExplainInterest, e error) {
+	interest.Name = args["name"].(ndn.Name)
+	interest.CanBePrefix, _ = args["canBePrefix"].(bool)
+	interest.MustBeFresh, _ = args["mustBeFresh"].(bool)
+	if fwHint, ok := args["fwHint"].([]interface{}); ok {
+		for _, fh := range fwHint {
+			interest.FwHint = append(interest.FwHint, fh.(ndn.Name))
+		}
+	}
+	if id, ok := args["face"]; ok && id != nil {
+		face, e := gqlserver.RetrieveNodeOfType(iface.GqlFaceNodeType, id)
+		if face == nil || e != nil {
+			return interest, fmt.Errorf("face not found: %w", e)
+		}
+		interest.Face = face.(iface.Face).ID()
+	}
+	return interest, nil
+}
+
+func gqlRetrieveIndex(id string, n int) (int, bool) {
+	index, e := strconv.Atoi(id)
+	return index, e == nil && index >= 0 && index < n
+}
+
+func init() {
+	GqlInputNodeType = gqlserver.NewNodeTypeNamed("FwdpInput", (*Input)(nil))
+	GqlInputNodeType.Retrieve = func(id string) (interface{}, error) {
+		if GqlDataPlane == nil {
+			return nil, nil
+		}
+		if index, ok := gqlRetrieveIndex(id, len(GqlDataPlane.inputs)); ok {
+			return GqlDataPlane.inputs[index], nil
+		}
+		return nil, nil
+	}
+
+	GqlInputType = graphql.NewObject(GqlInputNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "FwdpInput",
+		Description: "Forwarder input thread.",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Description: "Input thread index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Input).id, nil
+				},
+			},
+			"worker": &graphql.Field{
+				Description: "Worker LCore.",
+				Type:        graphql.NewNonNull(ealthread.GqlWorkerType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Input).rxl.LCore(), nil
+				},
+			},
+		},
+	}))
+	GqlInputNodeType.Register(GqlInputType)
+
+	GqlFwdNodeType = gqlserver.NewNodeTypeNamed("FwdpFwd", (*Fwd)(nil))
+	GqlFwdNodeType.Retrieve = func(id string) (interface{}, error) {
+		if GqlDataPlane == nil {
+			return nil, nil
+		}
+		if index, ok := gqlRetrieveIndex(id, len(GqlDataPlane.fwds)); ok {
+			return GqlDataPlane.fwds[index], nil
+		}
+		return nil, nil
+	}
+
+	GqlFwdType = graphql.NewObject(GqlFwdNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "FwdpFwd",
+		Description: "Forwarding thread.",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Description: "Forwarding thread index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Fwd).id, nil
+				},
+			},
+			"worker": &graphql.Field{
+				Description: "Worker LCore.",
+				Type:        graphql.NewNonNull(ealthread.GqlWorkerType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Fwd).LCore(), nil
+				},
+			},
+			"counters": &graphql.Field{
+				Description: "Forwarding thread counters, including input queues and latency.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if GqlDataPlane == nil {
+						return nil, errNoGqlDataPlane
+					}
+					return GqlDataPlane.ReadFwdInfo(p.Source.(*Fwd).id), nil
+				},
+			},
+			"pitCounters": &graphql.Field{
+				Description: "PIT counters.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return pit.FromPcct(p.Source.(*Fwd).pcct).ReadCounters(), nil
+				},
+			},
+			"csCounters": &graphql.Field{
+				Description: "CS counters.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if GqlDataPlane == nil {
+						return nil, errNoGqlDataPlane
+					}
+					return GqlDataPlane.ReadCsCounters(p.Source.(*Fwd).id), nil
+				},
+			},
+		},
+	}))
+	GqlFwdNodeType.Register(GqlFwdType)
+
+	GqlDataPlaneType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FwdpDataPlane",
+		Description: "Forwarder data plane.",
+		Fields: graphql.Fields{
+			"inputs": &graphql.Field{
+				Description: "Input threads.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlInputType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*DataPlane).inputs, nil
+				},
+			},
+			"fwds": &graphql.Field{
+				Description: "Forwarding threads.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlFwdType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*DataPlane).fwds, nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "fwdp",
+		Description: "Forwarder data plane.",
+		Type:        GqlDataPlaneType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			return GqlDataPlane, nil
+		},
+	})
+
+	GqlLimiterActionType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "LimiterAction",
+		Description: "Action on an Interest exceeding a rate limit.",
+		Values: graphql.EnumValueConfigMap{
+			"DROP": &graphql.EnumValueConfig{
+				Value:       LimiterDrop,
+				Description: "Drop the Interest.",
+			},
+			"NACK": &graphql.EnumValueConfig{
+				Value:       LimiterNack,
+				Description: "Reply a Nack of reason Congestion.",
+			},
+		},
+	})
+
+	GqlLimiterRuleType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LimiterRule",
+		Description: "Token bucket Interest rate limiter.",
+		Fields: graphql.Fields{
+			"face": &graphql.Field{
+				Description: "Incoming face of a per-face limiter. null indicates a per-prefix limiter or a deleted face.",
+				Type:        iface.GqlFaceType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					if !info.IsFaceRule() {
+						return nil, nil
+					}
+					return iface.Get(info.Face), nil
+				},
+			},
+			"prefix": &graphql.Field{
+				Description: "Interest name prefix of a per-prefix limiter. null indicates a per-face limiter.",
+				Type:        ndni.GqlNameType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return gqlserver.Optional(info.Prefix, !info.IsFaceRule()), nil
+				},
+			},
+			"rate": &graphql.Field{
+				Description: "Sustained rate in Interests per second.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return info.Rate, nil
+				},
+			},
+			"burst": &graphql.Field{
+				Description: "Bucket capacity in Interests.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return info.Burst, nil
+				},
+			},
+			"action": &graphql.Field{
+				Description: "Action on an Interest exceeding the rate limit.",
+				Type:        graphql.NewNonNull(GqlLimiterActionType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return info.Action, nil
+				},
+			},
+			"nPassed": &graphql.Field{
+				Description: "Number of Interests within the rate limit.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return info.NPassed, nil
+				},
+			},
+			"nShed": &graphql.Field{
+				Description: "Number of Interests dropped or Nacked due to the rate limit.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(LimiterRuleInfo)
+					return info.NShed, nil
+				},
+			},
+		},
+	})
+
+	GqlLimiterRuleInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "LimiterRuleInput",
+		Description: "Token bucket Interest rate limiter. Either face or prefix should be specified.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"face": &graphql.InputObjectFieldConfig{
+				Description: "Incoming face of a per-face limiter.",
+				Type:        graphql.ID,
+			},
+			"prefix": &graphql.InputObjectFieldConfig{
+				Description: "Interest name prefix of a per-prefix limiter.",
+				Type:        ndni.GqlNameType,
+			},
+			"rate": &graphql.InputObjectFieldConfig{
+				Description: "Sustained rate in Interests per second.",
+				Type:        gqlserver.NonNullInt,
+			},
+			"burst": &graphql.InputObjectFieldConfig{
+				Description: "Bucket capacity in Interests.",
+				Type:        gqlserver.NonNullInt,
+			},
+			"action": &graphql.InputObjectFieldConfig{
+				Description:  "Action on an Interest exceeding the rate limit.",
+				Type:         GqlLimiterActionType,
+				DefaultValue: LimiterNack,
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "pitEntries",
+		Description: "List of PIT entries in a forwarding thread, in insertion order of their PCC entries.",
+		Args:        makeGqlWalkArgs(),
+		Type:        graphql.NewList(graphql.NewNonNull(pit.GqlEntryType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			w, e := gqlWalk(p, pcct.WalkPit)
+			if e != nil {
+				return nil, e
+			}
+			defer w.Close()
+			return pit.ListEntries(w), nil
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "csEntries",
+		Description: "List of CS entries in a forwarding thread, in insertion order of their PCC entries.",
+		Args:        makeGqlWalkArgs(),
+		Type:        graphql.NewList(graphql.NewNonNull(cs.GqlEntryType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			w, e := gqlWalk(p, pcct.WalkCs)
+			if e != nil {
+				return nil, e
+			}
+			defer w.Close()
+			return cs.ListEntries(w), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "csErase",
+		Description: "Erase CS entries under a name prefix in all forwarding threads. Returns number of erased entries.",
+		Args: graphql.FieldConfigArgument{
+			"prefix": &graphql.ArgumentConfig{
+				Description: "Name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+		},
+		Type: gqlserver.NonNullInt,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			return GqlDataPlane.CsErase(p.Args["prefix"].(ndn.Name))
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "csAdmitRules",
+		Description: "CS admission rules, with counters summed across forwarding threads.",
+		Type:        graphql.NewList(graphql.NewNonNull(cs.GqlAdmitRuleType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			return GqlDataPlane.CsAdmitRules(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setCsAdmitRules",
+		Description: "Replace CS admission rules in all forwarding threads.",
+		Args: graphql.FieldConfigArgument{
+			"rules": &graphql.ArgumentConfig{
+				Description: fmt.Sprintf("Admission rules, up to %d. Data is admitted under the rule with longest matching prefix.", cs.MaxAdmitRules),
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cs.GqlAdmitRuleInputType))),
+			},
+		},
+		Type: graphql.NewList(graphql.NewNonNull(cs.GqlAdmitRuleType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			if e := GqlDataPlane.SetCsAdmitRules(cs.ParseGqlAdmitRules(p.Args["rules"])); e != nil {
+				return nil, e
+			}
+			return GqlDataPlane.CsAdmitRules(), nil
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "limiterRules",
+		Description: "Interest rate limiters, with counters of admitted and shed Interests.",
+		Type:        graphql.NewList(graphql.NewNonNull(GqlLimiterRuleType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			return GqlDataPlane.LimiterRules(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setLimiterRules",
+		Description: "Replace Interest rate limiters.",
+		Args: graphql.FieldConfigArgument{
+			"rules": &graphql.ArgumentConfig{
+				Description: fmt.Sprintf("Rate limiters, up to %d. An Interest is subject to the limiter of its incoming face and the limiter with longest matching prefix.", MaxLimiterRules),
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlLimiterRuleInputType))),
+			},
+		},
+		Type: graphql.NewList(graphql.NewNonNull(GqlLimiterRuleType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			rules, e := parseGqlLimiterRules(p.Args["rules"])
+			if e != nil {
+				return nil, e
+			}
+			if e := GqlDataPlane.SetLimiterRules(rules); e != nil {
+				return nil, e
+			}
+			return GqlDataPlane.LimiterRules(), nil
+		},
+	})
+
+	GqlExplainNexthopType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FwdpExplainNexthop",
+		Description: "FIB nexthop in an explanation.",
+		Fields: graphql.Fields{
+			"face": &graphql.Field{
+				Description: "Nexthop face. null indicates a deleted face.",
+				Type:        iface.GqlFaceType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return iface.Get(p.Source.(ExplainNexthop).Face), nil
+				},
+			},
+			"faceId": &graphql.Field{
+				Description: "Numeric face ID.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(ExplainNexthop).Face), nil
+				},
+			},
+			"eligible": &graphql.Field{
+				Description: "Whether the strategy may forward the Interest to this nexthop.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ExplainNexthop).Eligible(), nil
+				},
+			},
+			"reason": &graphql.Field{
+				Description: "Why the nexthop is ineligible: 'downstream' or 'face-down'. null if eligible.",
+				Type:        graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					nh := p.Source.(ExplainNexthop)
+					return gqlserver.Optional(nh.Reason, !nh.Eligible()), nil
+				},
+			},
+		},
+	})
+
+	GqlExplainFibLookupType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FwdpExplainFibLookup",
+		Description: "FIB longest prefix match in an explanation.",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Lookup name, either the Interest name or a forwarding hint.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ExplainFibLookup).Name, nil
+				},
+			},
+			"lpm": &graphql.Field{
+				Description: "2-stage LPM steps, see fibreplica.LpmTrace.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ExplainFibLookup).Lpm, nil
+				},
+			},
+			"entry": &graphql.Field{
+				Description: "Matched FIB entry. null indicates no match.",
+				Type:        fib.GqlEntryType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if entry := p.Source.(ExplainFibLookup).Entry; entry != nil {
+						return *entry, nil
+					}
+					return nil, nil
+				},
+			},
+			"nexthops": &graphql.Field{
+				Description: "Nexthops of the matched FIB entry.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlExplainNexthopType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ExplainFibLookup).Nexthops, nil
+				},
+			},
+			"nEligible": &graphql.Field{
+				Description: "Number of eligible nexthops.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ExplainFibLookup).NEligible(), nil
+				},
+			},
+		},
+	})
+
+	GqlExplanationType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FwdpExplanation",
+		Description: "How the forwarder would process an Interest.",
+		Fields: graphql.Fields{
+			"ndtIndex": &graphql.Field{
+				Description: "NDT table index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Explanation).NdtIndex, nil
+				},
+			},
+			"fwd": &graphql.Field{
+				Description: "Forwarding thread chosen by the NDT.",
+				Type:        graphql.NewNonNull(GqlFwdType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return GqlDataPlane.fwds[p.Source.(Explanation).Fwd], nil
+				},
+			},
+			"fibLookups": &graphql.Field{
+				Description: "FIB lookups, in order.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlExplainFibLookupType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Explanation).FibLookups, nil
+				},
+			},
+			"fibLookup": &graphql.Field{
+				Description: "FIB lookup whose entry would be used. null indicates no route.",
+				Type:        GqlExplainFibLookupType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if lookup := p.Source.(Explanation).FibLookup; lookup != nil {
+						return *lookup, nil
+					}
+					return nil, nil
+				},
+			},
+			"fwHint": &graphql.Field{
+				Description: "Active forwarding hint. null indicates none.",
+				Type:        ndni.GqlNameType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					x := p.Source.(Explanation)
+					return gqlserver.Optional(x.FwHint, len(x.FwHint) > 0), nil
+				},
+			},
+			"strategy": &graphql.Field{
+				Description: "Forwarding strategy of the FIB entry.",
+				Type:        strategycode.GqlStrategyType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Explanation).Strategy, nil
+				},
+			},
+			"csEntry": &graphql.Field{
+				Description: "CS entry that would satisfy the Interest.",
+				Type:        cs.GqlEntryType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if entry := p.Source.(Explanation).CsEntry; entry != nil {
+						return *entry, nil
+					}
+					return nil, nil
+				},
+			},
+			"pitEntry": &graphql.Field{
+				Description: "Existing PIT entry that the Interest would join.",
+				Type:        pit.GqlEntryType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if entry := p.Source.(Explanation).PitEntry; entry != nil {
+						return *entry, nil
+					}
+					return nil, nil
+				},
+			},
+			"verdict": &graphql.Field{
+				Description: "Outcome: 'no-route', 'cs-hit', 'pit-found', or 'pit-new'.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Explanation).Verdict, nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "explain",
+		Description: "Explain how the forwarder would process an Interest, without forwarding anything.",
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Description: "Interest name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"canBePrefix": &graphql.ArgumentConfig{
+				Description:  "Interest CanBePrefix.",
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+			},
+			"mustBeFresh": &graphql.ArgumentConfig{
+				Description:  "Interest MustBeFresh.",
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+			},
+			"fwHint": &graphql.ArgumentConfig{
+				Description: "Forwarding hint delegations.",
+				Type:        graphql.NewList(graphql.NewNonNull(ndni.GqlNameType)),
+			},
+			"face": &graphql.ArgumentConfig{
+				Description: "Incoming face, which is excluded from nexthops.",
+				Type:        graphql.ID,
+			},
+		},
+		Type: graphql.NewNonNull(GqlExplanationType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlDataPlane == nil {
+				return nil, errNoGqlDataPlane
+			}
+			interest, e := parseGqlExplainInterest(p.Args)
+			if e != nil {
+				return nil, e
+			}
+			return GqlDataPlane.Explain(interest)
+		},
+	})
+}
@@ -0,0 +1,205 @@
+package fwdp
+
+/*
+#include "../../csrc/fwdp/fwd.h"
+*/
+import "C"
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Errors returned by SetLimiterRules.
+var (
+	ErrLimiterRules     = errors.New("too many rate limiters")
+	ErrLimiterTarget    = errors.New("rate limiter must have either face or prefix")
+	ErrLimiterRate      = errors.New("rate limiter rate and burst must be positive")
+	ErrLimiterOverflow  = errors.New("rate limiter rate or burst too large")
+	ErrLimiterAction    = errors.New("invalid rate limiter action")
+	ErrLimiterPrefix    = errors.New("rate limiter prefix too long")
+	ErrLimiterDuplicate = errors.New("duplicate face or prefix in rate limiters")
+)
+
+// LimiterRule is a token bucket Interest rate limiter.
+//
+// A per-face limiter applies to Interests arriving on a face.
+// A per-prefix limiter applies to Interests under a name prefix, arriving on any face.
+// Exactly one of Face and Prefix should be specified; an empty Prefix with zero Face matches every Interest.
+type LimiterRule struct {
+	Face   iface.ID `json:"face,omitempty"`
+	Prefix ndn.Name `json:"prefix,omitempty"`
+
+	// Rate is the sustained rate in Interests per second.
+	Rate int `json:"rate"`
+
+	// Burst is the bucket capacity in Interests.
+	// Rate and Burst must not exceed 2^64 divided by TSC frequency in Hz.
+	Burst int `json:"burst"`
+
+	// Action determines what happens to an Interest exceeding the rate limit.
+	Action LimiterAction `json:"action"`
+}
+
+// IsFaceRule determines whether this is a per-face limiter.
+func (rule LimiterRule) IsFaceRule() bool {
+	return rule.Face != 0
+}
+
+func (rule LimiterRule) sameTarget(other LimiterRule) bool {
+	return rule.Face == other.Face && rule.Prefix.Equal(other.Prefix)
+}
+
+func (rule LimiterRule) validate() error {
+	if rule.Face != 0 && len(rule.Prefix) > 0 {
+		return ErrLimiterTarget
+	}
+	if rule.Rate <= 0 || rule.Burst <= 0 {
+		return ErrLimiterRate
+	}
+	// TokenBucket counts in units of 1/tscHz token, which must fit in uint64
+	maxTokens := uint64(math.MaxUint64) / uint64(C.rte_get_tsc_hz())
+	if uint64(rule.Rate) > maxTokens || uint64(rule.Burst) > maxTokens {
+		return ErrLimiterOverflow
+	}
+	switch rule.Action {
+	case LimiterDrop, LimiterNack:
+	default:
+		return ErrLimiterAction
+	}
+	if rule.Prefix.Length() > ndni.NameMaxLength {
+		return ErrLimiterPrefix
+	}
+	return nil
+}
+
+func (rule LimiterRule) copyToC(r *C.FwLimiterRule) {
+	C.TokenBucket_Init(&r.tb, C.uint64_t(rule.Rate), C.uint64_t(rule.Burst))
+	r.face = C.FaceID(rule.Face)
+	r.action = C.FwLimiterAction(rule.Action)
+	prefixV, _ := rule.Prefix.MarshalBinary()
+	for i, b := range prefixV {
+		r.prefixV[i] = C.uint8_t(b)
+	}
+	r.prefixL = C.uint16_t(len(prefixV))
+}
+
+// LimiterCounters contains counters of a rate limiter.
+type LimiterCounters struct {
+	NPassed uint64 `json:"nPassed"` // Interests within rate limit
+	NShed   uint64 `json:"nShed"`   // Interests dropped or Nacked due to rate limit
+}
+
+// LimiterRuleInfo contains a rate limiter and its counters.
+type LimiterRuleInfo struct {
+	LimiterRule
+	LimiterCounters
+}
+
+// LimiterRules returns Interest rate limiters and their counters.
+// Per-face limiters are listed first, followed by per-prefix limiters in descending prefix length.
+func (dp *DataPlane) LimiterRules() (list []LimiterRuleInfo) {
+	dp.limiterMutex.Lock()
+	defer dp.limiterMutex.Unlock()
+
+	lim := dp.limiter
+	if lim == nil {
+		return nil
+	}
+
+	for i := range lim.rules[:lim.nFaceRules+lim.nPrefixRules] {
+		r := &lim.rules[i]
+		var info LimiterRuleInfo
+		info.Face = iface.ID(r.face)
+		info.Prefix.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&r.prefixV[0]), C.int(r.prefixL)))
+		info.Rate = int(r.tb.rate)
+		info.Burst = int(r.tb.capacity / r.tb.cost)
+		info.Action = LimiterAction(r.action)
+		info.NPassed = atomic.LoadUint64((*uint64)(unsafe.Pointer(&r.nPassed)))
+		info.NShed = atomic.LoadUint64((*uint64)(unsafe.Pointer(&r.nShed)))
+		list = append(list, info)
+	}
+	return list
+}
+
+// SetLimiterRules replaces Interest rate limiters in all forwarding threads.
+// Each limiter is shared among forwarding threads, so that its rate applies to the forwarder as a whole.
+// Counters of a limiter are retained if its face or prefix is unchanged; token buckets start full.
+// This function waits for an RCU grace period, and should not be called from an RCU read-side thread.
+func (dp *DataPlane) SetLimiterRules(rules []LimiterRule) error {
+	if len(rules) > MaxLimiterRules {
+		return ErrLimiterRules
+	}
+	rules = append([]LimiterRule{}, rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		if a, b := rules[i].IsFaceRule(), rules[j].IsFaceRule(); a != b {
+			return a
+		}
+		return len(rules[i].Prefix) > len(rules[j].Prefix)
+	})
+
+	nFaceRules := 0
+	for i, rule := range rules {
+		if e := rule.validate(); e != nil {
+			return e
+		}
+		for _, prev := range rules[:i] {
+			if prev.sameTarget(rule) {
+				return ErrLimiterDuplicate
+			}
+		}
+		if rule.IsFaceRule() {
+			nFaceRules++
+		}
+	}
+
+	dp.limiterMutex.Lock()
+	defer dp.limiterMutex.Unlock()
+
+	var lim *C.FwLimiter
+	if len(rules) > 0 {
+		lim = (*C.FwLimiter)(eal.Zmalloc("FwLimiter", C.sizeof_FwLimiter, eal.NumaSocket{}))
+		for i, rule := range rules {
+			rule.copyToC(&lim.rules[i])
+		}
+		lim.nFaceRules = C.uint32_t(nFaceRules)
+		lim.nPrefixRules = C.uint32_t(len(rules) - nFaceRules)
+	}
+
+	old := dp.limiter
+	dp.limiter = lim
+	for _, fwd := range dp.fwds {
+		C.FwFwd_SetLimiter(fwd.c, lim)
+	}
+	if old == nil {
+		return nil
+	}
+
+	// After the grace period, forwarding threads no longer update the old table, so that its
+	// counters are final and can be carried over to the new table without losing increments.
+	urcu.Synchronize()
+	if lim != nil {
+		for i := range old.rules[:old.nFaceRules+old.nPrefixRules] {
+			o := &old.rules[i]
+			for j := range lim.rules[:lim.nFaceRules+lim.nPrefixRules] {
+				r := &lim.rules[j]
+				if r.face == o.face && r.prefixL == o.prefixL &&
+					C.memcmp(unsafe.Pointer(&r.prefixV[0]), unsafe.Pointer(&o.prefixV[0]), C.size_t(o.prefixL)) == 0 {
+					atomic.AddUint64((*uint64)(unsafe.Pointer(&r.nPassed)), uint64(o.nPassed))
+					atomic.AddUint64((*uint64)(unsafe.Pointer(&r.nShed)), uint64(o.nShed))
+					break
+				}
+			}
+		}
+	}
+	eal.Free(old)
+	return nil
+}
@@ -0,0 +1,90 @@
+package fwdp
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs"
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+	"github.com/usnistgov/ndn-dpdk/core/runningstat"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+)
+
+// latencySummary combines RunningStat and QuantileSketch readings as a metrics summary source.
+type latencySummary struct {
+	runningstat.Snapshot
+	quantiles runningstat.QuantileSnapshot
+}
+
+func (s latencySummary) Quantile(q float64) float64 {
+	return s.quantiles.Quantile(q)
+}
+
+// CollectMetrics adds forwarder metrics, including forwarding thread, PIT, CS, NDT, and FIB counters.
+// Counters are read without locking, so that a scrape does not stall forwarding threads.
+func (dp *DataPlane) CollectMetrics(w *metrics.Writer) {
+	ndtTable, ndtCounters := dp.ndt.ReadTable(), dp.ndt.ReadCounters()
+	ndtLookups := make([]uint64, len(dp.fwds))
+	for index, value := range ndtTable {
+		if int(value) < len(ndtLookups) {
+			ndtLookups[value] += uint64(ndtCounters[index])
+		}
+	}
+
+	for i, fwd := range dp.fwds {
+		labels := []string{"fwd", strconv.Itoa(i)}
+		info := dp.ReadFwdInfo(i)
+		ealthread.CollectMetrics(w, fwd, roleFwd)
+
+		w.Counter("fwd_input_dropped_total", "Packets dropped due to full forwarding thread input queue.",
+			info.InputInterest.NDropped+info.InputData.NDropped+info.InputNack.NDropped, labels...)
+		w.Summary("fwd_input_latency_nanoseconds", "Latency from packet arrival to forwarding thread processing.",
+			latencySummary{info.InputLatency, info.InputLatencyQuantiles}, labels...)
+		w.Counter("fwd_no_fib_match_total", "Interests dropped due to no FIB match.", info.NNoFibMatch, labels...)
+		w.Counter("fwd_dup_nonce_total", "Interests dropped due to duplicate nonce.", info.NDupNonce, labels...)
+		w.Counter("fwd_sg_no_fwd_total", "Interests not forwarded by strategy.", info.NSgNoFwd, labels...)
+		w.Counter("fwd_nack_mismatch_total", "Nacks dropped due to outdated nonce.", info.NNackMismatch, labels...)
+		w.Counter("fwd_acl_drops_total", "Packets dropped due to face ACL.", info.NAclDrops, labels...)
+		w.Counter("fwd_scope_violations_total", "Packets dropped due to scope control.",
+			info.NLocalhostViolations, append(labels, "scope", "localhost")...)
+		w.Counter("fwd_scope_violations_total", "Packets dropped due to scope control.",
+			info.NLocalhopViolations, append(labels, "scope", "localhop")...)
+		w.Gauge("ndt_hits", "NDT hit counters of elements assigned to forwarding thread; each element counter wraps at 65536.",
+			float64(ndtLookups[i]), labels...)
+
+		pitCnt := dp.GetFwdPit(i).ReadCounters()
+		w.Gauge("pit_entries", "Current number of PIT entries.", float64(pitCnt.NEntries), labels...)
+		w.Counter("pit_inserts_total", "PIT inserts that created a new entry.", pitCnt.NInsert, labels...)
+		w.Counter("pit_found_total", "PIT inserts that found an existing entry.", pitCnt.NFound, labels...)
+		w.Counter("pit_alloc_errors_total", "PIT inserts that failed due to allocation error.", pitCnt.NAllocErr, labels...)
+		w.Counter("pit_data_hits_total", "Data that matched PIT entries.", pitCnt.NDataHit, labels...)
+		w.Counter("pit_data_misses_total", "Data that did not match PIT entries.", pitCnt.NDataMiss, labels...)
+		w.Counter("pit_nack_hits_total", "Nacks that matched PIT entries.", pitCnt.NNackHit, labels...)
+		w.Counter("pit_nack_misses_total", "Nacks that did not match PIT entries.", pitCnt.NNackMiss, labels...)
+		w.Counter("pit_expired_total", "Expired PIT entries.", pitCnt.NExpired, labels...)
+
+		theCs := dp.GetFwdCs(i)
+		for _, list := range []struct {
+			id   cs.ListID
+			name string
+		}{{cs.ListMd, "md"}, {cs.ListMi, "mi"}} {
+			listLabels := append(labels, "list", list.name)
+			w.Gauge("cs_entries", "Current number of CS entries.", float64(theCs.CountEntries(list.id)), listLabels...)
+			w.Gauge("cs_capacity", "CS capacity.", float64(theCs.Capacity(list.id)), listLabels...)
+		}
+		w.Counter("cs_hits_total", "Interests satisfied by CS.", pitCnt.NCsMatch, labels...)
+		w.Counter("cs_misses_total", "Interests not satisfied by CS.", pitCnt.NInsert+pitCnt.NFound, labels...)
+	}
+
+	if dp.ndtBalancer != nil {
+		w.Counter("ndt_balancer_moves_total", "NDT elements moved by the balancer.", uint64(dp.ndtBalancer.CountMoves()))
+	}
+
+	for _, entry := range dp.fib.List() {
+		labels := []string{"name", entry.Name.String()}
+		cnt := entry.Counters()
+		w.Counter("fib_rx_interests_total", "Interests received under FIB entry.", cnt.NRxInterests, labels...)
+		w.Counter("fib_rx_data_total", "Data received under FIB entry.", cnt.NRxData, labels...)
+		w.Counter("fib_rx_nacks_total", "Nacks received under FIB entry.", cnt.NRxNacks, labels...)
+		w.Counter("fib_tx_interests_total", "Interests forwarded under FIB entry.", cnt.NTxInterests, labels...)
+	}
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/usnistgov/ndn-dpdk/container/cs"
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/ndt"
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
 	"github.com/usnistgov/ndn-dpdk/container/pit"
 	"github.com/usnistgov/ndn-dpdk/core/runningstat"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/mempool"
 	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
 )
 
 // Count number of input and forwarding processes.
@@ -46,15 +48,20 @@ func (dp *DataPlane) ReadInputInfo(i int) (info *InputInfo) {
 type FwdInfo struct {
 	LCore eal.LCore // LCore executing this fwd process
 
-	InputInterest FwdInputCounter
-	InputData     FwdInputCounter
-	InputNack     FwdInputCounter
-	InputLatency  runningstat.Snapshot // input latency in nanos
+	InputInterest         FwdInputCounter
+	InputData             FwdInputCounter
+	InputNack             FwdInputCounter
+	InputLatency          runningstat.Snapshot         // input latency in nanos
+	InputLatencyQuantiles runningstat.QuantileSnapshot // input latency quantiles in nanos
 
 	NNoFibMatch   uint64 // Interests dropped due to no FIB match
 	NDupNonce     uint64 // Interests dropped due duplicate nonce
 	NSgNoFwd      uint64 // Interests not forwarded by strategy
 	NNackMismatch uint64 // Nack dropped due to outdated nonce
+	NAclDrops     uint64 // packets dropped due to face ACL
+
+	NLocalhostViolations uint64 // /localhost packets dropped on non-local faces
+	NLocalhopViolations  uint64 // /localhop Interests not forwarded beyond one hop
 
 	HeaderMpUsage   int // how many entries are used in header mempool
 	IndirectMpUsage int // how many entries are used in indirect mempool
@@ -83,11 +90,16 @@ func (dp *DataPlane) ReadFwdInfo(i int) (info *FwdInfo) {
 
 	latencyStat := runningstat.FromPtr(unsafe.Pointer(&fwd.c.latencyStat))
 	info.InputLatency = latencyStat.Read().Scale(eal.GetNanosInTscUnit())
+	latencyQuantiles := runningstat.QuantileSketchFromPtr(unsafe.Pointer(&fwd.c.latencyQuantiles))
+	info.InputLatencyQuantiles = latencyQuantiles.Read().Scale(eal.GetNanosInTscUnit())
 
 	info.NNoFibMatch = uint64(fwd.c.nNoFibMatch)
 	info.NDupNonce = uint64(fwd.c.nDupNonce)
 	info.NSgNoFwd = uint64(fwd.c.nSgNoFwd)
 	info.NNackMismatch = uint64(fwd.c.nNackMismatch)
+	info.NAclDrops = uint64(fwd.c.nAclDrops)
+	info.NLocalhostViolations = uint64(fwd.c.nLocalhostViolations)
+	info.NLocalhopViolations = uint64(fwd.c.nLocalhopViolations)
 
 	info.HeaderMpUsage = mempool.FromPtr(unsafe.Pointer(fwd.c.headerMp)).CountInUse()
 	info.IndirectMpUsage = mempool.FromPtr(unsafe.Pointer(fwd.c.indirectMp)).CountInUse()
@@ -109,6 +121,11 @@ func (dp *DataPlane) GetNdt() *ndt.Ndt {
 	return dp.ndt
 }
 
+// Access the NDT balancer, nil if disabled.
+func (dp *DataPlane) GetNdtBalancer() *ndt.Balancer {
+	return dp.ndtBalancer
+}
+
 // Access the FIB.
 func (dp *DataPlane) GetFib() *fib.Fib {
 	return dp.fib
@@ -127,3 +144,80 @@ func (dp *DataPlane) GetFwdCs(i int) *cs.Cs {
 	}
 	return cs.FromPcct(dp.fwds[i].pcct)
 }
+
+// CsListCounters contains counters of a CS list.
+type CsListCounters struct {
+	Count    int
+	Capacity int
+}
+
+// CsCounters contains CS counters of a fwd.
+type CsCounters struct {
+	MD CsListCounters // in-memory direct entries
+	MI CsListCounters // in-memory indirect entries
+
+	NHits   uint64
+	NMisses uint64
+}
+
+// ReadCsCounters reads CS counters of i-th fwd.
+func (dp *DataPlane) ReadCsCounters(i int) *CsCounters {
+	thePit, theCs := dp.GetFwdPit(i), dp.GetFwdCs(i)
+	if thePit == nil || theCs == nil {
+		return nil
+	}
+	pitCnt := thePit.ReadCounters()
+
+	readList := func(list cs.ListID) CsListCounters {
+		return CsListCounters{
+			Count:    theCs.CountEntries(list),
+			Capacity: theCs.Capacity(list),
+		}
+	}
+	return &CsCounters{
+		MD:      readList(cs.ListMd),
+		MI:      readList(cs.ListMi),
+		NHits:   pitCnt.NCsMatch,
+		NMisses: pitCnt.NInsert + pitCnt.NFound,
+	}
+}
+
+// CsErase erases CS entries under a name prefix in all forwarding threads.
+// Returns number of erased CS entries.
+func (dp *DataPlane) CsErase(prefix ndn.Name) (nErased int, e error) {
+	for _, fwd := range dp.fwds {
+		w, e := pcct.NewWalk(pcct.WalkCsErase, prefix, 0, 0, fwd.NumaSocket())
+		if e != nil {
+			return nErased, e
+		}
+		fwd.Walk(w)
+		nErased += w.NErased()
+		w.Close()
+	}
+	return nErased, nil
+}
+
+// CsAdmitRules returns CS admission rules and their counters summed across forwarding threads.
+func (dp *DataPlane) CsAdmitRules() (list []cs.AdmitRuleInfo) {
+	for i, fwd := range dp.fwds {
+		fwdList := cs.FromPcct(fwd.pcct).AdmitRules()
+		if i == 0 {
+			list = fwdList
+			continue
+		}
+		for j := 0; j < len(list) && j < len(fwdList); j++ {
+			list[j].AdmitCounters = list[j].AdmitCounters.Add(fwdList[j].AdmitCounters)
+		}
+	}
+	return list
+}
+
+// SetCsAdmitRules replaces CS admission rules in all forwarding threads.
+func (dp *DataPlane) SetCsAdmitRules(rules []cs.AdmitRule) error {
+	for _, fwd := range dp.fwds {
+		if e := cs.FromPcct(fwd.pcct).SetAdmitRules(rules, fwd.NumaSocket()); e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -55,7 +55,7 @@ func New(cfg []TaskConfig) (app *App, e error) {
 		}
 		app.inputs[len(app.inputs)-1].face = face
 
-		task, e := newTask(face, taskCfg)
+		task, e := newTask(i, face, taskCfg)
 		if e != nil {
 			return nil, fmt.Errorf("[%d] init error: %v", i, e)
 		}
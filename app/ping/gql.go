@@ -0,0 +1,339 @@
+package ping
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/app/fetch"
+	"github.com/usnistgov/ndn-dpdk/app/pingclient"
+	"github.com/usnistgov/ndn-dpdk/app/pingserver"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GqlApp is the App instance accessible via GraphQL.
+var GqlApp *App
+
+var (
+	errNoGqlApp      = errors.New("App unavailable")
+	errTaskNotFound  = errors.New("task not found")
+	errNoClient      = errors.New("task has no client")
+	errNoFetcher     = errors.New("task has no fetcher")
+	errClientRunning = errors.New("client is running")
+	errInterval      = errors.New("interval must be a positive duration")
+)
+
+// GraphQL types.
+var (
+	GqlTaskNodeType           *gqlserver.NodeType
+	GqlTaskType               *graphql.Object
+	GqlClientType             *graphql.Object
+	GqlServerType             *graphql.Object
+	GqlFetcherType            *graphql.Object
+	GqlFetchTemplateInputType *graphql.InputObject
+)
+
+func gqlRetrieveTask(id interface{}) (*Task, error) {
+	task, e := gqlserver.RetrieveNodeOfType(GqlTaskNodeType, id)
+	if e != nil {
+		return nil, e
+	}
+	if task == nil {
+		return nil, errTaskNotFound
+	}
+	return task.(*Task), nil
+}
+
+func init() {
+	GqlClientType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PingClient",
+		Description: "Traffic generator client.",
+		Fields: graphql.Fields{
+			"isRunning": &graphql.Field{
+				Description: "Whether the client is running.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					client := p.Source.(*pingclient.Client)
+					return client.Rx.IsRunning() || client.Tx.IsRunning(), nil
+				},
+			},
+			"interval": &graphql.Field{
+				Description: "Interest sending interval, as a duration string such as \"100us\".",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*pingclient.Client).GetInterval().String(), nil
+				},
+			},
+			"counters": &graphql.Field{
+				Description: "Client counters.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*pingclient.Client).ReadCounters(), nil
+				},
+			},
+		},
+	})
+
+	GqlServerType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PingServer",
+		Description: "Traffic generator server.",
+		Fields: graphql.Fields{
+			"counters": &graphql.Field{
+				Description: "Server counters.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*pingserver.Server).ReadCounters(), nil
+				},
+			},
+		},
+	})
+
+	GqlFetcherType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Fetcher",
+		Description: "Traffic generator fetcher.",
+		Fields: graphql.Fields{
+			"isRunning": &graphql.Field{
+				Description: "Whether the fetcher is running.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*fetch.Fetcher).Thread(0).IsRunning(), nil
+				},
+			},
+			"counters": &graphql.Field{
+				Description: "Counters of each fetch procedure.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					fetcher := p.Source.(*fetch.Fetcher)
+					list := []fetch.Counters{}
+					for i, last := 0, fetcher.CountProcs(); i < last; i++ {
+						list = append(list, fetcher.Logic(i).ReadCounters())
+					}
+					return list, nil
+				},
+			},
+		},
+	})
+
+	GqlTaskNodeType = gqlserver.NewNodeTypeNamed("PingTask", (*Task)(nil))
+	GqlTaskNodeType.Retrieve = func(id string) (interface{}, error) {
+		if GqlApp == nil {
+			return nil, errNoGqlApp
+		}
+		index, e := strconv.Atoi(id)
+		if e != nil || index < 0 || index >= len(GqlApp.Tasks) {
+			return nil, nil
+		}
+		return &GqlApp.Tasks[index], nil
+	}
+
+	GqlTaskType = graphql.NewObject(GqlTaskNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "PingTask",
+		Description: "Traffic generator task on a face.",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Description: "Task index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Task).index, nil
+				},
+			},
+			"face": &graphql.Field{
+				Description: "Face.",
+				Type:        graphql.NewNonNull(iface.GqlFaceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Task).Face, nil
+				},
+			},
+			"servers": &graphql.Field{
+				Description: "Server threads.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlServerType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return append([]*pingserver.Server{}, p.Source.(*Task).Server...), nil
+				},
+			},
+			"client": &graphql.Field{
+				Description: "Client. null indicates the task has no client.",
+				Type:        GqlClientType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return gqlserver.Optional(p.Source.(*Task).Client), nil
+				},
+			},
+			"fetcher": &graphql.Field{
+				Description: "Fetcher. null indicates the task has no fetcher.",
+				Type:        GqlFetcherType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return gqlserver.Optional(p.Source.(*Task).Fetch), nil
+				},
+			},
+		},
+	}))
+	GqlTaskNodeType.Register(GqlTaskType)
+
+	GqlFetchTemplateInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FetchTemplateInput",
+		Description: "Interest template of a fetch procedure.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"prefix": &graphql.InputObjectFieldConfig{
+				Description: "Name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"interestLifetime": &graphql.InputObjectFieldConfig{
+				Description: "InterestLifetime in milliseconds.",
+				Type:        graphql.Int,
+			},
+			"canBePrefix": &graphql.InputObjectFieldConfig{
+				Description: "CanBePrefix flag.",
+				Type:        graphql.Boolean,
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "pingTasks",
+		Description: "Traffic generator tasks.",
+		Type:        graphql.NewList(graphql.NewNonNull(GqlTaskType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlApp == nil {
+				return nil, errNoGqlApp
+			}
+			list := []*Task{}
+			for i := range GqlApp.Tasks {
+				list = append(list, &GqlApp.Tasks[i])
+			}
+			return list, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "startPingClient",
+		Description: "Start a traffic generator client.",
+		Args: graphql.FieldConfigArgument{
+			"task": &graphql.ArgumentConfig{
+				Description: "Task ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"interval": &graphql.ArgumentConfig{
+				Description: "Interest sending interval, as a duration string such as \"100us\". Omit to keep current interval.",
+				Type:        graphql.String,
+			},
+			"clearCounters": &graphql.ArgumentConfig{
+				Description:  "Whether to clear counters.",
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+			},
+		},
+		Type: graphql.NewNonNull(GqlTaskType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			task, e := gqlRetrieveTask(p.Args["task"])
+			if e != nil {
+				return nil, e
+			}
+			client := task.Client
+			if client == nil {
+				return nil, errNoClient
+			}
+			if client.Rx.IsRunning() || client.Tx.IsRunning() {
+				return nil, errClientRunning
+			}
+
+			if intervalArg, ok := p.Args["interval"].(string); ok {
+				interval, e := time.ParseDuration(intervalArg)
+				if e != nil || interval <= 0 {
+					return nil, errInterval
+				}
+				client.SetInterval(interval)
+			}
+			if p.Args["clearCounters"].(bool) {
+				client.ClearCounters()
+			}
+			client.Launch()
+			return task, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "stopPingClient",
+		Description: "Stop a traffic generator client.",
+		Args: graphql.FieldConfigArgument{
+			"task": &graphql.ArgumentConfig{
+				Description: "Task ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"rxDelay": &graphql.ArgumentConfig{
+				Description:  "Duration between stopping TX and stopping RX, in milliseconds.",
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+		},
+		Type: graphql.NewNonNull(GqlTaskType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			task, e := gqlRetrieveTask(p.Args["task"])
+			if e != nil {
+				return nil, e
+			}
+			if task.Client == nil {
+				return nil, errNoClient
+			}
+			rxDelay := nnduration.Milliseconds(p.Args["rxDelay"].(int))
+			if e := task.Client.Stop(rxDelay.Duration()); e != nil {
+				return nil, e
+			}
+			return task, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "fetchBenchmark",
+		Description: "Run a fetcher benchmark. This blocks until the benchmark completes, and returns counters of each fetch procedure.",
+		Args: graphql.FieldConfigArgument{
+			"task": &graphql.ArgumentConfig{
+				Description: "Task ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"templates": &graphql.ArgumentConfig{
+				Description: "Interest templates, one per fetch procedure.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlFetchTemplateInputType))),
+			},
+			"interval": &graphql.ArgumentConfig{
+				Description:  "Interval between counter readings, in milliseconds.",
+				Type:         graphql.Int,
+				DefaultValue: 1000,
+			},
+			"count": &graphql.ArgumentConfig{
+				Description: "Number of counter readings.",
+				Type:        gqlserver.NonNullInt,
+			},
+		},
+		Type: gqlserver.NonNullJSON,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			task, e := gqlRetrieveTask(p.Args["task"])
+			if e != nil {
+				return nil, e
+			}
+			if task.Fetch == nil {
+				return nil, errNoFetcher
+			}
+
+			cfg := fetch.BenchmarkConfig{
+				Interval: nnduration.Milliseconds(p.Args["interval"].(int)),
+				Count:    p.Args["count"].(int),
+			}
+			for _, item := range p.Args["templates"].([]interface{}) {
+				m := item.(map[string]interface{})
+				var tpl fetch.TemplateConfig
+				tpl.Prefix = m["prefix"].(ndn.Name)
+				if lifetime, ok := m["interestLifetime"].(int); ok {
+					tpl.InterestLifetime = nnduration.Milliseconds(lifetime)
+				}
+				tpl.CanBePrefix, _ = m["canBePrefix"].(bool)
+				cfg.Templates = append(cfg.Templates, tpl)
+			}
+			return task.Fetch.Benchmark(cfg)
+		},
+	})
+}
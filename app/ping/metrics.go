@@ -0,0 +1,49 @@
+package ping
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+// CollectMetrics adds traffic generator metrics, including client, server, and fetcher counters.
+// Counters are read without locking, so that a scrape does not stall traffic generator threads.
+func (app *App) CollectMetrics(w *metrics.Writer) {
+	for i, task := range app.Tasks {
+		labels := []string{"task", strconv.Itoa(i), "face", strconv.Itoa(int(task.Face.ID()))}
+
+		for j, server := range task.Server {
+			serverLabels := append(labels, "server", strconv.Itoa(j))
+			cnt := server.ReadCounters()
+			w.Counter("pingserver_interests_total", "Interests processed by ping server.", cnt.NInterests, serverLabels...)
+			w.Counter("pingserver_no_match_total", "Interests not matching any pattern.", cnt.NNoMatch, serverLabels...)
+			w.Counter("pingserver_alloc_errors_total", "Reply allocation errors.", cnt.NAllocError, serverLabels...)
+		}
+
+		if task.Client != nil {
+			cnt := task.Client.ReadCounters()
+			w.Counter("pingclient_alloc_errors_total", "Interest allocation errors.", cnt.NAllocError, labels...)
+			for j, pcnt := range cnt.PerPattern {
+				patternLabels := append(labels, "pattern", strconv.Itoa(j))
+				w.Counter("pingclient_interests_total", "Interests sent by ping client.", pcnt.NInterests, patternLabels...)
+				w.Counter("pingclient_data_total", "Data received by ping client.", pcnt.NData, patternLabels...)
+				w.Counter("pingclient_nacks_total", "Nacks received by ping client.", pcnt.NNacks, patternLabels...)
+				w.Summary("pingclient_rtt_nanoseconds", "Round trip time of sampled Interests.", pcnt.Rtt, patternLabels...)
+			}
+		}
+
+		if task.Fetch != nil {
+			for j, last := 0, task.Fetch.CountProcs(); j < last; j++ {
+				procLabels := append(labels, "proc", strconv.Itoa(j))
+				cnt := task.Fetch.Logic(j).ReadCounters()
+				w.Counter("fetch_rx_data_total", "Data satisfying pending Interests.", cnt.NRxData, procLabels...)
+				w.Counter("fetch_tx_retx_total", "Retransmitted Interests.", cnt.NTxRetx, procLabels...)
+				w.Counter("fetch_rx_cong_nacks_total", "Nacks indicating congestion.", cnt.NRxCongNacks, procLabels...)
+				w.Gauge("fetch_in_flight", "In-flight Interests.", float64(cnt.NInFlight), procLabels...)
+				w.Gauge("fetch_cwnd", "Congestion window.", float64(cnt.Cwnd), procLabels...)
+				w.Gauge("fetch_srtt_seconds", "Smoothed round trip time.", cnt.SRtt.Seconds(), procLabels...)
+				w.Summary("fetch_rtt_nanoseconds", "Round trip time of Data not retransmitted.", cnt.Rtt, procLabels...)
+			}
+		}
+	}
+}
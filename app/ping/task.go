@@ -9,14 +9,16 @@ import (
 )
 
 type Task struct {
+	index  int
 	Face   iface.Face
 	Server []*pingserver.Server
 	Client *pingclient.Client
 	Fetch  *fetch.Fetcher
 }
 
-func newTask(face iface.Face, cfg TaskConfig) (task Task, e error) {
+func newTask(index int, face iface.Face, cfg TaskConfig) (task Task, e error) {
 	socket := face.NumaSocket()
+	task.index = index
 	task.Face = face
 
 	if cfg.Server != nil {
@@ -7,6 +7,7 @@ package pingclient
 */
 import "C"
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"time"
@@ -39,11 +40,46 @@ func (cnt PacketCounters) String() string {
 
 type RttCounters struct {
 	runningstat.Snapshot
+	Quantiles runningstat.QuantileSnapshot
+}
+
+// Quantile returns the q-quantile of RTT in nanoseconds.
+func (cnt RttCounters) Quantile(q float64) float64 {
+	return cnt.Quantiles.Quantile(q)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Quantiles are placed in "quantiles" key alongside RunningStat fields.
+func (cnt RttCounters) MarshalJSON() ([]byte, error) {
+	var m map[string]interface{}
+	j, e := json.Marshal(cnt.Snapshot)
+	if e != nil {
+		return nil, e
+	}
+	if e = json.Unmarshal(j, &m); e != nil {
+		return nil, e
+	}
+	m["quantiles"] = cnt.Quantiles
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (cnt *RttCounters) UnmarshalJSON(p []byte) error {
+	var m struct {
+		Quantiles runningstat.QuantileSnapshot `json:"quantiles"`
+	}
+	if e := json.Unmarshal(p, &m); e != nil {
+		return e
+	}
+	cnt.Quantiles = m.Quantiles
+	return json.Unmarshal(p, &cnt.Snapshot)
 }
 
 func (cnt RttCounters) String() string {
 	ms := cnt.Scale(1.0 / float64(time.Millisecond))
-	return fmt.Sprintf("%0.3f/%0.3f/%0.3f/%0.3fms(%dsamp)", ms.Min(), ms.Mean(), ms.Max(), ms.Stdev(), ms.Len())
+	msQ := cnt.Quantiles.Scale(1.0 / float64(time.Millisecond))
+	return fmt.Sprintf("%0.3f/%0.3f/%0.3f/%0.3fms(%dsamp) p50=%0.3fms p99=%0.3fms",
+		ms.Min(), ms.Mean(), ms.Max(), ms.Stdev(), ms.Len(), msQ.Quantile(0.5), msQ.Quantile(0.99))
 }
 
 type PatternCounters struct {
@@ -75,21 +111,24 @@ func (cnt Counters) String() string {
 func (client *Client) ReadCounters() (cnt Counters) {
 	rttScale := eal.GetNanosInTscUnit() * math.Exp2(C.PING_TIMING_PRECISION)
 	for i := 0; i < int(client.rxC.nPatterns); i++ {
-		crP := client.rxC.pattern[i]
+		crP := &client.rxC.pattern[i]
 		ctP := client.txC.pattern[i]
 		rtt := runningstat.FromPtr(unsafe.Pointer(&crP.rtt)).Read().Scale(rttScale)
+		rttQ := runningstat.QuantileSketchFromPtr(unsafe.Pointer(&crP.rttQuantiles)).Read().Scale(rttScale)
 
 		var pcnt PatternCounters
 		pcnt.NInterests = uint64(ctP.nInterests)
 		pcnt.NData = uint64(crP.nData)
 		pcnt.NNacks = uint64(crP.nNacks)
 		pcnt.Rtt.Snapshot = rtt
+		pcnt.Rtt.Quantiles = rttQ
 		cnt.PerPattern = append(cnt.PerPattern, pcnt)
 
 		cnt.NInterests += pcnt.NInterests
 		cnt.NData += pcnt.NData
 		cnt.NNacks += pcnt.NNacks
 		cnt.Rtt.Snapshot = cnt.Rtt.Snapshot.Add(rtt)
+		cnt.Rtt.Quantiles = cnt.Rtt.Quantiles.Add(rttQ)
 	}
 
 	cnt.NAllocError = uint64(client.txC.nAllocError)
@@ -110,5 +149,6 @@ func (client *Client) clearCounter(index int) {
 	client.rxC.pattern[index].nNacks = 0
 	rtt := runningstat.FromPtr(unsafe.Pointer(&client.rxC.pattern[index].rtt))
 	rtt.Clear(true)
+	runningstat.QuantileSketchFromPtr(unsafe.Pointer(&client.rxC.pattern[index].rttQuantiles)).Clear()
 	client.txC.pattern[index].nInterests = 0
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"github.com/usnistgov/ndn-dpdk/core/yamlflag"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/gqlmgmt"
+)
+
+func init() {
+	var cfg gqlmgmt.FwConfig
+	var dryRun, prune bool
+
+	defineCommand(&cli.Command{
+		Name:  "apply",
+		Usage: "Apply a declarative forwarder configuration of faces, strategies, and FIB entries.",
+		Flags: []cli.Flag{
+			&cli.GenericFlag{
+				Name:     "config",
+				Aliases:  []string{"f"},
+				Usage:    "Configuration document in YAML format, or '@' followed by a filename.",
+				Value:    yamlflag.New(&cfg),
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "Print the difference without making changes.",
+				Destination: &dryRun,
+			},
+			&cli.BoolFlag{
+				Name:        "prune",
+				Usage:       "Remove FIB entries and faces not described in the document.",
+				Destination: &prune,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			plan, e := gqlmgmt.MakePlan(client, cfg, prune)
+			if e != nil {
+				return e
+			}
+			fmt.Print(plan)
+			if dryRun || plan.Empty() {
+				return nil
+			}
+			return plan.Apply(client)
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	var withStats bool
+
+	defineCommand(&cli.Command{
+		Category: "face",
+		Name:     "list-ethdev",
+		Usage:    "List Ethernet adapters.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "stats",
+				Usage:       "Show hardware statistics.",
+				Destination: &withStats,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				query listEthDevs($withStats: Boolean!) {
+					ethDevs {
+						id
+						nid
+						numaSocket
+						macAddr
+						isDown
+						implName
+						faces {
+							id
+						}
+						stats @include(if: $withStats)
+					}
+				}
+			`, map[string]interface{}{
+				"withStats": withStats,
+			}, "ethDevs")
+		},
+	})
+}
+
+func init() {
+	var id string
+
+	defineCommand(&cli.Command{
+		Category: "face",
+		Name:     "reset-ethdev-stats",
+		Usage:    "Clear hardware statistics of an Ethernet adapter.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "id",
+				Usage:       "EthDev `ID`.",
+				Destination: &id,
+				Required:    true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation resetEthDevStats($id: ID!) {
+					resetEthDevStats(id: $id)
+				}
+			`, map[string]interface{}{
+				"id": id,
+			}, "resetEthDevStats")
+		},
+	})
+}
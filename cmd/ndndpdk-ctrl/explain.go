@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+type explainFibLookup struct {
+	Name string `json:"name"`
+	Lpm  struct {
+		StartDepth       int
+		FirstStage       string
+		Height           int
+		SecondStageStart int
+		NProbes          int
+		MatchLength      int
+	} `json:"lpm"`
+	Entry *struct {
+		Name string `json:"name"`
+	} `json:"entry"`
+	Nexthops []struct {
+		FaceID   int     `json:"faceId"`
+		Eligible bool    `json:"eligible"`
+		Reason   *string `json:"reason"`
+	} `json:"nexthops"`
+	NEligible int `json:"nEligible"`
+}
+
+type explanation struct {
+	NdtIndex int `json:"ndtIndex"`
+	Fwd      struct {
+		Nid int `json:"nid"`
+	} `json:"fwd"`
+	FibLookups []explainFibLookup `json:"fibLookups"`
+	FwHint     *string            `json:"fwHint"`
+	Strategy   *struct {
+		Name string `json:"name"`
+	} `json:"strategy"`
+	CsEntry  json.RawMessage `json:"csEntry"`
+	PitEntry json.RawMessage `json:"pitEntry"`
+	Verdict  string          `json:"verdict"`
+}
+
+func (x explanation) Print() {
+	fmt.Printf("NDT index %d, forwarding thread %d\n", x.NdtIndex, x.Fwd.Nid)
+	for _, lookup := range x.FibLookups {
+		lpm := lookup.Lpm
+		fmt.Printf("FIB lookup %s\n", lookup.Name)
+		fmt.Printf("  2-stage LPM: startDepth=%d first-stage=%s", lpm.StartDepth, lpm.FirstStage)
+		if lpm.FirstStage == "virtual" {
+			fmt.Printf(" height=%d", lpm.Height)
+		}
+		if lpm.SecondStageStart >= 0 {
+			fmt.Printf(" second-stage-start=%d", lpm.SecondStageStart)
+		}
+		fmt.Printf(" probes=%d\n", lpm.NProbes)
+		if lookup.Entry == nil {
+			fmt.Println("  no match")
+			continue
+		}
+		fmt.Printf("  match %s (%d components), %d eligible nexthops\n", lookup.Entry.Name, lpm.MatchLength, lookup.NEligible)
+		for _, nh := range lookup.Nexthops {
+			if nh.Eligible {
+				fmt.Printf("  + face %d\n", nh.FaceID)
+			} else {
+				fmt.Printf("  - face %d (%s)\n", nh.FaceID, *nh.Reason)
+			}
+		}
+	}
+	if x.FwHint != nil {
+		fmt.Printf("active forwarding hint %s\n", *x.FwHint)
+	}
+	if x.Strategy != nil {
+		fmt.Printf("strategy %s\n", x.Strategy.Name)
+	}
+	printEntry := func(title string, entry json.RawMessage) {
+		if len(entry) == 0 || string(entry) == "null" {
+			fmt.Printf("%s no match\n", title)
+		} else {
+			fmt.Printf("%s %s\n", title, entry)
+		}
+	}
+	if x.Verdict != "no-route" {
+		printEntry("CS", x.CsEntry)
+		if x.Verdict != "cs-hit" {
+			printEntry("PIT", x.PitEntry)
+		}
+	}
+	fmt.Printf("verdict %s\n", x.Verdict)
+}
+
+func init() {
+	var name, face string
+	var canBePrefix, mustBeFresh, printJSON bool
+	var fwHint cli.StringSlice
+
+	defineCommand(&cli.Command{
+		Category: "fwdp",
+		Name:     "explain",
+		Usage:    "Explain how the forwarder would process an Interest, without forwarding anything.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "Interest `name`.",
+				Destination: &name,
+				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "can-be-prefix",
+				Usage:       "Set CanBePrefix.",
+				Destination: &canBePrefix,
+			},
+			&cli.BoolFlag{
+				Name:        "must-be-fresh",
+				Usage:       "Set MustBeFresh.",
+				Destination: &mustBeFresh,
+			},
+			&cli.StringSliceFlag{
+				Name:        "fwhint",
+				Usage:       "Forwarding hint delegation `name` (repeatable).",
+				Destination: &fwHint,
+			},
+			&cli.StringFlag{
+				Name:        "face",
+				Usage:       "Incoming face `ID`.",
+				Destination: &face,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "Print JSON instead of text.",
+				Destination: &printJSON,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			query := `
+				query explain($name: Name!, $canBePrefix: Boolean, $mustBeFresh: Boolean, $fwHint: [Name!], $face: ID) {
+					explain(name: $name, canBePrefix: $canBePrefix, mustBeFresh: $mustBeFresh, fwHint: $fwHint, face: $face) {
+						ndtIndex
+						fwd {
+							nid
+						}
+						fibLookups {
+							name
+							lpm
+							entry {
+								name
+							}
+							nexthops {
+								faceId
+								eligible
+								reason
+							}
+							nEligible
+						}
+						fwHint
+						strategy {
+							name
+						}
+						csEntry {
+							name
+							list
+							nIndirects
+							freshUntil
+							isFresh
+						}
+						pitEntry {
+							name
+							mustBeFresh
+							expiry
+							downstreams {
+								face {
+									nid
+								}
+							}
+							upstreams {
+								face {
+									nid
+								}
+							}
+						}
+						verdict
+					}
+				}
+			`
+			vars := map[string]interface{}{
+				"name":        name,
+				"canBePrefix": canBePrefix,
+				"mustBeFresh": mustBeFresh,
+				"fwHint":      fwHint.Value(),
+			}
+			if face != "" {
+				vars["face"] = face
+			}
+			if printJSON {
+				return clientDoPrint(query, vars, "explain")
+			}
+
+			var x explanation
+			if e := client.Do(query, vars, "explain", &x); e != nil {
+				return e
+			}
+			x.Print()
+			return nil
+		},
+	})
+}
@@ -18,6 +18,7 @@ func init() {
 					faces {
 						id
 						locator
+						persistency
 					}
 				}
 			`, nil, "faces")
@@ -53,6 +54,8 @@ func init() {
 						id
 						... on Face {
 							locator
+							isDown
+							persistency
 							counters  @include(if: $withCounters)
 						}
 					}
@@ -78,6 +81,7 @@ func init() {
 	}
 	loc.Scheme = "ether"
 	loc.Remote.HardwareAddr = packettransport.MulticastAddressNDN
+	persistency := "PERSISTENT"
 
 	defineCommand(&cli.Command{
 		Category: "face",
@@ -110,21 +114,65 @@ func init() {
 				Usage:       "Network interface MTU",
 				Destination: &loc.PortConfig.MTU,
 			},
+			&cli.StringFlag{
+				Name:        "persistency",
+				Usage:       "Face persistency level (PERSISTENT, ON_DEMAND, PERMANENT).",
+				Value:       persistency,
+				Destination: &persistency,
+			},
 		},
 		Action: func(c *cli.Context) error {
 			return clientDoPrint(`
-				mutation createFace($locator: JSON!) {
-					createFace(locator: $locator) {
+				mutation createFace($locator: JSON!, $persistency: FacePersistency) {
+					createFace(locator: $locator, persistency: $persistency) {
 						id
 					}
 				}
 			`, map[string]interface{}{
-				"locator": loc,
+				"locator":     loc,
+				"persistency": persistency,
 			}, "createFace")
 		},
 	})
 }
 
+func init() {
+	var id, persistency string
+
+	defineCommand(&cli.Command{
+		Category: "face",
+		Name:     "set-face-persistency",
+		Usage:    "Change face persistency level.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "id",
+				Usage:       "Face ID.",
+				Destination: &id,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "persistency",
+				Usage:       "Face persistency level (PERSISTENT, ON_DEMAND, PERMANENT).",
+				Destination: &persistency,
+				Required:    true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation setFacePersistency($id: ID!, $persistency: FacePersistency!) {
+					setFacePersistency(id: $id, persistency: $persistency) {
+						id
+						persistency
+					}
+				}
+			`, map[string]interface{}{
+				"id":          id,
+				"persistency": persistency,
+			}, "setFacePersistency")
+		},
+	})
+}
+
 func init() {
 	defineDeleteCommand("face", "destroy-face", "Destroy a face.")
 }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	var withCounters bool
+
+	defineCommand(&cli.Command{
+		Category: "fwdp",
+		Name:     "show-fwdp",
+		Usage:    "Show forwarder data plane threads.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "cnt",
+				Usage:       "Show counters.",
+				Destination: &withCounters,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				query fwdp($withCounters: Boolean!) {
+					fwdp {
+						inputs {
+							id
+							worker {
+								nid
+								numaSocket
+							}
+						}
+						fwds {
+							id
+							worker {
+								nid
+								numaSocket
+							}
+							counters @include(if: $withCounters)
+							pitCounters @include(if: $withCounters)
+							csCounters @include(if: $withCounters)
+						}
+					}
+				}
+			`, map[string]interface{}{
+				"withCounters": withCounters,
+			}, "fwdp")
+		},
+	})
+}
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "fwdp",
+		Name:     "list-ndt",
+		Usage:    "List NDT elements and hit counters.",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				{
+					ndt {
+						nid
+						value
+						hits
+					}
+				}
+			`, nil, "ndt")
+		},
+	})
+}
+
+func init() {
+	var index int
+	var name string
+	var value int
+
+	defineCommand(&cli.Command{
+		Category: "fwdp",
+		Name:     "update-ndt",
+		Usage:    "Update an NDT element.",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "index",
+				Usage:       "Table `index`.",
+				Destination: &index,
+				Value:       -1,
+			},
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "Name whose hash determines the table index.",
+				Destination: &name,
+			},
+			&cli.IntFlag{
+				Name:        "value",
+				Usage:       "Forwarding thread `index`.",
+				Destination: &value,
+				Required:    true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]interface{}{
+				"value": value,
+			}
+			if name != "" {
+				vars["name"] = name
+			} else if index >= 0 {
+				vars["index"] = index
+			}
+
+			return clientDoPrint(`
+				mutation updateNdt($index: Int, $name: Name, $value: Int!) {
+					updateNdt(index: $index, name: $name, value: $value) {
+						nid
+						value
+						hits
+					}
+				}
+			`, vars, "updateNdt")
+		},
+	})
+}
+
+func init() {
+	var filename string
+	var count int
+
+	defineCommand(&cli.Command{
+		Category: "fwdp",
+		Name:     "collect-hrlog",
+		Usage:    "Start collecting high resolution log entries.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "filename",
+				Usage:       "Output `filename`.",
+				Destination: &filename,
+				Required:    true,
+			},
+			&cli.IntFlag{
+				Name:        "count",
+				Usage:       "Maximum number of log entries.",
+				Destination: &count,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation collectHrlog($filename: String!, $count: Int) {
+					collectHrlog(filename: $filename, count: $count) {
+						id
+					}
+				}
+			`, map[string]interface{}{
+				"filename": filename,
+				"count":    count,
+			}, "collectHrlog")
+		},
+	})
+}
+
+func init() {
+	defineDeleteCommand("fwdp", "stop-hrlog", "Stop collecting high resolution log entries.")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "log",
+		Name:     "list-log",
+		Usage:    "List log modules and their levels.",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				{
+					logModules {
+						name
+						goLevel
+						cLevel
+						cEffectiveLevel
+						revertAt
+					}
+				}
+			`, nil, "logModules")
+		},
+	})
+}
+
+func init() {
+	var module, level string
+	var revert time.Duration
+
+	defineCommand(&cli.Command{
+		Category: "log",
+		Name:     "set-log",
+		Usage:    "Change log level.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "module",
+				Usage:       "Log `module` name. Omit to change all modules.",
+				Destination: &module,
+			},
+			&cli.StringFlag{
+				Name:        "level",
+				Usage:       "Log `level` letter: V, D, I, W, E, F, or N.",
+				Destination: &level,
+				Required:    true,
+			},
+			&cli.DurationFlag{
+				Name:        "revert",
+				Usage:       "Revert to original log level after this `duration`. 0 means permanent.",
+				Destination: &revert,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]interface{}{
+				"level":       level,
+				"revertAfter": revert.Milliseconds(),
+			}
+			if module != "" {
+				vars["module"] = module
+			}
+			return clientDoPrint(`
+				mutation setLogLevel($module: String, $level: String!, $revertAfter: Int) {
+					setLogLevel(module: $module, level: $level, revertAfter: $revertAfter) {
+						name
+						goLevel
+						cLevel
+						cEffectiveLevel
+						revertAt
+					}
+				}
+			`, vars, "setLogLevel")
+		},
+	})
+}
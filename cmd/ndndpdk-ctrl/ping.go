@@ -0,0 +1,136 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	var withCounters bool
+
+	defineCommand(&cli.Command{
+		Category: "ping",
+		Name:     "list-ping",
+		Usage:    "List traffic generator tasks.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "cnt",
+				Usage:       "Show counters.",
+				Destination: &withCounters,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				query pingTasks($withCounters: Boolean!) {
+					pingTasks {
+						id
+						face {
+							id
+						}
+						servers {
+							counters @include(if: $withCounters)
+						}
+						client {
+							isRunning
+							interval
+							counters @include(if: $withCounters)
+						}
+						fetcher {
+							isRunning
+							counters @include(if: $withCounters)
+						}
+					}
+				}
+			`, map[string]interface{}{
+				"withCounters": withCounters,
+			}, "pingTasks")
+		},
+	})
+}
+
+func init() {
+	var task string
+	var interval time.Duration
+	var clearCounters bool
+
+	defineCommand(&cli.Command{
+		Category: "ping",
+		Name:     "start-ping-client",
+		Usage:    "Start a traffic generator client.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "task",
+				Usage:       "Task `ID`.",
+				Destination: &task,
+				Required:    true,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "Interest sending interval.",
+				Destination: &interval,
+			},
+			&cli.BoolFlag{
+				Name:        "clear",
+				Usage:       "Clear counters.",
+				Destination: &clearCounters,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]interface{}{
+				"task":          task,
+				"clearCounters": clearCounters,
+			}
+			if interval > 0 {
+				vars["interval"] = interval.String()
+			}
+
+			return clientDoPrint(`
+				mutation startPingClient($task: ID!, $interval: String, $clearCounters: Boolean) {
+					startPingClient(task: $task, interval: $interval, clearCounters: $clearCounters) {
+						id
+					}
+				}
+			`, vars, "startPingClient")
+		},
+	})
+}
+
+func init() {
+	var task string
+	var rxDelay int
+
+	defineCommand(&cli.Command{
+		Category: "ping",
+		Name:     "stop-ping-client",
+		Usage:    "Stop a traffic generator client.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "task",
+				Usage:       "Task `ID`.",
+				Destination: &task,
+				Required:    true,
+			},
+			&cli.IntFlag{
+				Name:        "rx-delay",
+				Usage:       "Duration between stopping TX and stopping RX, in milliseconds.",
+				Destination: &rxDelay,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation stopPingClient($task: ID!, $rxDelay: Int) {
+					stopPingClient(task: $task, rxDelay: $rxDelay) {
+						id
+						client {
+							counters
+						}
+					}
+				}
+			`, map[string]interface{}{
+				"task":    task,
+				"rxDelay": rxDelay,
+			}, "stopPingClient")
+		},
+	})
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	var prefix string
+	var sampleRate float64
+
+	defineCommand(&cli.Command{
+		Category: "pkttrace",
+		Name:     "start-trace",
+		Usage:    "Start tracing packets under a name prefix.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "prefix",
+				Usage:       "Name `prefix` of traced packets.",
+				Destination: &prefix,
+				Required:    true,
+			},
+			&cli.Float64Flag{
+				Name:        "sample-rate",
+				Usage:       "Probability of tracing a matching packet, between 0 and 1.",
+				Value:       1,
+				Destination: &sampleRate,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation startPktTrace($prefix: Name!, $sampleRate: Float) {
+					startPktTrace(prefix: $prefix, sampleRate: $sampleRate) {
+						filter {
+							prefix
+							sampleRate
+						}
+						counters
+					}
+				}
+			`, map[string]interface{}{
+				"prefix":     prefix,
+				"sampleRate": sampleRate,
+			}, "startPktTrace")
+		},
+	})
+}
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "pkttrace",
+		Name:     "stop-trace",
+		Usage:    "Stop tracing packets.",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation stopPktTrace {
+					stopPktTrace {
+						counters
+					}
+				}
+			`, nil, "stopPktTrace")
+		},
+	})
+}
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "pkttrace",
+		Name:     "show-trace",
+		Usage:    "Show packet tracer status.",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				query pktTrace {
+					pktTrace {
+						filter {
+							prefix
+							sampleRate
+						}
+						counters
+					}
+				}
+			`, nil, "pktTrace")
+		},
+	})
+}
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "pkttrace",
+		Name:     "watch-trace",
+		Usage:    "Print packet trace records as JSON lines.",
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-interrupt
+				cancel()
+			}()
+
+			return client.Subscribe(ctx, `
+				subscription pktTraceRecords {
+					pktTraceRecords {
+						id
+						time
+						stage
+						pktType
+						face
+						lcore
+						name
+						value
+						detail
+					}
+				}
+			`, nil, "pktTraceRecords", func(data json.RawMessage) error {
+				fmt.Println(string(data))
+				return nil
+			})
+		},
+	})
+}
+
+func init() {
+	var filename string
+	var count int
+
+	defineCommand(&cli.Command{
+		Category: "pkttrace",
+		Name:     "collect-trace",
+		Usage:    "Collect packet trace records to a file on the daemon host.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "filename",
+				Usage:       "Output `filename`.",
+				Destination: &filename,
+				Required:    true,
+			},
+			&cli.IntFlag{
+				Name:        "count",
+				Usage:       "Maximum number of records, 0 means 2^24.",
+				Destination: &count,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation collectPktTrace($filename: String!, $count: Int) {
+					collectPktTrace(filename: $filename, count: $count) {
+						id
+						filename
+						count
+					}
+				}
+			`, map[string]interface{}{
+				"filename": filename,
+				"count":    count,
+			}, "collectPktTrace")
+		},
+	})
+
+	defineDeleteCommand("pkttrace", "stop-collect-trace", "Stop a packet trace collection job.")
+}
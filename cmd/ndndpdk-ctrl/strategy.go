@@ -90,6 +90,50 @@ func init() {
 	})
 }
 
+func init() {
+	var oldID, newID string
+	var keepScratch bool
+
+	defineCommand(&cli.Command{
+		Category: "strategy",
+		Name:     "replace-strategy",
+		Usage:    "Switch all FIB entries to a new strategy, and unload the old strategy.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "old",
+				Usage:       "Old strategy `ID`.",
+				Destination: &oldID,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "new",
+				Usage:       "New strategy `ID`.",
+				Destination: &newID,
+				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "keep-scratch",
+				Usage:       "Keep FIB and PIT entry scratch areas.",
+				Destination: &keepScratch,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(`
+				mutation replaceStrategy($old: ID!, $new: ID!, $resetScratch: Boolean) {
+					replaceStrategy(old: $old, new: $new, resetScratch: $resetScratch) {
+						id
+						name
+					}
+				}
+			`, map[string]interface{}{
+				"old":          oldID,
+				"new":          newID,
+				"resetScratch": !keepScratch,
+			}, "replaceStrategy")
+		},
+	})
+}
+
 func init() {
 	defineDeleteCommand("strategy", "unload-strategy", "Unload a strategy ELF program.")
 }
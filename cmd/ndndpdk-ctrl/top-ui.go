@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// topColumn describes a table column.
+type topColumn struct {
+	Title string
+	Width int
+	Num   bool // numeric column, right aligned and sorted by value
+}
+
+// topCell is a table cell.
+type topCell struct {
+	Text  string
+	Value float64 // sort key of numeric column; NaN sorts last
+}
+
+func topText(s string) topCell {
+	return topCell{Text: s, Value: math.NaN()}
+}
+
+func topCount(v float64) topCell {
+	return topCell{Text: topFormatSI(v), Value: v}
+}
+
+func topRate(v float64) topCell {
+	if math.IsNaN(v) {
+		return topCell{Text: "-", Value: v}
+	}
+	return topCount(v)
+}
+
+func topPercent(v float64) topCell {
+	if math.IsNaN(v) {
+		return topCell{Text: "-", Value: v}
+	}
+	return topCell{Text: fmt.Sprintf("%.1f", v), Value: v}
+}
+
+// topFormatSI formats a number with SI prefix.
+func topFormatSI(v float64) string {
+	switch {
+	case v < 1e3:
+		return fmt.Sprintf("%.0f", v)
+	case v < 1e6:
+		return fmt.Sprintf("%.1fk", v/1e3)
+	case v < 1e9:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v < 1e12:
+		return fmt.Sprintf("%.1fG", v/1e9)
+	}
+	return fmt.Sprintf("%.1fT", v/1e12)
+}
+
+// topTable is a sortable table.
+type topTable struct {
+	Title   string
+	Columns []topColumn
+	Rows    [][]topCell
+
+	Sort    int  // sort column index
+	Desc    bool // sort in descending order
+	MaxRows int  // maximum number of rows to show, 0 means unlimited
+
+	Filter        string // show rows whose FilterColumns contain this text
+	FilterColumns []int
+}
+
+// SortBy changes sort column by title, case insensitive.
+func (tbl *topTable) SortBy(title string) bool {
+	for i, col := range tbl.Columns {
+		if strings.EqualFold(col.Title, title) {
+			tbl.Sort = i
+			return true
+		}
+	}
+	return false
+}
+
+// MoveSort changes sort column to the next or previous column.
+func (tbl *topTable) MoveSort(delta int) {
+	n := len(tbl.Columns)
+	tbl.Sort = (tbl.Sort + delta + n) % n
+	tbl.Desc = tbl.Columns[tbl.Sort].Num
+}
+
+func (tbl *topTable) match(row []topCell) bool {
+	if tbl.Filter == "" {
+		return true
+	}
+	filter := strings.ToLower(tbl.Filter)
+	for _, i := range tbl.FilterColumns {
+		if strings.Contains(strings.ToLower(row[i].Text), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// View returns filtered and sorted rows.
+func (tbl *topTable) View() (rows [][]topCell) {
+	for _, row := range tbl.Rows {
+		if tbl.match(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	col := tbl.Columns[tbl.Sort]
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i][tbl.Sort], rows[j][tbl.Sort]
+		if !col.Num {
+			if tbl.Desc {
+				return a.Text > b.Text
+			}
+			return a.Text < b.Text
+		}
+		switch {
+		case math.IsNaN(a.Value):
+			return false
+		case math.IsNaN(b.Value):
+			return true
+		case tbl.Desc:
+			return a.Value > b.Value
+		}
+		return a.Value < b.Value
+	})
+	return rows
+}
+
+func topPad(s string, width int, right bool) string {
+	if len(s) > width {
+		if right {
+			return s[len(s)-width:]
+		}
+		return s[:width]
+	}
+	if right {
+		return strings.Repeat(" ", width-len(s)) + s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// Render writes the table.
+// maxRows limits number of rows, -1 means unlimited.
+func (tbl *topTable) Render(w io.Writer, selected bool, maxRows int) {
+	rows := tbl.View()
+	if tbl.MaxRows > 0 && (maxRows < 0 || tbl.MaxRows < maxRows) {
+		maxRows = tbl.MaxRows
+	}
+	title := tbl.Title
+	if tbl.Filter != "" {
+		title += fmt.Sprintf(" matching %q", tbl.Filter)
+	}
+	if maxRows >= 0 && len(rows) > maxRows {
+		title += fmt.Sprintf(" (%d of %d)", maxRows, len(rows))
+		rows = rows[:maxRows]
+	}
+	if selected {
+		fmt.Fprintf(w, "\x1b[1m%s\x1b[0m\n", title)
+	} else {
+		fmt.Fprintln(w, title)
+	}
+
+	var b strings.Builder
+	for i, col := range tbl.Columns {
+		text := col.Title
+		if i == tbl.Sort {
+			if tbl.Desc {
+				text += "v"
+			} else {
+				text += "^"
+			}
+		}
+		b.WriteString(topPad(text, col.Width+1, col.Num))
+		b.WriteByte(' ')
+	}
+	fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+
+	for _, row := range rows {
+		b.Reset()
+		for i, col := range tbl.Columns {
+			b.WriteString(topPad(row[i].Text, col.Width+1, col.Num))
+			b.WriteByte(' ')
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+}
+
+// topUI runs the live view.
+type topUI struct {
+	model      *topModel
+	interval   time.Duration
+	iterations int
+	fetch      func() (topData, error)
+
+	interactive bool
+	selected    int
+	editing     bool // editing face filter
+	editText    string
+	fetchErr    error
+	lastFetch   time.Time
+}
+
+func (ui *topUI) refresh() {
+	d, e := ui.fetch()
+	ui.fetchErr = e
+	if e == nil {
+		ui.lastFetch = time.Now()
+		ui.model.update(ui.lastFetch, d)
+	}
+}
+
+func (ui *topUI) render() {
+	width, height := -1, -1
+	if ui.interactive {
+		if ws, e := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ); e == nil {
+			width, height = int(ws.Col), int(ws.Row)
+		}
+	}
+
+	var b strings.Builder
+	m := ui.model
+	fmt.Fprintf(&b, "ndndpdk-ctrl top - %s - interval %s\n", ui.lastFetch.Format("15:04:05"), ui.interval)
+	fmt.Fprintf(&b, "%d faces, %d forwarding threads, %d PIT entries, %d CS entries, %d FIB entries\n",
+		m.nFaces, len(m.fwds.Rows), m.nPit, m.nCs, len(m.fib.Rows))
+	if ui.fetchErr != nil {
+		fmt.Fprintf(&b, "error: %v\n", ui.fetchErr)
+	}
+	b.WriteByte('\n')
+
+	tables := m.tables()
+	faceRows := -1
+	if height > 0 {
+		used := strings.Count(b.String(), "\n") + 1 // +1 for status line
+		for _, tbl := range tables[1:] {
+			n := len(tbl.Rows)
+			if tbl.MaxRows > 0 && tbl.MaxRows < n {
+				n = tbl.MaxRows
+			}
+			used += n + 3
+		}
+		faceRows = height - used - 3
+		if faceRows < 3 {
+			faceRows = 3
+		}
+	}
+	for i, tbl := range tables {
+		maxRows := -1
+		if i == 0 {
+			maxRows = faceRows
+		}
+		tbl.Render(&b, ui.interactive && i == ui.selected, maxRows)
+		b.WriteByte('\n')
+	}
+
+	if !ui.interactive {
+		fmt.Fprint(os.Stdout, b.String())
+		return
+	}
+
+	if ui.editing {
+		fmt.Fprintf(&b, "filter faces: %s", ui.editText)
+	} else {
+		b.WriteString("q:quit  tab:table  left/right:sort column  r:reverse  /:filter faces  space:refresh")
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	if height > 0 && len(lines) > height {
+		lines = append(lines[:height-1], lines[len(lines)-1])
+	}
+	for i, line := range lines {
+		if width > 0 && len(line) > width && !strings.HasPrefix(line, "\x1b") {
+			lines[i] = line[:width]
+		}
+	}
+	out := bufio.NewWriter(os.Stdout)
+	out.WriteString("\x1b[H\x1b[2J")
+	out.WriteString(strings.Join(lines, "\n"))
+	out.Flush()
+}
+
+// splitKeys splits keyboard input into keys.
+// An escape sequence, such as an arrow key, is one key.
+func splitKeys(input []byte) (keys [][]byte) {
+	for len(input) > 0 {
+		n := 1
+		if input[0] == 0x1b && len(input) >= 3 && input[1] == '[' {
+			n = 3
+		}
+		keys, input = append(keys, input[:n]), input[n:]
+	}
+	return keys
+}
+
+// handleKey processes a key, and returns false to quit.
+func (ui *topUI) handleKey(input []byte) bool {
+	if ui.editing {
+		switch {
+		case string(input) == "\x1b":
+			ui.editing = false
+		case input[0] == '\r' || input[0] == '\n':
+			ui.editing = false
+			ui.model.faces.Filter = ui.editText
+		case input[0] == 0x7f || input[0] == 0x08:
+			if n := len(ui.editText); n > 0 {
+				ui.editText = ui.editText[:n-1]
+			}
+		case input[0] >= 0x20 && input[0] < 0x7f:
+			ui.editText += string(input)
+		}
+		return true
+	}
+
+	tbl := ui.model.tables()[ui.selected]
+	switch {
+	case input[0] == 'q' || input[0] == 'Q':
+		return false
+	case input[0] == '\t':
+		ui.selected = (ui.selected + 1) % len(ui.model.tables())
+	case string(input) == "\x1b[D" || input[0] == '<':
+		tbl.MoveSort(-1)
+	case string(input) == "\x1b[C" || input[0] == '>':
+		tbl.MoveSort(1)
+	case input[0] == 'r':
+		tbl.Desc = !tbl.Desc
+	case input[0] == '/':
+		ui.editing = true
+		ui.editText = ui.model.faces.Filter
+	case input[0] == ' ':
+		ui.refresh()
+	}
+	return true
+}
+
+// Run executes the live view until user quits or iterations are completed.
+func (ui *topUI) Run(batch bool) error {
+	stdin := int(os.Stdin.Fd())
+	oldTermios, e := unix.IoctlGetTermios(stdin, unix.TCGETS)
+	ui.interactive = !batch && e == nil
+	if _, e := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ); e != nil {
+		ui.interactive = false
+	}
+
+	ui.refresh()
+	if ui.fetchErr != nil {
+		return ui.fetchErr
+	}
+
+	keys := make(chan []byte)
+	if ui.interactive {
+		raw := *oldTermios
+		raw.Lflag &^= unix.ICANON | unix.ECHO
+		raw.Cc[unix.VMIN] = 1
+		raw.Cc[unix.VTIME] = 0
+		if e := unix.IoctlSetTermios(stdin, unix.TCSETS, &raw); e != nil {
+			return e
+		}
+		fmt.Print("\x1b[?1049h\x1b[?25l")
+		defer func() {
+			fmt.Print("\x1b[?25h\x1b[?1049l")
+			unix.IoctlSetTermios(stdin, unix.TCSETS, oldTermios)
+		}()
+
+		go func() {
+			buf := make([]byte, 16)
+			for {
+				n, e := os.Stdin.Read(buf)
+				if e != nil {
+					close(keys)
+					return
+				}
+				keys <- append([]byte(nil), buf[:n]...)
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGWINCH)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(ui.interval)
+	defer ticker.Stop()
+	ui.render()
+	for i := 1; ui.iterations <= 0 || i < ui.iterations; {
+		select {
+		case <-ticker.C:
+			ui.refresh()
+			i++
+		case input, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range splitKeys(input) {
+				if !ui.handleKey(key) {
+					return nil
+				}
+			}
+		case sig := <-signals:
+			if sig != syscall.SIGWINCH {
+				return nil
+			}
+		}
+		ui.render()
+	}
+	return nil
+}
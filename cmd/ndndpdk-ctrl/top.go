@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const topQuery = `
+	{
+		faces {
+			id
+			locator
+			isDown
+			counters
+		}
+		fwdp {
+			fwds {
+				nid
+				worker {
+					nid
+				}
+				counters
+				pitCounters
+				csCounters
+			}
+		}
+		fib {
+			name
+			counters {
+				nRxInterests
+				nRxData
+				nRxNacks
+				nTxInterests
+			}
+		}
+	}
+`
+
+type topFwdInput struct {
+	NDropped uint64
+	NQueued  uint64
+}
+
+type topCsList struct {
+	Count    int
+	Capacity int
+}
+
+// topData is the result of topQuery.
+type topData struct {
+	Faces []struct {
+		ID       string                 `json:"id"`
+		Locator  map[string]interface{} `json:"locator"`
+		IsDown   bool                   `json:"isDown"`
+		Counters struct {
+			RxOctets        uint64
+			RxInterests     uint64
+			RxData          uint64
+			RxNacks         uint64
+			DecodeErrs      uint64
+			ReassDrops      uint64
+			ReassQueueDrops uint64
+			TxOctets        uint64
+			TxInterests     uint64
+			TxData          uint64
+			TxNacks         uint64
+			FragBad         uint64
+			TxAllocErrs     uint64
+			TxDropped       uint64
+		} `json:"counters"`
+	} `json:"faces"`
+	Fwdp struct {
+		Fwds []struct {
+			Nid    int `json:"nid"`
+			Worker struct {
+				Nid int `json:"nid"`
+			} `json:"worker"`
+			Counters struct {
+				InputInterest topFwdInput
+				InputData     topFwdInput
+				InputNack     topFwdInput
+			} `json:"counters"`
+			PitCounters struct {
+				NEntries uint64
+			} `json:"pitCounters"`
+			CsCounters struct {
+				MD      topCsList
+				MI      topCsList
+				NHits   uint64
+				NMisses uint64
+			} `json:"csCounters"`
+		} `json:"fwds"`
+	} `json:"fwdp"`
+	Fib []struct {
+		Name     string `json:"name"`
+		Counters struct {
+			NRxInterests uint64 `json:"nRxInterests"`
+			NRxData      uint64 `json:"nRxData"`
+			NRxNacks     uint64 `json:"nRxNacks"`
+			NTxInterests uint64 `json:"nTxInterests"`
+		} `json:"counters"`
+	} `json:"fib"`
+}
+
+// topRates computes per-second rates from cumulative counters between two samples.
+type topRates struct {
+	t    time.Time
+	prev map[string][]uint64
+	next map[string][]uint64
+	dt   float64
+}
+
+func (r *topRates) begin(t time.Time) {
+	if !r.t.IsZero() {
+		r.dt = t.Sub(r.t).Seconds()
+	}
+	r.t = t
+	r.prev, r.next = r.next, make(map[string][]uint64)
+}
+
+// rates records counters of an object, and returns their rates since the previous sample.
+// A rate is NaN if there is no previous sample, or the counter has decreased.
+func (r *topRates) rates(key string, counters ...uint64) []float64 {
+	r.next[key] = counters
+	prev := r.prev[key]
+	rates := make([]float64, len(counters))
+	for i, cnt := range counters {
+		if i >= len(prev) || cnt < prev[i] || r.dt <= 0 {
+			rates[i] = math.NaN()
+		} else {
+			rates[i] = float64(cnt-prev[i]) / r.dt
+		}
+	}
+	return rates
+}
+
+func topLocatorString(loc map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprint(&b, loc["scheme"])
+	keys := []string{}
+	for key := range loc {
+		if key != "scheme" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := loc[key].(type) {
+		case string, float64, bool:
+			fmt.Fprintf(&b, " %s=%v", key, value)
+		}
+	}
+	return b.String()
+}
+
+var (
+	topFaceColumns = []topColumn{
+		{Title: "ID", Width: 6},
+		{Title: "LOCATOR", Width: 32},
+		{Title: "STATE", Width: 5},
+		{Title: "RX-I/s", Width: 8, Num: true},
+		{Title: "RX-D/s", Width: 8, Num: true},
+		{Title: "RX-N/s", Width: 8, Num: true},
+		{Title: "RX-bps", Width: 8, Num: true},
+		{Title: "TX-I/s", Width: 8, Num: true},
+		{Title: "TX-D/s", Width: 8, Num: true},
+		{Title: "TX-N/s", Width: 8, Num: true},
+		{Title: "TX-bps", Width: 8, Num: true},
+		{Title: "DROP/s", Width: 8, Num: true},
+		{Title: "DROPS", Width: 8, Num: true},
+	}
+	topFwdColumns = []topColumn{
+		{Title: "FWD", Width: 4, Num: true},
+		{Title: "LCORE", Width: 5, Num: true},
+		{Title: "I/s", Width: 8, Num: true},
+		{Title: "D/s", Width: 8, Num: true},
+		{Title: "N/s", Width: 8, Num: true},
+		{Title: "DROP/s", Width: 8, Num: true},
+		{Title: "PIT", Width: 8, Num: true},
+		{Title: "CS-MD", Width: 8, Num: true},
+		{Title: "CS-MI", Width: 8, Num: true},
+		{Title: "CS-CAP", Width: 8, Num: true},
+		{Title: "CS-HIT%", Width: 7, Num: true},
+	}
+	topFibColumns = []topColumn{
+		{Title: "NAME", Width: 40},
+		{Title: "RX-I/s", Width: 8, Num: true},
+		{Title: "RX-D/s", Width: 8, Num: true},
+		{Title: "RX-N/s", Width: 8, Num: true},
+		{Title: "TX-I/s", Width: 8, Num: true},
+	}
+)
+
+// topModel contains sampled data and table states.
+type topModel struct {
+	rates topRates
+	faces topTable
+	fwds  topTable
+	fib   topTable
+
+	nFaces int
+	nPit   uint64
+	nCs    int
+}
+
+func newTopModel() (m *topModel) {
+	m = &topModel{}
+	m.faces = topTable{Title: "Faces", Columns: topFaceColumns, Sort: 3, Desc: true, FilterColumns: []int{0, 1}}
+	m.fwds = topTable{Title: "Forwarding threads", Columns: topFwdColumns, Sort: 0}
+	m.fib = topTable{Title: "FIB entries", Columns: topFibColumns, Sort: 1, Desc: true}
+	return m
+}
+
+func (m *topModel) tables() []*topTable {
+	return []*topTable{&m.faces, &m.fwds, &m.fib}
+}
+
+func (m *topModel) update(t time.Time, d topData) {
+	m.rates.begin(t)
+
+	m.nFaces = len(d.Faces)
+	m.faces.Rows = nil
+	for _, face := range d.Faces {
+		cnt := face.Counters
+		rxDrops := cnt.DecodeErrs + cnt.ReassDrops + cnt.ReassQueueDrops
+		txDrops := cnt.FragBad + cnt.TxAllocErrs + cnt.TxDropped
+		r := m.rates.rates("face "+face.ID, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.RxOctets,
+			cnt.TxInterests, cnt.TxData, cnt.TxNacks, cnt.TxOctets, rxDrops+txDrops)
+
+		state := "up"
+		if face.IsDown {
+			state = "down"
+		}
+		m.faces.Rows = append(m.faces.Rows, []topCell{
+			topText(face.ID), topText(topLocatorString(face.Locator)), topText(state),
+			topRate(r[0]), topRate(r[1]), topRate(r[2]), topRate(r[3] * 8),
+			topRate(r[4]), topRate(r[5]), topRate(r[6]), topRate(r[7] * 8),
+			topRate(r[8]), topCount(float64(rxDrops + txDrops)),
+		})
+	}
+
+	m.nPit, m.nCs = 0, 0
+	m.fwds.Rows = nil
+	for _, fwd := range d.Fwdp.Fwds {
+		cnt, pitCnt, csCnt := fwd.Counters, fwd.PitCounters, fwd.CsCounters
+		drops := cnt.InputInterest.NDropped + cnt.InputData.NDropped + cnt.InputNack.NDropped
+		r := m.rates.rates(fmt.Sprint("fwd ", fwd.Nid), cnt.InputInterest.NQueued, cnt.InputData.NQueued, cnt.InputNack.NQueued,
+			drops, csCnt.NHits, csCnt.NMisses)
+		m.nPit += pitCnt.NEntries
+		m.nCs += csCnt.MD.Count
+
+		hitRatio := math.NaN()
+		if lookups := r[4] + r[5]; lookups > 0 {
+			hitRatio = r[4] / lookups * 100
+		}
+		m.fwds.Rows = append(m.fwds.Rows, []topCell{
+			topCount(float64(fwd.Nid)), topCount(float64(fwd.Worker.Nid)),
+			topRate(r[0]), topRate(r[1]), topRate(r[2]), topRate(r[3]),
+			topCount(float64(pitCnt.NEntries)), topCount(float64(csCnt.MD.Count)), topCount(float64(csCnt.MI.Count)),
+			topCount(float64(csCnt.MD.Capacity)), topPercent(hitRatio),
+		})
+	}
+
+	m.fib.Rows = nil
+	for _, entry := range d.Fib {
+		cnt := entry.Counters
+		r := m.rates.rates("fib "+entry.Name, cnt.NRxInterests, cnt.NRxData, cnt.NRxNacks, cnt.NTxInterests)
+		m.fib.Rows = append(m.fib.Rows, []topCell{
+			topText(entry.Name), topRate(r[0]), topRate(r[1]), topRate(r[2]), topRate(r[3]),
+		})
+	}
+}
+
+func init() {
+	var interval time.Duration
+	var sortBy, filter string
+	var fibRows, iterations int
+	var batch bool
+
+	defineCommand(&cli.Command{
+		Name:  "top",
+		Usage: "Show face, forwarding thread, and FIB entry activities in a live view.",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:        "interval",
+				Aliases:     []string{"i"},
+				Usage:       "Refresh `interval`.",
+				Value:       time.Second,
+				Destination: &interval,
+			},
+			&cli.StringFlag{
+				Name:        "sort",
+				Usage:       "Sort faces by `column` title, such as RX-I/s or TX-bps. Prefix with + for ascending order.",
+				Destination: &sortBy,
+			},
+			&cli.StringFlag{
+				Name:        "filter",
+				Usage:       "Show faces whose ID or locator contains `text`.",
+				Destination: &filter,
+			},
+			&cli.IntFlag{
+				Name:        "fib-rows",
+				Usage:       "Number of busiest FIB entries to show.",
+				Value:       10,
+				Destination: &fibRows,
+			},
+			&cli.BoolFlag{
+				Name:        "batch",
+				Usage:       "Print each refresh as plain text instead of an interactive view.",
+				Destination: &batch,
+			},
+			&cli.IntFlag{
+				Name:        "iterations",
+				Aliases:     []string{"n"},
+				Usage:       "Exit after this many refreshes, 0 means unlimited.",
+				Destination: &iterations,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if interval <= 0 {
+				return cli.Exit("--interval must be positive", 1)
+			}
+
+			m := newTopModel()
+			m.faces.Filter = filter
+			m.fib.MaxRows = fibRows
+			if sortBy != "" {
+				title := strings.TrimPrefix(sortBy, "+")
+				if !m.faces.SortBy(title) {
+					return cli.Exit("unknown --sort column "+title, 1)
+				}
+				m.faces.Desc = !strings.HasPrefix(sortBy, "+")
+			}
+
+			ui := &topUI{
+				model:      m,
+				interval:   interval,
+				iterations: iterations,
+				fetch: func() (d topData, e error) {
+					e = client.Do(topQuery, nil, "", &d)
+					return
+				},
+			}
+			return ui.Run(batch)
+		},
+	})
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+)
+
+var watchTopics = []struct {
+	Flag  string
+	Usage string
+	Query string
+}{
+	{
+		Flag:  "face",
+		Usage: "Watch face events.",
+		Query: `
+			subscription faceEvents {
+				faceEvents {
+					event
+					nid
+					face {
+						id
+						locator
+						isDown
+					}
+				}
+			}
+		`,
+	},
+	{
+		Flag:  "fib",
+		Usage: "Watch FIB entry events.",
+		Query: `
+			subscription fibEvents {
+				fibEvents {
+					event
+					name
+					entry {
+						nexthops {
+							id
+						}
+						strategy {
+							id
+						}
+					}
+				}
+			}
+		`,
+	},
+	{
+		Flag:  "strategy",
+		Usage: "Watch strategy events.",
+		Query: `
+			subscription strategyEvents {
+				strategyEvents {
+					event
+					nid
+					name
+				}
+			}
+		`,
+	},
+}
+
+func init() {
+	selected := make([]bool, len(watchTopics))
+	var flags []cli.Flag
+	for i, topic := range watchTopics {
+		flags = append(flags, &cli.BoolFlag{
+			Name:        topic.Flag,
+			Usage:       topic.Usage,
+			Destination: &selected[i],
+		})
+	}
+
+	defineCommand(&cli.Command{
+		Name:  "watch",
+		Usage: "Watch events as JSON lines. If no topic is selected, watch all topics.",
+		Flags: flags,
+		Action: func(c *cli.Context) error {
+			hasSelected := false
+			for _, sel := range selected {
+				hasSelected = hasSelected || sel
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-interrupt
+				cancel()
+			}()
+
+			var printMutex sync.Mutex
+			onData := func(data json.RawMessage) error {
+				printMutex.Lock()
+				defer printMutex.Unlock()
+				fmt.Println(string(data))
+				return nil
+			}
+
+			var wg sync.WaitGroup
+			errs := make(chan error, len(watchTopics))
+			for i, topic := range watchTopics {
+				if hasSelected && !selected[i] {
+					continue
+				}
+				wg.Add(1)
+				go func(query string) {
+					defer wg.Done()
+					if e := client.Subscribe(ctx, query, nil, "", onData); e != nil {
+						errs <- e
+						cancel()
+					}
+				}(topic.Query)
+			}
+			wg.Wait()
+			close(errs)
+			return <-errs
+		},
+	})
+}
@@ -1,95 +1,83 @@
+// Command ndndpdk-hrlog2histogram extracts histograms from high resolution log files.
 package main
 
 import (
-	"encoding/binary"
 	"encoding/json"
 	"flag"
-	"io"
+	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
-)
 
-var (
-	r      *os.File
-	order  binary.ByteOrder
-	tschz  uint64
-	tscMul float64 // TSC*tscMul = microseconds
+	"github.com/usnistgov/ndn-dpdk/mgmt/hrlog/hrlogreader"
 )
 
+type quantilesOutput struct {
+	Act       string
+	LCore     int
+	N         int
+	Quantiles map[string]float64 // quantile => microseconds
+}
+
 func main() {
-	var filename string
+	var filename, quantiles string
+	var resolution time.Duration
 	flag.StringVar(&filename, "f", "", "input .hrlog filename")
+	flag.DurationVar(&resolution, "r", time.Microsecond, "histogram bin width")
+	flag.StringVar(&quantiles, "q", "", "comma separated quantiles, print quantiles instead of histograms")
 	flag.Parse()
 
-	var e error
-	if r, e = os.Open(filename); e != nil {
-		panic(e)
-	}
-	defer r.Close()
-	if e = readHeader(); e != nil {
-		panic(e)
+	if resolution <= 0 {
+		log.Fatalf("invalid resolution %s", resolution)
 	}
-	tscMul = float64(time.Second) / float64(time.Microsecond) / float64(tschz)
 
-	hists := readEntries()
-	var histArray []*histogram
-	for _, hist := range hists {
-		hist.Trim()
-		histArray = append(histArray, hist)
+	var qs []float64
+	if quantiles != "" {
+		for _, token := range strings.Split(quantiles, ",") {
+			q, e := strconv.ParseFloat(token, 64)
+			if e != nil || q < 0 || q > 1 {
+				log.Fatalf("invalid quantile %s", token)
+			}
+			qs = append(qs, q)
+		}
 	}
-	json.NewEncoder(os.Stdout).Encode(histArray)
-}
 
-func readHeader() (e error) {
-	var hdr [16]byte
-	if _, e = r.ReadAt(hdr[:], 0); e != nil {
-		return
+	file, e := os.Open(filename)
+	if e != nil {
+		log.Fatal(e)
 	}
+	defer file.Close()
 
-	const magic = 0x35f0498a
-	if binary.LittleEndian.Uint32(hdr[0:]) == magic {
-		order = binary.LittleEndian
-	} else if binary.BigEndian.Uint32(hdr[0:]) == magic {
-		order = binary.BigEndian
-	} else {
-		panic("invalid magic number")
+	reader, e := hrlogreader.NewReader(file)
+	if e != nil {
+		log.Fatal(e)
 	}
-
-	version := order.Uint32(hdr[4:])
-	if version != 2 {
-		panic("invalid file version")
+	hists, e := hrlogreader.ReadHistograms(reader, resolution)
+	if e != nil {
+		log.Fatal(e)
 	}
 
-	tschz = order.Uint64(hdr[8:])
-	return
-}
-
-func readEntries() (hists map[uint16]*histogram) {
-	hists = make(map[uint16]*histogram)
-	var buf [4096]byte
-	for off := int64(16); ; off += int64(len(buf)) {
-		n, e := r.ReadAt(buf[:], off)
-		if e != nil && e != io.EOF {
-			panic(e)
+	list := hists.Sorted()
+	enc := json.NewEncoder(os.Stdout)
+	if len(qs) == 0 {
+		for _, h := range list {
+			h.Trim()
 		}
+		enc.Encode(list)
+		return
+	}
 
-		for pos := 0; pos < n; pos += 8 {
-			entry := order.Uint64(buf[pos:])
-			lcoreAct := uint16(entry)
-			value := entry >> 16
-			microseconds := float64(value) * tscMul
-
-			hist := hists[lcoreAct]
-			if hist == nil {
-				hist = newHistogram(uint8(lcoreAct), uint8(lcoreAct>>8))
-				hists[lcoreAct] = hist
-			}
-			hist.Add(int(microseconds))
+	for _, h := range list {
+		output := quantilesOutput{
+			Act:       h.Act.String(),
+			LCore:     h.LCore,
+			N:         h.N,
+			Quantiles: make(map[string]float64),
 		}
-
-		if e != nil {
-			break
+		for _, q := range qs {
+			output.Quantiles[strconv.FormatFloat(q, 'f', -1, 64)] = float64(h.Quantile(q)) / float64(time.Microsecond)
 		}
+		enc.Encode(output)
 	}
-	return hists
 }
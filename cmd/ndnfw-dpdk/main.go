@@ -8,21 +8,31 @@ import (
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/ndt"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealinit"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/mgmt/hrlog"
+	"github.com/usnistgov/ndn-dpdk/mgmt/pkttrace"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/gqlmgmt"
+
+	_ "github.com/usnistgov/ndn-dpdk/mgmt/loglevel"
 )
 
 var dp *fwdp.DataPlane
 
 func main() {
-	gqlserver.Start()
+	if e := gqlserver.Start(); e != nil {
+		log.WithError(e).Error("GraphQL server error, continuing without HTTP management")
+	}
 
-	initCfg, e := parseCommand(ealinit.Init(os.Args)[1:])
+	initCfg, fwCfg, e := parseCommand(ealinit.Init(os.Args)[1:])
 	if e != nil {
 		log.WithError(e).Fatal("command line error")
 	}
 	hrlog.Init()
+	if e := pkttrace.Init(); e != nil {
+		log.WithError(e).Fatal("pkttrace init error")
+	}
 
 	initCfg.Mempool.Apply()
 	ealthread.DefaultAllocator.Config = initCfg.LCoreAlloc
@@ -30,10 +40,29 @@ func main() {
 	startDp(initCfg.Ndt, initCfg.Fib, initCfg.Fwdp)
 	startMgmt()
 	fib.GqlFib = dp.GetFib()
+	ndt.GqlNdt = dp.GetNdt()
+	ndt.GqlBalancer = dp.GetNdtBalancer()
+	fwdp.GqlDataPlane = dp
+	metrics.AddCollector("fwdp", dp.CollectMetrics)
+
+	if fwCfg != nil {
+		applyFwConfig(*fwCfg)
+	}
 
 	select {}
 }
 
+func applyFwConfig(fwCfg gqlmgmt.FwConfig) {
+	plan, e := gqlmgmt.MakePlan(gqlserver.Local, fwCfg, false)
+	if e != nil {
+		log.WithError(e).Fatal("fwconfig error")
+	}
+	if e := plan.Apply(gqlserver.Local); e != nil {
+		log.WithError(e).Fatal("fwconfig apply error")
+	}
+	log.WithField("steps", len(plan.Steps)).Info("fwconfig applied")
+}
+
 func startDp(ndtCfg ndt.Config, fibCfg fibdef.Config, dpInit fwdpInitConfig) {
 	var dpCfg fwdp.Config
 	dpCfg.Ndt = ndtCfg
@@ -52,6 +81,9 @@ func startDp(ndtCfg ndt.Config, fibCfg fibdef.Config, dpInit fwdpInitConfig) {
 	dpCfg.Pcct.MaxEntries = dpInit.PcctCapacity
 	dpCfg.Pcct.CsCapMd = dpInit.CsCapMd
 	dpCfg.Pcct.CsCapMi = dpInit.CsCapMi
+	dpCfg.Pcct.DeadNonceCapacity = dpInit.DeadNonceCapacity
+	dpCfg.Pcct.DeadNonceLifetime = dpInit.DeadNonceLifetime
+	dpCfg.NdtBalancer = dpInit.NdtBalancer
 
 	// create and launch dataplane
 	var e error
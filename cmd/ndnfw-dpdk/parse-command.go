@@ -7,10 +7,12 @@ import (
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/ndt"
 	"github.com/usnistgov/ndn-dpdk/container/pit"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/core/yamlflag"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
 	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/gqlmgmt"
 
 	_ "github.com/usnistgov/ndn-dpdk/iface/ethface"
 	_ "github.com/usnistgov/ndn-dpdk/iface/socketface"
@@ -33,9 +35,12 @@ type fwdpInitConfig struct {
 	PcctCapacity      int
 	CsCapMd           int
 	CsCapMi           int
+	DeadNonceCapacity int
+	DeadNonceLifetime nnduration.Milliseconds
+	NdtBalancer       ndt.BalancerConfig
 }
 
-func parseCommand(args []string) (initCfg initConfig, e error) {
+func parseCommand(args []string) (initCfg initConfig, fwCfg *gqlmgmt.FwConfig, e error) {
 	initCfg.Ndt.PrefixLen = 2
 	initCfg.Ndt.IndexBits = 16
 	initCfg.Ndt.SampleFreq = 8
@@ -46,14 +51,22 @@ func parseCommand(args []string) (initCfg initConfig, e error) {
 	initCfg.Fwdp.PcctCapacity = 131071
 	initCfg.Fwdp.CsCapMd = 32768
 	initCfg.Fwdp.CsCapMi = 32768
+	initCfg.Fwdp.DeadNonceCapacity = 65536
 
 	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	flags.Var(yamlflag.New(&initCfg), "initcfg", "initialization config object")
+	var fwCfgValue gqlmgmt.FwConfig
+	flags.Var(yamlflag.New(&fwCfgValue), "fwconfig", "declarative forwarder config document")
 
 	e = flags.Parse(args)
 	if e != nil {
-		return initConfig{}, e
+		return initConfig{}, nil, e
 	}
 
-	return initCfg, nil
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "fwconfig" {
+			fwCfg = &fwCfgValue
+		}
+	})
+	return initCfg, fwCfg, nil
 }
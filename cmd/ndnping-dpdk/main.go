@@ -6,15 +6,24 @@ import (
 	"time"
 
 	"github.com/usnistgov/ndn-dpdk/app/ping"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealinit"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/mgmt"
 	"github.com/usnistgov/ndn-dpdk/mgmt/facemgmt"
 	"github.com/usnistgov/ndn-dpdk/mgmt/pingmgmt"
 	"github.com/usnistgov/ndn-dpdk/mgmt/versionmgmt"
+
+	_ "github.com/usnistgov/ndn-dpdk/mgmt/loglevel"
 )
 
 func main() {
+	gqlserver.DefaultHTTPAddr = "127.0.0.1:3031"
+	if e := gqlserver.Start(); e != nil {
+		log.WithError(e).Fatal("GraphQL server error")
+	}
+
 	pc, e := parseCommand(ealinit.Init(os.Args)[1:])
 	if e != nil {
 		log.WithError(e).Fatal("command line error")
@@ -29,6 +38,8 @@ func main() {
 	}
 
 	app.Launch()
+	ping.GqlApp = app
+	metrics.AddCollector("ping", app.CollectMetrics)
 
 	if pc.counterInterval > 0 {
 		go printPeriodicCounters(app, pc.counterInterval)
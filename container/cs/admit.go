@@ -0,0 +1,160 @@
+package cs
+
+/*
+#include "../../csrc/pcct/cs.h"
+*/
+import "C"
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Errors returned by SetAdmitRules.
+var (
+	ErrAdmitRules       = errors.New("too many admission rules")
+	ErrAdmitPolicy      = errors.New("invalid admission policy")
+	ErrAdmitProbability = errors.New("admission probability out of range")
+	ErrAdmitPrefix      = errors.New("admission rule prefix too long")
+	ErrAdmitDupPrefix   = errors.New("duplicate prefix in admission rules")
+)
+
+// AdmitRule is a CS admission rule.
+type AdmitRule struct {
+	Prefix ndn.Name    `json:"prefix"`
+	Policy AdmitPolicy `json:"policy"`
+
+	// Probability is the admission probability of AdmitRandom policy, between 0.0 and 1.0.
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// AdmitCounters contains counters of a CS admission rule.
+type AdmitCounters struct {
+	NAdmitted uint64 `json:"nAdmitted"`
+	NRejected uint64 `json:"nRejected"`
+}
+
+// Add combines counters.
+func (cnt AdmitCounters) Add(other AdmitCounters) AdmitCounters {
+	return AdmitCounters{
+		NAdmitted: cnt.NAdmitted + other.NAdmitted,
+		NRejected: cnt.NRejected + other.NRejected,
+	}
+}
+
+// AdmitRuleInfo contains an admission rule and its counters.
+type AdmitRuleInfo struct {
+	AdmitRule
+	AdmitCounters
+}
+
+// AdmitRules returns admission rules and their counters.
+func (cs *Cs) AdmitRules() (list []AdmitRuleInfo) {
+	admit := cs.ptr().admit
+	if admit == nil {
+		return nil
+	}
+
+	for _, r := range admit.rules[:admit.nRules] {
+		var info AdmitRuleInfo
+		info.Prefix.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&r.prefixV[0]), C.int(r.prefixL)))
+		info.Policy = AdmitPolicy(r.policy)
+		if info.Policy == AdmitRandom {
+			info.Probability = float64(r.randThreshold) / (math.MaxUint32 + 1)
+		}
+		info.NAdmitted = uint64(r.nAdmitted)
+		info.NRejected = uint64(r.nRejected)
+		list = append(list, info)
+	}
+	return list
+}
+
+// SetAdmitRules replaces admission rules.
+// Data is admitted under the rule with longest matching prefix; if no rule matches, Data is admitted.
+// Counters of a rule are retained if its prefix and policy are unchanged.
+// This function waits for an RCU grace period, and should not be called from an RCU read-side thread.
+func (cs *Cs) SetAdmitRules(rules []AdmitRule, socket eal.NumaSocket) error {
+	if len(rules) > MaxAdmitRules {
+		return ErrAdmitRules
+	}
+	rules = append([]AdmitRule{}, rules...)
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].Prefix) > len(rules[j].Prefix) })
+
+	var admit *C.CsAdmit
+	if len(rules) > 0 {
+		admit = (*C.CsAdmit)(eal.Zmalloc("CsAdmit", C.sizeof_CsAdmit, socket))
+		for i, rule := range rules {
+			r := &admit.rules[i]
+			if e := rule.copyToC(r); e != nil {
+				eal.Free(admit)
+				return e
+			}
+			for _, prev := range rules[:i] {
+				if prev.Prefix.Equal(rule.Prefix) {
+					eal.Free(admit)
+					return ErrAdmitDupPrefix
+				}
+			}
+		}
+		admit.nRules = C.uint32_t(len(rules))
+	}
+
+	old := C.Cs_SetAdmit(cs.ptr(), admit)
+	if old == nil {
+		return nil
+	}
+
+	// After the grace period, the forwarding thread no longer updates the old rules, so that their
+	// counters are final and can be carried over to the new rules without losing increments.
+	urcu.Synchronize()
+	if admit != nil {
+		for i := range old.rules[:old.nRules] {
+			o := &old.rules[i]
+			for j := range admit.rules[:admit.nRules] {
+				r := &admit.rules[j]
+				if r.policy == o.policy && r.prefixL == o.prefixL &&
+					C.memcmp(unsafe.Pointer(&r.prefixV[0]), unsafe.Pointer(&o.prefixV[0]), C.size_t(o.prefixL)) == 0 {
+					atomic.AddUint64((*uint64)(unsafe.Pointer(&r.nAdmitted)), uint64(o.nAdmitted))
+					atomic.AddUint64((*uint64)(unsafe.Pointer(&r.nRejected)), uint64(o.nRejected))
+					break
+				}
+			}
+		}
+	}
+	eal.Free(old)
+	return nil
+}
+
+func (rule AdmitRule) copyToC(r *C.CsAdmitRule) error {
+	switch rule.Policy {
+	case AdmitAlways, AdmitNever, AdmitMustBeFresh:
+	case AdmitRandom:
+		if !(rule.Probability >= 0.0 && rule.Probability <= 1.0) {
+			return ErrAdmitProbability
+		}
+		r.randThreshold = C.uint64_t(math.Round(rule.Probability * (math.MaxUint32 + 1)))
+	default:
+		return ErrAdmitPolicy
+	}
+	r.policy = C.CsAdmitPolicy(rule.Policy)
+
+	prefixV, e := rule.Prefix.MarshalBinary()
+	if e != nil {
+		return e
+	}
+	if len(prefixV) > ndni.NameMaxLength {
+		return ErrAdmitPrefix
+	}
+	for i, b := range prefixV {
+		r.prefixV[i] = C.uint8_t(b)
+	}
+	r.prefixL = C.uint16_t(len(prefixV))
+	return nil
+}
@@ -0,0 +1,112 @@
+package cs_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs"
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestAdmit(t *testing.T) {
+	assert, require := makeAR(t)
+	var cfg pcct.Config
+	fixture := NewFixture(cfg)
+	defer fixture.Close()
+
+	assert.Error(fixture.Cs.SetAdmitRules([]cs.AdmitRule{
+		{Prefix: ndn.ParseName("/R"), Policy: cs.AdmitRandom, Probability: 1.5},
+	}, eal.NumaSocket{}))
+	assert.Error(fixture.Cs.SetAdmitRules([]cs.AdmitRule{
+		{Prefix: ndn.ParseName("/N"), Policy: cs.AdmitNever},
+		{Prefix: ndn.ParseName("/N"), Policy: cs.AdmitAlways},
+	}, eal.NumaSocket{}))
+	assert.Len(fixture.Cs.AdmitRules(), 0)
+
+	require.NoError(fixture.Cs.SetAdmitRules([]cs.AdmitRule{
+		{Prefix: ndn.ParseName("/N"), Policy: cs.AdmitNever},
+		{Prefix: ndn.ParseName("/N/A"), Policy: cs.AdmitAlways},
+		{Prefix: ndn.ParseName("/F"), Policy: cs.AdmitMustBeFresh},
+		{Prefix: ndn.ParseName("/R"), Policy: cs.AdmitRandom, Probability: 0.5},
+	}, eal.NumaSocket{}))
+
+	assert.True(fixture.Insert(makeInterest("/N/1"), makeData("/N/1")))
+	assert.Nil(fixture.Find(makeInterest("/N/1")))
+	assert.True(fixture.Insert(makeInterest("/N/A/1"), makeData("/N/A/1")))
+	assert.NotNil(fixture.Find(makeInterest("/N/A/1")))
+	assert.True(fixture.Insert(makeInterest("/F/1"), makeData("/F/1")))
+	assert.Nil(fixture.Find(makeInterest("/F/1")))
+	assert.True(fixture.Insert(makeInterest("/F/2", ndn.MustBeFreshFlag), makeData("/F/2")))
+	assert.NotNil(fixture.Find(makeInterest("/F/2")))
+	assert.True(fixture.Insert(makeInterest("/O/1"), makeData("/O/1")))
+	assert.NotNil(fixture.Find(makeInterest("/O/1")))
+
+	nInserted := fixture.InsertBulk(0, 99, "/R/%d", "/R/%d")
+	assert.Equal(100, nInserted)
+	nFound := fixture.FindBulk(0, 99, "/R/%d")
+	assert.InDelta(50, nFound, 25)
+
+	rules := fixture.Cs.AdmitRules()
+	require.Len(rules, 4)
+	nameEqual(assert, "/N/A", rules[0].Prefix)
+	assert.Equal(cs.AdmitAlways, rules[0].Policy)
+	assert.EqualValues(1, rules[0].NAdmitted)
+	assert.EqualValues(0, rules[0].NRejected)
+	nameEqual(assert, "/N", rules[1].Prefix)
+	assert.EqualValues(0, rules[1].NAdmitted)
+	assert.EqualValues(1, rules[1].NRejected)
+	nameEqual(assert, "/F", rules[2].Prefix)
+	assert.EqualValues(1, rules[2].NAdmitted)
+	assert.EqualValues(1, rules[2].NRejected)
+	nameEqual(assert, "/R", rules[3].Prefix)
+	assert.InDelta(0.5, rules[3].Probability, 0.001)
+	assert.EqualValues(100, rules[3].NAdmitted+rules[3].NRejected)
+	assert.EqualValues(nFound, rules[3].NAdmitted)
+
+	require.NoError(fixture.Cs.SetAdmitRules([]cs.AdmitRule{
+		{Prefix: ndn.ParseName("/N"), Policy: cs.AdmitNever},
+	}, eal.NumaSocket{}))
+	rules = fixture.Cs.AdmitRules()
+	require.Len(rules, 1)
+	assert.EqualValues(1, rules[0].NRejected)
+
+	require.NoError(fixture.Cs.SetAdmitRules(nil, eal.NumaSocket{}))
+	assert.Len(fixture.Cs.AdmitRules(), 0)
+}
+
+func TestEraseWalk(t *testing.T) {
+	assert, require := makeAR(t)
+	var cfg pcct.Config
+	fixture := NewFixture(cfg)
+	defer fixture.Close()
+
+	assert.Equal(50, fixture.InsertBulk(0, 49, "/A/%d", "/A/%d"))
+	assert.Equal(50, fixture.InsertBulk(0, 49, "/B/%d/C", "/B/%d", ndn.CanBePrefixFlag))
+	assert.Equal(100, fixture.Cs.CountEntries(cs.ListMd))
+	assert.Equal(50, fixture.Cs.CountEntries(cs.ListMi))
+
+	w, e := pcct.NewWalk(pcct.WalkCsErase, ndn.ParseName("/B"), 0, 0, eal.NumaSocket{})
+	require.NoError(e)
+	w.Run(fixture.Pcct)
+	assert.Equal(100, w.NErased())
+	w.Close()
+	assert.Equal(50, fixture.Cs.CountEntries(cs.ListMd))
+	assert.Equal(0, fixture.Cs.CountEntries(cs.ListMi))
+	assert.Equal(0, fixture.FindBulk(0, 49, "/B/%d", ndn.CanBePrefixFlag))
+
+	w, e = pcct.NewWalk(pcct.WalkCsErase, ndn.ParseName("/A/7"), 0, 0, eal.NumaSocket{})
+	require.NoError(e)
+	w.Run(fixture.Pcct)
+	assert.Equal(1, w.NErased())
+	w.Close()
+	assert.Equal(49, fixture.FindBulk(0, 49, "/A/%d"))
+
+	w, e = pcct.NewWalk(pcct.WalkCsErase, ndn.Name{}, 0, 0, eal.NumaSocket{})
+	require.NoError(e)
+	w.Run(fixture.Pcct)
+	assert.Equal(49, w.NErased())
+	w.Close()
+	assert.Zero(fixture.Cs.CountEntries(cs.ListMd))
+	assert.Zero(fixture.CountMpInUse())
+}
@@ -17,3 +17,23 @@ const (
 
 	_ = "enumgen:CsListID:Csl:List"
 )
+
+// AdmitPolicy determines whether a Data packet is admitted into the CS.
+type AdmitPolicy int
+
+// AdmitPolicy values.
+const (
+	AdmitAlways      AdmitPolicy = iota // always admit
+	AdmitNever                          // never admit
+	AdmitMustBeFresh                    // admit only if it satisfies a MustBeFresh Interest
+	AdmitRandom                         // admit with a probability
+
+	_ = "enumgen:CsAdmitPolicy:Cs"
+)
+
+// MaxAdmitRules is the maximum number of admission rules.
+const (
+	MaxAdmitRules = 16
+
+	_ = "enumgen::Cs"
+)
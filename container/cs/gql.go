@@ -0,0 +1,205 @@
+package cs
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GraphQL types.
+var (
+	GqlListType           *graphql.Enum
+	GqlEntryType          *graphql.Object
+	GqlAdmitPolicyType    *graphql.Enum
+	GqlAdmitRuleType      *graphql.Object
+	GqlAdmitRuleInputType *graphql.InputObject
+)
+
+func init() {
+	GqlListType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "CsList",
+		Description: "CS list that contains an entry.",
+		Values: graphql.EnumValueConfigMap{
+			"T1": &graphql.EnumValueConfig{
+				Value:       ListMdT1,
+				Description: "Direct entries that appeared once.",
+			},
+			"T2": &graphql.EnumValueConfig{
+				Value:       ListMdT2,
+				Description: "Direct entries that appeared more than once.",
+			},
+			"MI": &graphql.EnumValueConfig{
+				Value:       ListMi,
+				Description: "Indirect entries.",
+			},
+		},
+	})
+
+	GqlEntryType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "CsEntry",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Entry name. For an indirect entry, this is the Interest name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.Name, nil
+				},
+			},
+			"fwHint": &graphql.Field{
+				Description: "Forwarding hint delegation name.",
+				Type:        ndni.GqlNameType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return gqlserver.Optional(entry.FwHint, len(entry.FwHint) > 0), nil
+				},
+			},
+			"list": &graphql.Field{
+				Description: "List membership.",
+				Type:        graphql.NewNonNull(GqlListType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.List, nil
+				},
+			},
+			"nIndirects": &graphql.Field{
+				Description: "Number of indirect entries associated with a direct entry.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.NIndirects, nil
+				},
+			},
+			"freshUntil": &graphql.Field{
+				Description: "When the Data becomes non-fresh.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.FreshUntil.ToTime(), nil
+				},
+			},
+			"isFresh": &graphql.Field{
+				Description: "Whether the Data is fresh.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.IsFresh(), nil
+				},
+			},
+			"dataLength": &graphql.Field{
+				Description: "Data packet length in octets.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.DataLen, nil
+				},
+			},
+		},
+	})
+
+	GqlAdmitPolicyType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "CsAdmitPolicy",
+		Description: "CS admission policy.",
+		Values: graphql.EnumValueConfigMap{
+			"ALWAYS": &graphql.EnumValueConfig{
+				Value:       AdmitAlways,
+				Description: "Always admit.",
+			},
+			"NEVER": &graphql.EnumValueConfig{
+				Value:       AdmitNever,
+				Description: "Never admit.",
+			},
+			"MUST_BE_FRESH": &graphql.EnumValueConfig{
+				Value:       AdmitMustBeFresh,
+				Description: "Admit only if Data satisfies a pending MustBeFresh Interest.",
+			},
+			"RANDOM": &graphql.EnumValueConfig{
+				Value:       AdmitRandom,
+				Description: "Admit with a probability.",
+			},
+		},
+	})
+
+	GqlAdmitRuleType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "CsAdmitRule",
+		Fields: graphql.Fields{
+			"prefix": &graphql.Field{
+				Description: "Data name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(AdmitRuleInfo)
+					return info.Prefix, nil
+				},
+			},
+			"policy": &graphql.Field{
+				Description: "Admission policy.",
+				Type:        graphql.NewNonNull(GqlAdmitPolicyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(AdmitRuleInfo)
+					return info.Policy, nil
+				},
+			},
+			"probability": &graphql.Field{
+				Description: "Admission probability of RANDOM policy.",
+				Type:        graphql.Float,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(AdmitRuleInfo)
+					return gqlserver.Optional(info.Probability, info.Policy == AdmitRandom), nil
+				},
+			},
+			"nAdmitted": &graphql.Field{
+				Description: "Number of Data packets admitted under this rule.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(AdmitRuleInfo)
+					return info.NAdmitted, nil
+				},
+			},
+			"nRejected": &graphql.Field{
+				Description: "Number of Data packets rejected under this rule.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					info := p.Source.(AdmitRuleInfo)
+					return info.NRejected, nil
+				},
+			},
+		},
+	})
+
+	GqlAdmitRuleInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "CsAdmitRuleInput",
+		Description: "CS admission rule.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"prefix": &graphql.InputObjectFieldConfig{
+				Description: "Data name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"policy": &graphql.InputObjectFieldConfig{
+				Description: "Admission policy.",
+				Type:        graphql.NewNonNull(GqlAdmitPolicyType),
+			},
+			"probability": &graphql.InputObjectFieldConfig{
+				Description:  "Admission probability of RANDOM policy, between 0.0 and 1.0.",
+				Type:         graphql.Float,
+				DefaultValue: 1.0,
+			},
+		},
+	})
+}
+
+// ParseGqlAdmitRules parses a list of CsAdmitRuleInput.
+func ParseGqlAdmitRules(arg interface{}) (rules []AdmitRule) {
+	for _, item := range arg.([]interface{}) {
+		m := item.(map[string]interface{})
+		rule := AdmitRule{
+			Prefix: m["prefix"].(ndn.Name),
+			Policy: m["policy"].(AdmitPolicy),
+		}
+		if rule.Policy == AdmitRandom {
+			rule.Probability, _ = m["probability"].(float64)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,53 @@
+package cs
+
+/*
+#include "../../csrc/pcct/walk.h"
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// EntryInfo is a snapshot of a CS entry.
+type EntryInfo struct {
+	Name       ndn.Name
+	FwHint     ndn.Name
+	List       ListID // ListMdT1, ListMdT2, or ListMi
+	IsDirect   bool
+	NIndirects int // number of indirect entries associated with a direct entry
+	FreshUntil eal.TscTime
+	Now        eal.TscTime // when this snapshot was taken
+	DataLen    int         // Data packet length
+}
+
+// IsFresh determines whether the Data was fresh when this snapshot was taken.
+func (info EntryInfo) IsFresh() bool {
+	return info.FreshUntil > info.Now
+}
+
+// ListEntries extracts CS entry snapshots from a completed Walk of pcct.WalkCs kind.
+func ListEntries(w *pcct.Walk) (list []EntryInfo) {
+	list = make([]EntryInfo, w.Len())
+	for i := range list {
+		info := &list[i]
+		var ptr unsafe.Pointer
+		info.Name, info.FwHint, ptr = w.Record(i)
+		rec := (*C.PcctWalkCsRecord)(ptr)
+
+		info.IsDirect = rec.nIndirects >= 0
+		if info.IsDirect {
+			info.List = ListID(rec.arcList)
+			info.NIndirects = int(rec.nIndirects)
+		} else {
+			info.List = ListMi
+		}
+		info.FreshUntil = eal.TscTime(rec.freshUntil)
+		info.Now = w.Now()
+		info.DataLen = int(rec.dataLen)
+	}
+	return list
+}
@@ -3,11 +3,13 @@ package fib
 
 import (
 	"fmt"
+	"strings"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibreplica"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibtree"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/urcu"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/ndn"
@@ -38,6 +40,7 @@ func (fib *Fib) Replica(socket eal.NumaSocket) *fibreplica.Table {
 
 // Close frees the FIB.
 func (fib *Fib) Close() (e error) {
+	strategycode.UnregisterMigrator(fib)
 	eal.CallMain(fib.doClose)
 	return nil
 }
@@ -94,6 +97,58 @@ func (fib *Fib) Erase(name ndn.Name) (e error) {
 	return e
 }
 
+// MigrateStrategy switches every entry using oldSc to use newSc.
+// This implements strategycode.Migrator.
+//
+// Each entry is replaced via RCU, while no other FIB update may interleave.
+// If resetScratch is false, counters and strategy scratch areas are copied to the new entry, and
+// PIT entries referencing the old entry remain valid.
+// Otherwise, they are cleared.
+// If an update fails, already migrated entries are reverted to oldSc, and any revert failures are
+// appended to the returned error.
+// The revert does not restore counters and scratch areas cleared by resetScratch.
+func (fib *Fib) MigrateStrategy(oldSc, newSc *strategycode.Strategy, resetScratch bool) (n int, e error) {
+	oldID, newID := oldSc.ID(), newSc.ID()
+	eal.CallMain(func() {
+		var migrated []fibdef.Entry
+		for _, entry := range fib.tree.List() {
+			if entry.Strategy != oldID {
+				continue
+			}
+
+			entry.Strategy = newID
+			if e = fib.doMigrate(entry, resetScratch); e != nil {
+				break
+			}
+			migrated = append(migrated, entry)
+		}
+
+		if e != nil {
+			var revertErrs []string
+			for _, entry := range migrated {
+				entry.Strategy = oldID
+				if re := fib.doMigrate(entry, false); re != nil {
+					revertErrs = append(revertErrs, fmt.Sprintf("%s: %v", entry.Name, re))
+				}
+			}
+			if len(revertErrs) > 0 {
+				e = fmt.Errorf("%w; revert failed: %s", e, strings.Join(revertErrs, ", "))
+			}
+			return
+		}
+		n = len(migrated)
+	})
+	return n, e
+}
+
+func (fib *Fib) doMigrate(entry fibdef.Entry, resetScratch bool) error {
+	tu := fib.tree.Insert(entry)
+	if u := tu.Real(); u != nil {
+		u.KeepDyn = !resetScratch
+	}
+	return fib.doUpdate(tu)
+}
+
 func (fib *Fib) doUpdate(tu fibdef.Update) error {
 	updates := make(map[*fibreplica.Table]*fibreplica.UpdateCommand)
 	for socket, replica := range fib.replicas {
@@ -112,6 +167,9 @@ func (fib *Fib) doUpdate(tu fibdef.Update) error {
 		replica.ExecuteUpdate(u)
 	}
 	tu.Commit()
+	if u := tu.Real(); u != nil && fib == GqlFib {
+		gqlFibPublisher.Publish(gqlFibEvent{u.Action, u.Name})
+	}
 	return nil
 }
 
@@ -144,6 +202,7 @@ func New(cfg fibdef.Config, threads []LookupThread) (*Fib, error) {
 			th.SetFib(replica, i)
 		}
 	}
+	strategycode.RegisterMigrator(fib)
 	return fib, nil
 }
 
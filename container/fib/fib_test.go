@@ -6,7 +6,9 @@ import (
 
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibreplica"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibtestenv"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
@@ -204,3 +206,89 @@ func TestLpm(t *testing.T) {
 	checkEntryNames()
 	checkLpms(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
 }
+
+func TestLpmTrace(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	f.Insert(makeEntry("/", nil, 5000))
+	f.Insert(makeEntry("/A", nil, 5100))
+	f.Insert(makeEntry("/A/B/C", nil, 5101))   // insert virtual /A/B
+	f.Insert(makeEntry("/E/F", nil, 5200))     // real entry at StartDepth
+	f.Insert(makeEntry("/J/K/L/M", nil, 5300)) // insert virtual /J/K
+	f.Insert(makeEntry("/J/K", nil, 5301))     // real entry behind virtual /J/K
+
+	replica := f.Replica(th0.Socket)
+	for _, tt := range []struct {
+		name  string
+		trace fibreplica.LpmTrace
+	}{
+		{"/A", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageSkipped, SecondStageStart: 1, NProbes: 1, MatchLength: 1}},
+		{"/X/Y/Z", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageNone, SecondStageStart: 1, NProbes: 3, MatchLength: 0}},
+		{"/E/F/G", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageReal, SecondStageStart: -1, NProbes: 1, MatchLength: 2}},
+		{"/A/B/C/D", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageVirtual, Height: 1, SecondStageStart: 3, NProbes: 2, MatchLength: 3}},
+		{"/A/B/CD", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageVirtual, Height: 1, SecondStageStart: 3, NProbes: 4, MatchLength: 1}},
+		{"/J/K/L", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageVirtual, Height: 2, SecondStageStart: 3, NProbes: 3, MatchLength: 2}},
+		{"/J/K/L/M/N", fibreplica.LpmTrace{FirstStage: fibreplica.LpmFirstStageVirtual, Height: 2, SecondStageStart: 4, NProbes: 2, MatchLength: 4}},
+	} {
+		tt.trace.StartDepth = 2
+		name := ndn.ParseName(tt.name)
+		entry, trace := replica.LpmTrace(name)
+		assert.Equal(replica.Lpm(name), entry, tt.name)
+		assert.Equal(tt.trace, trace, tt.name)
+	}
+}
+
+func TestReplaceStrategy(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	scP := strategycode.MakeEmpty("P")
+	scQ := strategycode.MakeEmpty("Q")
+	scR := strategycode.MakeEmpty("R")
+	defer scR.Close()
+
+	f.Insert(makeEntry("/A", scP, 5000))
+	f.Insert(makeEntry("/A/B/C", scP, 5001)) // insert virtual /A/B
+	f.Insert(makeEntry("/A/B", scP, 5002))   // real entry behind virtual /A/B
+	f.Insert(makeEntry("/D", scR, 5003))
+
+	n, e := strategycode.Replace(scP, scQ, false)
+	assert.NoError(e)
+	assert.Equal(3, n)
+	assert.Nil(strategycode.Get(scP.ID()))
+
+	for _, name := range []string{"/A", "/A/B/C", "/A/B"} {
+		if entry := f.Find(ndn.ParseName(name)); assert.NotNil(entry, name) {
+			assert.Equal(scQ.ID(), entry.Strategy, name)
+		}
+		if entry := f.Replica(th0.Socket).Lpm(ndn.ParseName(name)); assert.NotNil(entry, name) {
+			assert.Equal(scQ.ID(), entry.Read().Strategy, name)
+		}
+	}
+	if entry := f.Find(ndn.ParseName("/D")); assert.NotNil(entry) {
+		assert.Equal(scR.ID(), entry.Strategy)
+	}
+
+	n, e = strategycode.Replace(scQ, scR, true)
+	assert.NoError(e)
+	assert.Equal(3, n)
+	assert.Len(strategycode.List(), 1)
+
+	_, e = strategycode.Replace(scR, scR, true)
+	assert.Error(e)
+}
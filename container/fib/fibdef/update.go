@@ -20,6 +20,10 @@ type RealUpdate struct {
 	Name     ndn.Name
 	Action   UpdateAction
 	WithVirt *VirtUpdate
+
+	// KeepDyn indicates that ActReplace should copy counters and strategy scratch areas from the
+	// old entry, and retain its sequence number so that PIT entries referencing it remain valid.
+	KeepDyn bool
 }
 
 // VirtUpdate represents a virtual entry update command.
@@ -90,6 +90,15 @@ func (entry *Entry) assignReal(u *fibdef.RealUpdate) {
 
 	ptrStrategy := C.FibEntry_PtrStrategy(c)
 	*ptrStrategy = (*C.StrategyCode)(strategycode.Get(u.Strategy).Ptr())
+	C.StrategyCode_Ref(*ptrStrategy)
+}
+
+func (entry *Entry) copyDyn(src *Entry, t *Table) {
+	c, srcC := entry.ptr(), src.ptr()
+	for i := 0; i < t.nDyns; i++ {
+		*C.FibEntry_PtrDyn(c, C.int(i)) = *C.FibEntry_PtrDyn(srcC, C.int(i))
+	}
+	c.seqNum = srcC.seqNum
 }
 
 func (entry *Entry) assignVirt(u *fibdef.VirtUpdate, real *Entry) {
@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"unsafe"
 
+	mathpkg "github.com/pkg/math"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/mempool"
@@ -51,6 +52,69 @@ func (t *Table) Lpm(name ndn.Name) *Entry {
 	return entryFromPtr(C.Fib_Lpm(t.c, (*C.PName)(pname.Ptr())))
 }
 
+// LpmTrace records the steps of 2-stage longest prefix match.
+type LpmTrace struct {
+	StartDepth int // 'M' in 2-stage LPM algorithm
+
+	// FirstStage is the first stage result at StartDepth:
+	// "skipped" if the name has no more than StartDepth components,
+	// "none" if no entry exists, "virtual" if a virtual entry exists, "real" if a real entry exists.
+	FirstStage string
+
+	Height           int // height of the virtual entry found in the first stage
+	SecondStageStart int // prefix length where the second stage starts, -1 if not performed
+	NProbes          int // number of hashtable lookups
+	MatchLength      int // prefix length of the matched real entry, -1 if no match
+}
+
+// First stage results.
+const (
+	LpmFirstStageSkipped = "skipped"
+	LpmFirstStageNone    = "none"
+	LpmFirstStageVirtual = "virtual"
+	LpmFirstStageReal    = "real"
+)
+
+// LpmTrace performs longest prefix match, and records the steps.
+// This follows the same algorithm as Lpm, so that the returned entry is the same as Lpm.
+func (t *Table) LpmTrace(name ndn.Name) (entry *Entry, trace LpmTrace) {
+	trace.StartDepth = int(t.c.startDepth)
+	trace.SecondStageStart, trace.MatchLength = -1, -1
+	get := func(prefixLen int) *Entry {
+		trace.NProbes++
+		return t.Get(name[:prefixLen])
+	}
+
+	prefixLen := len(name)
+	if trace.StartDepth < prefixLen {
+		switch entry = get(trace.StartDepth); {
+		case entry == nil:
+			trace.FirstStage = LpmFirstStageNone
+			prefixLen = trace.StartDepth - 1
+		case entry.IsVirt():
+			trace.FirstStage = LpmFirstStageVirtual
+			trace.Height = int(entry.ptr().height)
+			prefixLen = mathpkg.MinInt(trace.StartDepth+trace.Height, len(name))
+		default:
+			trace.FirstStage = LpmFirstStageReal
+			trace.MatchLength = trace.StartDepth
+			return entry, trace
+		}
+	} else {
+		trace.FirstStage = LpmFirstStageSkipped
+	}
+
+	trace.SecondStageStart = prefixLen
+	for ; prefixLen >= 0; prefixLen-- {
+		if entry = get(prefixLen); entry != nil && entry.Real() != nil {
+			entry = entry.Real()
+			trace.MatchLength = int(entry.ptr().nComps)
+			return entry, trace
+		}
+	}
+	return nil, trace
+}
+
 func (t *Table) allocBulk(entries []*Entry) error {
 	if len(entries) == 0 {
 		return nil
@@ -62,8 +126,8 @@ func (t *Table) allocBulk(entries []*Entry) error {
 	return nil
 }
 
-func (t *Table) write(entry *Entry) {
-	C.Fib_Write(t.c, entry.ptr())
+func (t *Table) write(entry *Entry, keepSeqNum bool) {
+	C.Fib_Write(t.c, entry.ptr(), C.bool(keepSeqNum))
 }
 
 func (t *Table) erase(entry *Entry) {
@@ -97,18 +97,22 @@ func (u *realUpdate) execute(t *Table, allocated []*Entry) {
 	case fibdef.ActInsert, fibdef.ActReplace:
 		u.newReal = allocated[0]
 		u.newReal.assignReal(u.RealUpdate)
+		keepDyn := u.KeepDyn && u.oldReal != nil
+		if keepDyn {
+			u.newReal.copyDyn(u.oldReal, t)
+		}
 		if u.WithVirt != nil {
 			u.newVirt = allocated[1]
 			u.newVirt.assignVirt(u.WithVirt, u.newReal)
-			t.write(u.newVirt)
+			t.write(u.newVirt, keepDyn)
 		} else {
-			t.write(u.newReal)
+			t.write(u.newReal, keepDyn)
 		}
 	case fibdef.ActErase:
 		if u.WithVirt != nil {
 			u.newVirt = allocated[0]
 			u.newVirt.assignVirt(u.WithVirt, nil)
-			t.write(u.newVirt)
+			t.write(u.newVirt, false)
 		} else if u.oldVirt != nil {
 			t.erase(u.oldVirt)
 		} else {
@@ -165,12 +169,12 @@ func (u *virtUpdate) execute(t *Table, allocated []*Entry) {
 	case fibdef.ActInsert, fibdef.ActReplace:
 		u.newVirt = allocated[0]
 		u.newVirt.assignVirt(u.VirtUpdate, u.oldReal)
-		t.write(u.newVirt)
+		t.write(u.newVirt, false)
 	case fibdef.ActErase:
 		if u.oldReal == nil {
 			t.erase(u.oldVirt)
 		} else {
-			t.write(u.oldReal)
+			t.write(u.oldReal, false)
 		}
 	}
 
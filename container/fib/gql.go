@@ -26,8 +26,16 @@ var (
 	GqlEntryCountersType graphql.Type
 	GqlEntryNodeType     *gqlserver.NodeType
 	GqlEntryType         *graphql.Object
+	GqlEntryEventType    *graphql.Object
 )
 
+type gqlFibEvent struct {
+	Action fibdef.UpdateAction
+	Name   ndn.Name
+}
+
+var gqlFibPublisher = gqlserver.NewPublisher()
+
 func init() {
 	GqlEntryCountersType = graphql.NewObject(graphql.ObjectConfig{
 		Name:   "FibEntryCounters",
@@ -224,4 +232,53 @@ func init() {
 			return *GqlFib.Find(entry.Name), nil
 		},
 	})
+
+	GqlEntryEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "FibEntryEvent",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Description: "Update action.",
+				Type: graphql.NewNonNull(graphql.NewEnum(graphql.EnumConfig{
+					Name:        "FibEntryEventKind",
+					Description: "FIB entry update action.",
+					Values: graphql.EnumValueConfigMap{
+						"INSERT":  &graphql.EnumValueConfig{Value: fibdef.ActInsert},
+						"REPLACE": &graphql.EnumValueConfig{Value: fibdef.ActReplace},
+						"ERASE":   &graphql.EnumValueConfig{Value: fibdef.ActErase},
+					},
+				})),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlFibEvent).Action, nil
+				},
+			},
+			"name": &graphql.Field{
+				Description: "Entry name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlFibEvent).Name, nil
+				},
+			},
+			"entry": &graphql.Field{
+				Description: "Current FIB entry, if it still exists.",
+				Type:        GqlEntryType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if GqlFib == nil {
+						return nil, nil
+					}
+					if entry := GqlFib.Find(p.Source.(gqlFibEvent).Name); entry != nil {
+						return *entry, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "fibEvents",
+		Description: "FIB entry insertion, replacement, and erasure events.",
+		Type:        graphql.NewNonNull(GqlEntryEventType),
+	}, func(p graphql.ResolveParams) (<-chan interface{}, error) {
+		return gqlFibPublisher.Subscribe(p.Context), nil
+	})
 }
@@ -0,0 +1,326 @@
+package ndt
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// BalancerConfig contains NDT balancer configuration.
+type BalancerConfig struct {
+	// Enabled determines whether the balancer is started.
+	Enabled bool
+
+	// Interval is the sampling interval, default 1000ms.
+	Interval nnduration.Milliseconds
+
+	// Threshold is the hysteresis threshold, default 1.25.
+	// The balancer acts only if the busiest forwarding thread has a load score above Threshold times
+	// the average load score.
+	Threshold float64
+
+	// MinHits is the minimum sum of hit counter increments in an interval, default 64.
+	// Intervals with less traffic are skipped because the samples are not representative.
+	MinHits int
+
+	// MaxMoves is the maximum number of table elements moved in an interval, default 8.
+	MaxMoves int
+
+	// Transition is the duration of moving a table element, default 120000ms.
+	// During the transition, the element keeps its old value, and the old forwarding thread hands off
+	// Interests that do not match its existing PIT entries to the new forwarding thread.
+	// This should be no less than the maximum InterestLifetime, so that PIT entries in the old
+	// partition have expired or been satisfied when the element switches to its new value.
+	Transition nnduration.Milliseconds
+
+	// Hold is the duration after a move completes during which the element cannot be moved again,
+	// default 30000ms.
+	Hold nnduration.Milliseconds
+}
+
+func (cfg *BalancerConfig) applyDefaults() {
+	if cfg.Interval == 0 {
+		cfg.Interval = 1000
+	}
+	if cfg.Threshold <= 1 {
+		cfg.Threshold = 1.25
+	}
+	if cfg.MinHits <= 0 {
+		cfg.MinHits = 64
+	}
+	if cfg.MaxMoves <= 0 {
+		cfg.MaxMoves = 8
+	}
+	if cfg.Transition == 0 {
+		cfg.Transition = ndni.MaxInterestLifetime
+	}
+	if cfg.Hold == 0 {
+		cfg.Hold = 30000
+	}
+}
+
+// BalancerMove records a table element moved by the balancer.
+type BalancerMove struct {
+	Time  time.Time
+	Until time.Time // end of transition, when the element switches to the new value
+	Index int
+	From  uint8
+	To    uint8
+	Hits  int // hit counter increments of this element in the sampling interval
+}
+
+// MaxBalancerMoves is the number of recent moves retained by the balancer.
+const MaxBalancerMoves = 256
+
+// Balancer periodically moves hot table elements from busy forwarding threads to less busy ones.
+//
+// In each interval, the load score of a forwarding thread is its share of NDT hit counter increments
+// plus its input queue occupancy ratio.
+// When the busiest thread's score exceeds Threshold times the average, the balancer moves the elements
+// with the most hits from the busiest thread to the least busy thread, as long as each move does not
+// make the destination busier than the source.
+type Balancer struct {
+	ndt       *Ndt
+	cfg       BalancerConfig
+	nValues   int
+	occupancy func() []float64
+
+	mutex     sync.Mutex
+	prev      [][]uint16
+	transits  map[int]time.Time // elements in transition and their end time
+	heldUntil map[int]time.Time
+	moves     []BalancerMove
+	nMoves    int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBalancer creates a Balancer.
+// nValues is the number of forwarding threads, i.e. valid table element values are [0, nValues).
+// occupancy, if not nil, returns the input queue occupancy ratio (between 0 and 1) of each forwarding thread.
+// The balancer does not start until Launch is called.
+func NewBalancer(ndt *Ndt, nValues int, cfg BalancerConfig, occupancy func() []float64) (b *Balancer) {
+	cfg.applyDefaults()
+	b = &Balancer{
+		ndt:       ndt,
+		cfg:       cfg,
+		nValues:   nValues,
+		occupancy: occupancy,
+		transits:  map[int]time.Time{},
+		heldUntil: map[int]time.Time{},
+	}
+	b.prev = b.readThreadCounters()
+	return b
+}
+
+// Config returns the balancer configuration with defaults applied.
+func (b *Balancer) Config() BalancerConfig {
+	return b.cfg
+}
+
+// Launch starts the balancer goroutine.
+func (b *Balancer) Launch() {
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+	go b.loop(b.stop, b.done)
+}
+
+// Close stops the balancer goroutine.
+// Elements in transition are switched to their new values immediately.
+func (b *Balancer) Close() error {
+	if b.stop != nil {
+		close(b.stop)
+		<-b.done
+		b.stop = nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for index := range b.transits {
+		b.ndt.EndTransition(uint64(index))
+		delete(b.transits, index)
+	}
+	return nil
+}
+
+func (b *Balancer) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(b.cfg.Interval.Duration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			b.Rebalance(now)
+		}
+	}
+}
+
+// Moves returns recent moves, up to MaxBalancerMoves, oldest first.
+func (b *Balancer) Moves() []BalancerMove {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]BalancerMove{}, b.moves...)
+}
+
+// CountMoves returns the total number of moves since the balancer was created.
+func (b *Balancer) CountMoves() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.nMoves
+}
+
+func (b *Balancer) readThreadCounters() (cnt [][]uint16) {
+	nElements := b.ndt.CountElements()
+	for _, ndtt := range b.ndt.Threads() {
+		tc := make([]uint16, nElements)
+		for index := range tc {
+			tc[index] = ndtt.readCounter(uint64(index))
+		}
+		cnt = append(cnt, tc)
+	}
+	return cnt
+}
+
+// Rebalance completes elapsed transitions, samples the counters, and performs one round of balancing.
+// It is invoked periodically after Launch, but may also be invoked manually.
+func (b *Balancer) Rebalance(now time.Time) (moves []BalancerMove) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for index, until := range b.transits {
+		if !now.Before(until) {
+			b.ndt.EndTransition(uint64(index))
+			delete(b.transits, index)
+		}
+	}
+
+	// Per-thread counters are uint16 and wrap around, so that increments are computed per thread.
+	curr := b.readThreadCounters()
+	hits := make([]int, b.ndt.CountElements())
+	for i, tc := range curr {
+		for index, c := range tc {
+			hits[index] += int(c - b.prev[i][index])
+		}
+	}
+	b.prev = curr
+
+	var occupancy []float64
+	if b.occupancy != nil {
+		occupancy = b.occupancy()
+	}
+
+	// an element in transition is accounted at its new value
+	table := b.ndt.ReadTable()
+	for index := range b.transits {
+		table[index], _ = b.ndt.ReadTransition(uint64(index))
+	}
+
+	moves = b.plan(now, table, hits, occupancy)
+	for i, move := range moves {
+		move.Until = now.Add(b.cfg.Transition.Duration())
+		moves[i] = move
+		b.ndt.BeginTransition(uint64(move.Index), move.To)
+		b.transits[move.Index] = move.Until
+		b.heldUntil[move.Index] = move.Until.Add(b.cfg.Hold.Duration())
+		log.WithFields(makeLogFields("index", move.Index, "from", move.From, "to", move.To, "hits", move.Hits)).Info("NDT element moving")
+	}
+	b.nMoves += len(moves)
+	b.moves = append(b.moves, moves...)
+	if n := len(b.moves); n > MaxBalancerMoves {
+		b.moves = append([]BalancerMove{}, b.moves[n-MaxBalancerMoves:]...)
+	}
+	for index, until := range b.heldUntil {
+		if !now.Before(until) {
+			delete(b.heldUntil, index)
+		}
+	}
+	return moves
+}
+
+func (b *Balancer) plan(now time.Time, table []uint8, hits []int, occupancy []float64) (moves []BalancerMove) {
+	if b.nValues < 2 {
+		return nil
+	}
+
+	totalHits := 0
+	for index, h := range hits {
+		if int(table[index]) < b.nValues {
+			totalHits += h
+		}
+	}
+	if totalHits < b.cfg.MinHits {
+		return nil
+	}
+
+	score := make([]float64, b.nValues)
+	candidates := make([][]int, b.nValues)
+	for index, h := range hits {
+		value := int(table[index])
+		if value >= b.nValues {
+			continue
+		}
+		score[value] += float64(h) / float64(totalHits)
+		if until, ok := b.heldUntil[index]; h > 0 && (!ok || !now.Before(until)) {
+			candidates[value] = append(candidates[value], index)
+		}
+	}
+	average := 0.0
+	for value := range score {
+		if value < len(occupancy) {
+			score[value] += occupancy[value]
+		}
+		average += score[value]
+	}
+	average /= float64(b.nValues)
+	for _, list := range candidates {
+		sort.Slice(list, func(i, j int) bool { return hits[list[i]] > hits[list[j]] })
+	}
+
+	for len(moves) < b.cfg.MaxMoves {
+		src, dst := 0, 0
+		for value, s := range score {
+			if s > score[src] {
+				src = value
+			}
+			if s < score[dst] {
+				dst = value
+			}
+		}
+		if score[src] <= b.cfg.Threshold*average {
+			break
+		}
+
+		// choose the hottest element that does not overshoot, i.e. dst would not become busier than src
+		gap := score[src] - score[dst]
+		chosen := -1
+		for i, index := range candidates[src] {
+			if delta := float64(hits[index]) / float64(totalHits); 2*delta <= gap {
+				chosen = i
+				break
+			}
+		}
+		if chosen < 0 {
+			break
+		}
+
+		index := candidates[src][chosen]
+		candidates[src] = append(candidates[src][:chosen], candidates[src][chosen+1:]...)
+		delta := float64(hits[index]) / float64(totalHits)
+		score[src] -= delta
+		score[dst] += delta
+		moves = append(moves, BalancerMove{
+			Time:  now,
+			Index: index,
+			From:  uint8(src),
+			To:    uint8(dst),
+			Hits:  hits[index],
+		})
+	}
+	return moves
+}
@@ -0,0 +1,118 @@
+package ndt_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/ndt"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestBalancer(t *testing.T) {
+	assert, require := makeAR(t)
+
+	cfg := ndt.Config{
+		PrefixLen:  1,
+		IndexBits:  8,
+		SampleFreq: 0,
+	}
+	table := ndt.New(cfg, make([]eal.NumaSocket, 1))
+	defer table.Close()
+	for i := 0; i < table.CountElements(); i++ {
+		table.Update(uint64(i), 0)
+	}
+
+	var names []ndn.Name
+	for i := 0; i < 64; i++ {
+		names = append(names, ndn.ParseName(fmt.Sprintf("/%d", i)))
+	}
+	ndtt := table.Threads()[0]
+	lookupAll := func() {
+		for _, name := range names {
+			for j := 0; j < 10; j++ {
+				ndtt.Lookup(name)
+			}
+		}
+	}
+
+	var occupancy []float64
+	b := ndt.NewBalancer(table, 2, ndt.BalancerConfig{
+		Threshold:  1.25,
+		MinHits:    100,
+		MaxMoves:   100,
+		Transition: 5000,
+		Hold:       10000,
+	}, func() []float64 { return occupancy })
+	defer b.Close()
+
+	now := time.Now()
+	lookupAll()
+	moves := b.Rebalance(now)
+	require.NotEmpty(moves)
+	for _, move := range moves {
+		assert.EqualValues(0, move.From)
+		assert.EqualValues(1, move.To)
+		assert.Equal(now.Add(5*time.Second), move.Until)
+		assert.Greater(move.Hits, 0)
+
+		// element keeps old value during transition
+		assert.EqualValues(0, table.Read(uint64(move.Index)))
+		next, ok := table.ReadTransition(uint64(move.Index))
+		assert.True(ok)
+		assert.EqualValues(1, next)
+	}
+	assert.Equal(len(moves), b.CountMoves())
+	assert.Len(b.Moves(), len(moves))
+
+	// busiest thread is within threshold after rebalancing, counting elements at their new values
+	hits := [2]int{}
+	for _, name := range names {
+		index, value := table.Lookup(name)
+		if next, ok := table.ReadTransition(index); ok {
+			value = next
+		}
+		hits[value]++
+	}
+	assert.LessOrEqual(float64(hits[0])/float64(len(names)), 1.25*0.5+0.05)
+	assert.Greater(hits[1], 0)
+
+	// no traffic, below MinHits; transition is still ongoing
+	assert.Empty(b.Rebalance(now.Add(time.Second)))
+	for _, move := range moves {
+		_, ok := table.ReadTransition(uint64(move.Index))
+		assert.True(ok)
+	}
+
+	// transition completes, elements switch to new values
+	assert.Empty(b.Rebalance(now.Add(6 * time.Second)))
+	for _, move := range moves {
+		assert.EqualValues(1, table.Read(uint64(move.Index)))
+		_, ok := table.ReadTransition(uint64(move.Index))
+		assert.False(ok)
+	}
+
+	// occupancy on thread 1 makes it busier, but moved elements are held
+	occupancy = []float64{0, 1}
+	lookupAll()
+	moves = b.Rebalance(now.Add(7 * time.Second))
+	assert.Empty(moves)
+
+	// after hold expires, elements can move back
+	lookupAll()
+	moves = b.Rebalance(now.Add(20 * time.Second))
+	require.NotEmpty(moves)
+	for _, move := range moves {
+		assert.EqualValues(1, move.From)
+		assert.EqualValues(0, move.To)
+	}
+
+	// closing the balancer completes ongoing transitions
+	b.Close()
+	for _, move := range moves {
+		assert.EqualValues(0, table.Read(uint64(move.Index)))
+		_, ok := table.ReadTransition(uint64(move.Index))
+		assert.False(ok)
+	}
+}
@@ -0,0 +1,244 @@
+package ndt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GqlNdt is the NDT instance accessible via GraphQL.
+var GqlNdt *Ndt
+
+// GqlBalancer is the NDT balancer accessible via GraphQL.
+var GqlBalancer *Balancer
+
+var (
+	errNoGqlNdt       = errors.New("NDT unavailable")
+	errGqlNdtIndex    = errors.New("NDT index out of range")
+	errGqlNdtNoTarget = errors.New("either index or name must be specified")
+	errGqlNdtValue    = errors.New("NDT value out of range")
+)
+
+// Entry contains an NDT element and its hit counter.
+type Entry struct {
+	Index int
+	Value uint8
+	Hits  int
+}
+
+// ReadEntries reads all table elements and their hit counters.
+func (ndt *Ndt) ReadEntries() (list []Entry) {
+	table, counters := ndt.ReadTable(), ndt.ReadCounters()
+	list = make([]Entry, len(table))
+	for i, value := range table {
+		list[i] = Entry{Index: i, Value: value, Hits: counters[i]}
+	}
+	return list
+}
+
+func (ndt *Ndt) readEntry(index uint64) (entry Entry) {
+	entry.Index = int(index)
+	entry.Value = ndt.Read(index)
+	for _, ndtt := range ndt.Threads() {
+		entry.Hits += int(ndtt.readCounter(index))
+	}
+	return entry
+}
+
+// GraphQL types.
+var (
+	GqlEntryNodeType    *gqlserver.NodeType
+	GqlEntryType        *graphql.Object
+	GqlBalancerMoveType *graphql.Object
+	GqlBalancerType     *graphql.Object
+)
+
+func init() {
+	GqlEntryNodeType = gqlserver.NewNodeTypeNamed("NdtEntry", Entry{})
+	GqlEntryNodeType.Retrieve = func(id string) (interface{}, error) {
+		if GqlNdt == nil {
+			return nil, errNoGqlNdt
+		}
+		index, e := strconv.Atoi(id)
+		if e != nil || index < 0 || index >= GqlNdt.CountElements() {
+			return nil, nil
+		}
+		return GqlNdt.readEntry(uint64(index)), nil
+	}
+
+	GqlEntryType = graphql.NewObject(GqlEntryNodeType.Annotate(graphql.ObjectConfig{
+		Name: "NdtEntry",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Description: "Table index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Entry).Index, nil
+				},
+			},
+			"value": &graphql.Field{
+				Description: "Forwarding thread index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(Entry).Value), nil
+				},
+			},
+			"hits": &graphql.Field{
+				Description: "Hit counter, summed across lookup threads. Each per-thread counter wraps at 65536.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Entry).Hits, nil
+				},
+			},
+		},
+	}))
+	GqlEntryNodeType.Register(GqlEntryType)
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "ndt",
+		Description: "NDT elements and hit counters.",
+		Type:        graphql.NewList(graphql.NewNonNull(GqlEntryType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlNdt == nil {
+				return nil, errNoGqlNdt
+			}
+			return GqlNdt.ReadEntries(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "updateNdt",
+		Description: "Update an NDT element. This does not relocate FIB entries in a partitioned FIB.",
+		Args: graphql.FieldConfigArgument{
+			"index": &graphql.ArgumentConfig{
+				Description: "Table index.",
+				Type:        graphql.Int,
+			},
+			"name": &graphql.ArgumentConfig{
+				Description: "Name whose hash determines the table index. This overrides index.",
+				Type:        ndni.GqlNameType,
+			},
+			"value": &graphql.ArgumentConfig{
+				Description: "Forwarding thread index.",
+				Type:        gqlserver.NonNullInt,
+			},
+		},
+		Type: graphql.NewNonNull(GqlEntryType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlNdt == nil {
+				return nil, errNoGqlNdt
+			}
+
+			var index uint64
+			if name, ok := p.Args["name"].(ndn.Name); ok {
+				index = GqlNdt.IndexOfName(name)
+			} else if i, ok := p.Args["index"].(int); ok {
+				if i < 0 || i >= GqlNdt.CountElements() {
+					return nil, errGqlNdtIndex
+				}
+				index = uint64(i)
+			} else {
+				return nil, errGqlNdtNoTarget
+			}
+
+			value := p.Args["value"].(int)
+			if value < 0 || value > 255 {
+				return nil, errGqlNdtValue
+			}
+			GqlNdt.Update(index, uint8(value))
+			return GqlNdt.readEntry(uint64(index)), nil
+		},
+	})
+
+	GqlBalancerMoveType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "NdtBalancerMove",
+		Fields: graphql.Fields{
+			"time": &graphql.Field{
+				Description: "When the element started moving.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(BalancerMove).Time, nil
+				},
+			},
+			"until": &graphql.Field{
+				Description: "When the transition ends and the element switches to the new forwarding thread.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(BalancerMove).Until, nil
+				},
+			},
+			"index": &graphql.Field{
+				Description: "Table index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(BalancerMove).Index, nil
+				},
+			},
+			"from": &graphql.Field{
+				Description: "Old forwarding thread index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(BalancerMove).From), nil
+				},
+			},
+			"to": &graphql.Field{
+				Description: "New forwarding thread index.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(BalancerMove).To), nil
+				},
+			},
+			"hits": &graphql.Field{
+				Description: "Hit counter increments of this element in the sampling interval before the move.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(BalancerMove).Hits, nil
+				},
+			},
+		},
+	})
+
+	GqlBalancerType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "NdtBalancer",
+		Fields: graphql.Fields{
+			"config": &graphql.Field{
+				Description: "Balancer configuration.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Balancer).Config(), nil
+				},
+			},
+			"nMoves": &graphql.Field{
+				Description: "Total number of moved table elements.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Balancer).CountMoves(), nil
+				},
+			},
+			"moves": &graphql.Field{
+				Description: fmt.Sprintf("Recent moves, up to %d, oldest first.", MaxBalancerMoves),
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlBalancerMoveType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Balancer).Moves(), nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "ndtBalancer",
+		Description: "NDT balancer status, null if the balancer is disabled.",
+		Type:        GqlBalancerType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlBalancer == nil {
+				return nil, nil
+			}
+			return GqlBalancer, nil
+		},
+	})
+}
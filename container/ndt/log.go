@@ -0,0 +1,10 @@
+package ndt
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/logger"
+)
+
+var (
+	log           = logger.New("Ndt")
+	makeLogFields = logger.MakeFields
+)
@@ -37,6 +37,8 @@ func New(cfg Config, sockets []eal.NumaSocket) *Ndt {
 	c.nThreads = C.uint8_t(len(sockets))
 
 	c.table = (*C.uint8_t)(eal.Zmalloc("NdtTable", tableSize, sockets[0]))
+	c.transit = (*C.uint8_t)(eal.Zmalloc("NdtTransit", tableSize, sockets[0]))
+	C.memset(unsafe.Pointer(c.transit), C.NdtNoTransit, C.size_t(tableSize))
 	for i, socket := range sockets {
 		c.threads[i] = (*C.NdtThread)(eal.Zmalloc("NdtThread", threadSize, socket))
 	}
@@ -58,6 +60,7 @@ func (ndt *Ndt) Close() error {
 	for i, end := 0, int(c.nThreads); i < end; i++ {
 		eal.Free(c.threads[i])
 	}
+	eal.Free(c.transit)
 	eal.Free(c.table)
 	eal.Free(c)
 	return nil
@@ -135,6 +138,25 @@ func (ndt *Ndt) Update(index uint64, value uint8) {
 	C.Ndt_Update(ndt.ptr(), C.uint64_t(index), C.uint8_t(value))
 }
 
+// BeginTransition starts moving an element to a new value.
+// Until EndTransition, the element keeps its old value, and the forwarding thread of the old value
+// hands off Interests that do not match its existing PIT entries to the new value.
+func (ndt *Ndt) BeginTransition(index uint64, value uint8) {
+	C.Ndt_BeginTransit(ndt.ptr(), C.uint64_t(index), C.uint8_t(value))
+}
+
+// EndTransition completes moving an element, updating it to the new value.
+// This has no effect if the element is not in transition.
+func (ndt *Ndt) EndTransition(index uint64) {
+	C.Ndt_EndTransit(ndt.ptr(), C.uint64_t(index))
+}
+
+// ReadTransition returns the new value of an element in transition.
+func (ndt *Ndt) ReadTransition(index uint64) (value uint8, ok bool) {
+	value = uint8(C.Ndt_ReadTransit(ndt.ptr(), C.uint64_t(index)))
+	return value, value != C.NdtNoTransit
+}
+
 // Randomize updates all elements to random values < max.
 // This should be used during initialization only.
 func (ndt *Ndt) Randomize(max int) {
@@ -11,6 +11,7 @@ import (
 	"unsafe"
 
 	"github.com/pkg/math"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/mempool"
 )
@@ -20,8 +21,18 @@ type Config struct {
 	MaxEntries int
 	CsCapMd    int
 	CsCapMi    int
+
+	// DeadNonceCapacity is the capacity of the Dead Nonce List.
+	// Zero disables the Dead Nonce List.
+	DeadNonceCapacity int
+
+	// DeadNonceLifetime is the lifetime of a Dead Nonce List record.
+	// Default is 6 seconds.
+	DeadNonceLifetime nnduration.Milliseconds
 }
 
+const defaultDeadNonceLifetime nnduration.Milliseconds = 6000
+
 // Pcct represents a PIT-CS Composite Table (PCCT).
 type Pcct C.Pcct
 
@@ -53,6 +64,17 @@ func New(cfg Config, socket eal.NumaSocket) (pcct *Pcct, e error) {
 	}
 
 	C.Pit_Init(&pcctC.pit)
+	if cfg.DeadNonceCapacity > 0 {
+		dnlID := C.CString(eal.AllocObjectID("pcct.dnl"))
+		defer C.free(unsafe.Pointer(dnlID))
+		lifetime := eal.ToTscDuration(cfg.DeadNonceLifetime.DurationOr(defaultDeadNonceLifetime))
+		pcctC.pit.dnl = C.DeadNonceList_New(dnlID, C.uint32_t(cfg.DeadNonceCapacity), C.TscDuration(lifetime), C.int(socket.ID()))
+		if pcctC.pit.dnl == nil {
+			C.Pcct_Clear(pcctC)
+			mp.Close()
+			return nil, fmt.Errorf("DeadNonceList_New error %w", eal.GetErrno())
+		}
+	}
 	C.Cs_Init(&pcctC.cs, C.uint32_t(cfg.CsCapMd), C.uint32_t(cfg.CsCapMi))
 	return (*Pcct)(pcctC), nil
 }
@@ -0,0 +1,121 @@
+package pcct
+
+/*
+#include "../../csrc/pcct/walk.h"
+*/
+import "C"
+import (
+	"errors"
+	"math"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// WalkKind indicates which entries are collected by a Walk.
+type WalkKind int
+
+// WalkKind values.
+const (
+	WalkPit WalkKind = C.PcctWalkPit
+	WalkCs  WalkKind = C.PcctWalkCs
+
+	// WalkCsErase erases CS entries instead of collecting them.
+	WalkCsErase WalkKind = C.PcctWalkCsErase
+)
+
+// MaxWalkLimit is the maximum number of records collected by a Walk.
+const MaxWalkLimit = 256
+
+// ErrWalkRange indicates the Walk offset or limit is out of range.
+var ErrWalkRange = errors.New("Walk offset or limit out of range")
+
+// Walk collects a page of PIT or CS entries under a name prefix.
+//
+// The walk must be performed by the thread that owns the PCCT.
+// For a PCCT owned by a forwarding thread, the Walk should be passed to that thread, which performs
+// it incrementally without blocking packet processing.
+type Walk C.PcctWalk
+
+// NewWalk creates a Walk request.
+// It collects up to limit entries whose name starts with prefix, after skipping offset matching entries.
+// For WalkCsErase, it erases all CS entries whose name starts with prefix, and ignores offset and limit.
+func NewWalk(kind WalkKind, prefix ndn.Name, offset, limit int, socket eal.NumaSocket) (*Walk, error) {
+	nRecords := limit
+	if kind == WalkCsErase {
+		offset, limit, nRecords = 0, math.MaxUint32, 1
+	} else if offset < 0 || limit <= 0 || limit > MaxWalkLimit {
+		return nil, ErrWalkRange
+	}
+	prefixV, e := prefix.MarshalBinary()
+	if e != nil {
+		return nil, e
+	}
+
+	c := (*C.PcctWalk)(eal.Zmalloc("PcctWalk", C.sizeof_PcctWalk+C.size_t(nRecords)*C.sizeof_PcctWalkRecord, socket))
+	c.kind = C.PcctWalkKind(kind)
+	c.skip = C.uint32_t(offset)
+	c.limit = C.uint32_t(limit)
+	if len(prefixV) > 0 {
+		C.rte_memcpy(unsafe.Pointer(&c.prefixV[0]), unsafe.Pointer(&prefixV[0]), C.size_t(len(prefixV)))
+		c.prefixL = C.uint16_t(len(prefixV))
+	}
+	return (*Walk)(c), nil
+}
+
+// Ptr returns *C.PcctWalk pointer.
+func (w *Walk) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(w)
+}
+
+func (w *Walk) ptr() *C.PcctWalk {
+	return (*C.PcctWalk)(w)
+}
+
+// Close releases the Walk.
+func (w *Walk) Close() error {
+	eal.Free(w.ptr())
+	return nil
+}
+
+// Run performs the walk on the calling thread.
+// Calling thread must own the PCCT.
+func (w *Walk) Run(pcct *Pcct) {
+	for !bool(C.PcctWalk_Run(pcct.ptr(), w.ptr(), math.MaxUint32)) {
+	}
+}
+
+// Now returns the timestamp when the walk started.
+func (w *Walk) Now() eal.TscTime {
+	return eal.TscTime(w.ptr().now)
+}
+
+// NErased returns number of erased CS entries.
+func (w *Walk) NErased() int {
+	return int(w.ptr().nErased)
+}
+
+// Len returns number of collected records.
+func (w *Walk) Len() int {
+	return int(w.ptr().nRecords)
+}
+
+func (w *Walk) record(i int) *C.PcctWalkRecord {
+	if i < 0 || i >= w.Len() {
+		panic("record index out of range")
+	}
+	return C.PcctWalk_GetRecord(w.ptr(), C.uint32_t(i))
+}
+
+// Record returns i-th collected record.
+// name and fwHint are the PCC key.
+// ptr points to *C.PcctWalkPitRecord or *C.PcctWalkCsRecord, depending on WalkKind.
+func (w *Walk) Record(i int) (name, fwHint ndn.Name, ptr unsafe.Pointer) {
+	rec := w.record(i)
+	name.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&rec.nameV[0]), C.int(rec.nameL)))
+	if rec.fhL > 0 {
+		fwHint.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&rec.fhV[0]), C.int(rec.fhL)))
+	}
+	return name, fwHint, unsafe.Pointer(rec)
+}
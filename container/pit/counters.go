@@ -14,13 +14,20 @@ type Counters struct {
 	NNackHit  uint64 // how many find-by-Nack found PIT entry
 	NNackMiss uint64 // how many find-by-Nack did not found PIT entry
 	NExpired  uint64 // how many entries expired
+
+	NDeadNonce       uint64 // current number of Dead Nonce List records
+	NDeadNonceInsert uint64 // how many records were inserted into Dead Nonce List
+	NDeadNonceEvict  uint64 // how many Dead Nonce List records were evicted before expiry
+	NDeadNonceHit    uint64 // how many Interests matched Dead Nonce List
 }
 
 func (cnt Counters) String() string {
 	return fmt.Sprintf("%d entries, %d inserts, %d found, %d cs-match, %d alloc-err, "+
-		"%d data-hit, %d data-miss, %d nack-hit, %d nack-miss, %d expired",
+		"%d data-hit, %d data-miss, %d nack-hit, %d nack-miss, %d expired, "+
+		"%d dead-nonces, %d dead-nonce-insert, %d dead-nonce-evict, %d dead-nonce-hit",
 		cnt.NEntries, cnt.NInsert, cnt.NFound, cnt.NCsMatch, cnt.NAllocErr,
-		cnt.NDataHit, cnt.NDataMiss, cnt.NNackHit, cnt.NNackMiss, cnt.NExpired)
+		cnt.NDataHit, cnt.NDataMiss, cnt.NNackHit, cnt.NNackMiss, cnt.NExpired,
+		cnt.NDeadNonce, cnt.NDeadNonceInsert, cnt.NDeadNonceEvict, cnt.NDeadNonceHit)
 }
 
 // ReadCounters reads counters from this PIT.
@@ -35,5 +42,11 @@ func (pit *Pit) ReadCounters() (cnt Counters) {
 	cnt.NNackHit = uint64(pit.nNackHit)
 	cnt.NNackMiss = uint64(pit.nNackMiss)
 	cnt.NExpired = uint64(pit.timeoutSched.nTriggered)
+	if dnl := pit.dnl; dnl != nil {
+		cnt.NDeadNonce = uint64(dnl.count)
+		cnt.NDeadNonceInsert = uint64(dnl.nInsert)
+		cnt.NDeadNonceEvict = uint64(dnl.nEvict)
+		cnt.NDeadNonceHit = uint64(dnl.nHit)
+	}
 	return cnt
 }
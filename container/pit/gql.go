@@ -0,0 +1,217 @@
+package pit
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GraphQL types.
+var (
+	GqlDnType    *graphql.Object
+	GqlUpType    *graphql.Object
+	GqlEntryType *graphql.Object
+)
+
+func init() {
+	GqlDnType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PitDn",
+		Fields: graphql.Fields{
+			"face": &graphql.Field{
+				Description: "Downstream face. null indicates a deleted face.",
+				Type:        iface.GqlFaceType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return iface.Get(dn.Face), nil
+				},
+			},
+			"nonce": &graphql.Field{
+				Description: "Last received Nonce.",
+				Type:        graphql.NewNonNull(gqlserver.Bytes),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return dn.Nonce[:], nil
+				},
+			},
+			"pitToken": &graphql.Field{
+				Description: "Last received PIT token, in hexadecimal.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return formatToken(dn.PitToken), nil
+				},
+			},
+			"expiry": &graphql.Field{
+				Description: "When this record expires.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return dn.Expiry.ToTime(), nil
+				},
+			},
+			"canBePrefix": &graphql.Field{
+				Description: "Whether the Interest has CanBePrefix.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return dn.CanBePrefix, nil
+				},
+			},
+			"congMark": &graphql.Field{
+				Description: "Whether the Interest carries a congestion mark.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dn := p.Source.(DnInfo)
+					return dn.CongMark, nil
+				},
+			},
+		},
+	})
+
+	GqlUpType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PitUp",
+		Fields: graphql.Fields{
+			"face": &graphql.Field{
+				Description: "Upstream face. null indicates a deleted face.",
+				Type:        iface.GqlFaceType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return iface.Get(up.Face), nil
+				},
+			},
+			"nonce": &graphql.Field{
+				Description: "Nonce on last sent Interest.",
+				Type:        graphql.NewNonNull(gqlserver.Bytes),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return up.Nonce[:], nil
+				},
+			},
+			"nack": &graphql.Field{
+				Description: "Nack reason against last sent Interest, zero if none.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return int(up.Nack), nil
+				},
+			},
+			"lastTx": &graphql.Field{
+				Description: "When last Interest was sent.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return up.LastTx.ToTime(), nil
+				},
+			},
+			"nTx": &graphql.Field{
+				Description: "How many Interests were sent.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return up.NTx, nil
+				},
+			},
+			"suppressUntil": &graphql.Field{
+				Description: "When Interest suppression toward this upstream ends.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					up := p.Source.(UpInfo)
+					return up.SuppressUntil().ToTime(), nil
+				},
+			},
+		},
+	})
+
+	GqlEntryType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PitEntry",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Interest name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.Name, nil
+				},
+			},
+			"fwHint": &graphql.Field{
+				Description: "Chosen forwarding hint delegation name.",
+				Type:        ndni.GqlNameType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return gqlserver.Optional(entry.FwHint, len(entry.FwHint) > 0), nil
+				},
+			},
+			"mustBeFresh": &graphql.Field{
+				Description: "Whether the entry is for MustBeFresh Interests.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.MustBeFresh, nil
+				},
+			},
+			"pitToken": &graphql.Field{
+				Description: "PIT token assigned to this entry, in hexadecimal.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return formatToken(entry.PitToken), nil
+				},
+			},
+			"expiry": &graphql.Field{
+				Description: "When all downstream records expire.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.Expiry.ToTime(), nil
+				},
+			},
+			"hasStrategyTimer": &graphql.Field{
+				Description: "Whether the entry timer is set by the strategy.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.HasSgTimer, nil
+				},
+			},
+			"nDownstreams": &graphql.Field{
+				Description: "Number of downstream records, which may exceed the length of downstreams list.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.NDns, nil
+				},
+			},
+			"downstreams": &graphql.Field{
+				Description: "Downstream records.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlDnType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.Dns, nil
+				},
+			},
+			"nUpstreams": &graphql.Field{
+				Description: "Number of upstream records, which may exceed the length of upstreams list.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.NUps, nil
+				},
+			},
+			"upstreams": &graphql.Field{
+				Description: "Upstream records.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlUpType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(EntryInfo)
+					return entry.Ups, nil
+				},
+			},
+		},
+	})
+}
+
+func formatToken(token uint64) string {
+	return fmt.Sprintf("%016x", token)
+}
@@ -0,0 +1,101 @@
+package pit
+
+/*
+#include "../../csrc/pcct/walk.h"
+*/
+import "C"
+import (
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// DnInfo is a snapshot of a PIT downstream record.
+type DnInfo struct {
+	Face        iface.ID
+	Nonce       ndn.Nonce
+	PitToken    uint64
+	Expiry      eal.TscTime
+	CanBePrefix bool
+	CongMark    bool
+}
+
+// UpInfo is a snapshot of a PIT upstream record.
+type UpInfo struct {
+	Face        iface.ID
+	Nonce       ndn.Nonce
+	CanBePrefix bool
+	Nack        uint8 // Nack reason against last Interest, zero if none
+	LastTx      eal.TscTime
+	Suppress    time.Duration // suppression duration since LastTx
+	NTx         int
+}
+
+// SuppressUntil returns the timestamp when Interest suppression toward this upstream ends.
+func (up UpInfo) SuppressUntil() eal.TscTime {
+	return up.LastTx.Add(up.Suppress)
+}
+
+// EntryInfo is a snapshot of a PIT entry.
+type EntryInfo struct {
+	Name        ndn.Name
+	FwHint      ndn.Name
+	MustBeFresh bool
+	PitToken    uint64
+	Expiry      eal.TscTime
+	HasSgTimer  bool
+	Now         eal.TscTime // when this snapshot was taken
+	Dns         []DnInfo
+	NDns        int // number of downstream records, may exceed len(Dns)
+	Ups         []UpInfo
+	NUps        int // number of upstream records, may exceed len(Ups)
+}
+
+// ListEntries extracts PIT entry snapshots from a completed Walk of pcct.WalkPit kind.
+func ListEntries(w *pcct.Walk) (list []EntryInfo) {
+	list = make([]EntryInfo, w.Len())
+	for i := range list {
+		info := &list[i]
+		var ptr unsafe.Pointer
+		info.Name, info.FwHint, ptr = w.Record(i)
+		rec := (*C.PcctWalkPitRecord)(ptr)
+
+		info.MustBeFresh = bool(rec.mustBeFresh)
+		info.PitToken = uint64(rec.token)
+		info.Expiry = eal.TscTime(rec.expiry)
+		info.HasSgTimer = bool(rec.hasSgTimer)
+		info.Now = w.Now()
+
+		info.NDns = int(rec.nDns)
+		for j, n := 0, info.NDns; j < n && j < C.PcctWalkMaxDns; j++ {
+			dn := rec.dns[j]
+			info.Dns = append(info.Dns, DnInfo{
+				Face:        iface.ID(dn.face),
+				Nonce:       ndn.NonceFromUint(uint32(dn.nonce)),
+				PitToken:    uint64(dn.token),
+				Expiry:      eal.TscTime(dn.expiry),
+				CanBePrefix: bool(dn.canBePrefix),
+				CongMark:    bool(dn.congMark),
+			})
+		}
+
+		info.NUps = int(rec.nUps)
+		for j, n := 0, info.NUps; j < n && j < C.PcctWalkMaxUps; j++ {
+			up := rec.ups[j]
+			info.Ups = append(info.Ups, UpInfo{
+				Face:        iface.ID(up.face),
+				Nonce:       ndn.NonceFromUint(uint32(up.nonce)),
+				CanBePrefix: bool(up.canBePrefix),
+				Nack:        uint8(up.nack),
+				LastTx:      eal.TscTime(up.lastTx),
+				Suppress:    eal.FromTscDuration(int64(up.suppress)),
+				NTx:         int(up.nTx),
+			})
+		}
+	}
+	return list
+}
@@ -36,6 +36,11 @@ func (pit *Pit) TriggerTimeoutSched() {
 	C.MinSched_Trigger(pit.ptr().timeoutSched)
 }
 
+// HasDeadNonce determines whether an Interest carries a nonce in the Dead Nonce List.
+func (pit *Pit) HasDeadNonce(interest *ndni.Packet) bool {
+	return bool(C.Pit_HasDeadNonce(pit.ptr(), (*C.Packet)(interest.Ptr())))
+}
+
 // Insert attempts to insert a PIT entry for the given Interest.
 // It returns either a new or existing PIT entry, or a CS entry that satisfies the Interest.
 func (pit *Pit) Insert(interest *ndni.Packet, fibEntry *fibreplica.Entry) (pitEntry *Entry, csEntry *cs.Entry) {
@@ -54,6 +59,11 @@ func (pit *Pit) Erase(entry *Entry) {
 	C.Pit_Erase(pit.ptr(), entry.ptr())
 }
 
+// Expire erases a PIT entry as if it expired unsatisfied, inserting its nonces into Dead Nonce List.
+func (pit *Pit) Expire(entry *Entry) {
+	C.Pit_Expire(pit.ptr(), entry.ptr())
+}
+
 // FindByData searches for PIT entries matching a Data.
 func (pit *Pit) FindByData(data *ndni.Packet) FindResult {
 	resC := C.Pit_FindByData(pit.ptr(), (*C.Packet)(data.Ptr()))
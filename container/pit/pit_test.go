@@ -3,8 +3,11 @@ package pit_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
 	"github.com/usnistgov/ndn-dpdk/container/pit"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
 	"github.com/usnistgov/ndn-dpdk/ndni"
@@ -65,6 +68,96 @@ func TestInsertErase(t *testing.T) {
 	assert.Zero(fixture.CountMpInUse())
 }
 
+func TestDeadNonce(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(255, func(cfg *pcct.Config) {
+		cfg.DeadNonceCapacity = 64
+		cfg.DeadNonceLifetime = 200
+	})
+	defer fixture.Close()
+	pit := fixture.Pit
+
+	interest1 := makeInterest("/A/1", ndn.NonceFromUint(0x34c4a0d1), setFace(1001))
+	entry1 := fixture.Insert(interest1)
+	assert.NotNil(entry1.InsertDnRecord(interest1))
+	assert.False(pit.HasDeadNonce(interest1))
+	pit.Expire(entry1)
+
+	interest2 := makeInterest("/A/1", ndn.NonceFromUint(0x34c4a0d1))
+	defer interest2.Close()
+	interest3 := makeInterest("/A/1", ndn.NonceFromUint(0x81b5e0a3))
+	defer interest3.Close()
+	interest4 := makeInterest("/A/2", ndn.NonceFromUint(0x34c4a0d1))
+	defer interest4.Close()
+	assert.True(pit.HasDeadNonce(interest2))
+	assert.False(pit.HasDeadNonce(interest3))
+	assert.False(pit.HasDeadNonce(interest4))
+
+	cnt := pit.ReadCounters()
+	assert.Equal(uint64(1), cnt.NDeadNonce)
+	assert.Equal(uint64(1), cnt.NDeadNonceInsert)
+	assert.Equal(uint64(1), cnt.NDeadNonceHit)
+
+	// satisfied or otherwise erased PIT entry does not insert into Dead Nonce List
+	interest5 := makeInterest("/A/5", ndn.NonceFromUint(0x5d21f6b7), setFace(1001))
+	entry5 := fixture.Insert(interest5)
+	assert.NotNil(entry5.InsertDnRecord(interest5))
+	pit.Erase(entry5)
+	interest6 := makeInterest("/A/5", ndn.NonceFromUint(0x5d21f6b7))
+	defer interest6.Close()
+	assert.False(pit.HasDeadNonce(interest6))
+	assert.Equal(uint64(1), pit.ReadCounters().NDeadNonceInsert)
+
+	time.Sleep(300 * time.Millisecond)
+	assert.False(pit.HasDeadNonce(interest2))
+	assert.Equal(uint64(0), pit.ReadCounters().NDeadNonce)
+}
+
+func TestWalk(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(255)
+	defer fixture.Close()
+
+	interestA1 := makeInterest("/A/1", ndn.NonceFromUint(0xcb1d4a64), setFace(1001))
+	entryA1 := fixture.Insert(interestA1)
+	assert.NotNil(entryA1.InsertDnRecord(interestA1))
+	fixture.Insert(makeInterest("/B/1"))
+	fixture.Insert(makeInterest("/A/2"))
+	fixture.Insert(makeInterest("/A/2", ndn.MustBeFreshFlag))
+
+	walk := func(prefix string, offset, limit int) []pit.EntryInfo {
+		w, e := pcct.NewWalk(pcct.WalkPit, ndn.ParseName(prefix), offset, limit, eal.NumaSocket{})
+		require.NoError(e)
+		defer w.Close()
+		w.Run(fixture.Pcct)
+		return pit.ListEntries(w)
+	}
+
+	list := walk("/A", 0, 10)
+	require.Len(list, 3)
+	nameEqual(assert, "/A/1", list[0].Name)
+	assert.False(list[0].MustBeFresh)
+	assert.Equal(entryA1.PitToken(), list[0].PitToken)
+	assert.Equal(1, list[0].NDns)
+	if assert.Len(list[0].Dns, 1) {
+		assert.EqualValues(1001, list[0].Dns[0].Face)
+		assert.Equal(ndn.NonceFromUint(0xcb1d4a64), list[0].Dns[0].Nonce)
+	}
+	nameEqual(assert, "/A/2", list[1].Name)
+	nameEqual(assert, "/A/2", list[2].Name)
+	assert.NotEqual(list[1].MustBeFresh, list[2].MustBeFresh)
+
+	list = walk("/A", 1, 1)
+	require.Len(list, 1)
+	nameEqual(assert, "/A/2", list[0].Name)
+
+	assert.Len(walk("/", 0, 10), 4)
+	assert.Len(walk("/C", 0, 10), 0)
+
+	_, e := pcct.NewWalk(pcct.WalkPit, ndn.ParseName("/"), 0, pcct.MaxWalkLimit+1, eal.NumaSocket{})
+	assert.Error(e)
+}
+
 func TestToken(t *testing.T) {
 	assert, require := makeAR(t)
 	interestNames := make([]string, 255)
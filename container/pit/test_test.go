@@ -48,10 +48,14 @@ type Fixture struct {
 	FibEntry   *fibreplica.Entry
 }
 
-func NewFixture(pcctMaxEntries int) *Fixture {
+func NewFixture(pcctMaxEntries int, modifyConfig ...func(cfg *pcct.Config)) *Fixture {
 	fixture := new(Fixture)
+	cfg := pcct.Config{MaxEntries: pcctMaxEntries}
+	for _, f := range modifyConfig {
+		f(&cfg)
+	}
 	var e error
-	fixture.Pcct, e = pcct.New(pcct.Config{MaxEntries: pcctMaxEntries}, eal.NumaSocket{})
+	fixture.Pcct, e = pcct.New(cfg, eal.NumaSocket{})
 	if e != nil {
 		panic(e)
 	}
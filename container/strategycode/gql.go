@@ -1,6 +1,7 @@
 package strategycode
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/graphql-go/graphql"
@@ -9,10 +10,24 @@ import (
 
 // GraghQL types.
 var (
-	GqlStrategyNodeType *gqlserver.NodeType
-	GqlStrategyType     *graphql.Object
+	GqlStrategyNodeType  *gqlserver.NodeType
+	GqlStrategyType      *graphql.Object
+	GqlStrategyEventType *graphql.Object
 )
 
+const (
+	evtLoad   = "LOAD"
+	evtUnload = "UNLOAD"
+)
+
+type gqlStrategyEvent struct {
+	Event string
+	ID    int
+	Name  string
+}
+
+var gqlStrategyPublisher = gqlserver.NewPublisher()
+
 func init() {
 	GqlStrategyNodeType = gqlserver.NewNodeType((*Strategy)(nil))
 	GqlStrategyNodeType.Retrieve = func(id string) (interface{}, error) {
@@ -79,4 +94,92 @@ func init() {
 			return Load(name, elf)
 		},
 	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "replaceStrategy",
+		Description: "Switch all FIB entries from an old strategy to a new strategy, and unload the old strategy.",
+		Args: graphql.FieldConfigArgument{
+			"old": &graphql.ArgumentConfig{
+				Description: "Old strategy.",
+				Type:        gqlserver.NonNullID,
+			},
+			"new": &graphql.ArgumentConfig{
+				Description: "New strategy.",
+				Type:        gqlserver.NonNullID,
+			},
+			"resetScratch": &graphql.ArgumentConfig{
+				Description:  "Whether to clear FIB and PIT entry scratch areas.",
+				Type:         graphql.Boolean,
+				DefaultValue: true,
+			},
+		},
+		Type: graphql.NewNonNull(GqlStrategyType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			oldSc, e := gqlserver.RetrieveNodeOfType(GqlStrategyNodeType, p.Args["old"])
+			if oldSc == nil || e != nil {
+				return nil, fmt.Errorf("old strategy not found: %w", e)
+			}
+			newSc, e := gqlserver.RetrieveNodeOfType(GqlStrategyNodeType, p.Args["new"])
+			if newSc == nil || e != nil {
+				return nil, fmt.Errorf("new strategy not found: %w", e)
+			}
+
+			if _, e := Replace(oldSc.(*Strategy), newSc.(*Strategy), p.Args["resetScratch"].(bool)); e != nil {
+				return nil, e
+			}
+			return newSc, nil
+		},
+	})
+
+	GqlStrategyEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "StrategyEvent",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Description: "Event kind.",
+				Type: graphql.NewNonNull(graphql.NewEnum(graphql.EnumConfig{
+					Name:        "StrategyEventKind",
+					Description: "Strategy event kind.",
+					Values: graphql.EnumValueConfigMap{
+						evtLoad:   &graphql.EnumValueConfig{Value: evtLoad},
+						evtUnload: &graphql.EnumValueConfig{Value: evtUnload},
+					},
+				})),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlStrategyEvent).Event, nil
+				},
+			},
+			"nid": &graphql.Field{
+				Description: "Numeric strategy code identifier.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlStrategyEvent).ID, nil
+				},
+			},
+			"name": &graphql.Field{
+				Description: "Short name.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlStrategyEvent).Name, nil
+				},
+			},
+			"strategy": &graphql.Field{
+				Description: "Strategy object, if it is still loaded.",
+				Type:        GqlStrategyType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if sc := Get(p.Source.(gqlStrategyEvent).ID); sc != nil {
+						return sc, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "strategyEvents",
+		Description: "Strategy load and unload events.",
+		Type:        graphql.NewNonNull(GqlStrategyEventType),
+	}, func(p graphql.ResolveParams) (<-chan interface{}, error) {
+		return gqlStrategyPublisher.Subscribe(p.Context), nil
+	})
 }
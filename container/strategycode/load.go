@@ -42,6 +42,7 @@ func makeStrategyCode(name string, bpf *C.struct_rte_bpf) (sc *Strategy, e error
 	c.bpf = bpf
 	c.jit = jit._func
 	table[lastID] = sc
+	gqlStrategyPublisher.Publish(gqlStrategyEvent{evtLoad, lastID, name})
 	return sc, nil
 }
 
@@ -65,9 +66,15 @@ func Load(name string, elf []byte) (sc *Strategy, e error) {
 
 // LoadFile loads a strategy BPF program from ELF file.
 func LoadFile(name, filename string) (sc *Strategy, e error) {
+	return LoadFileWithXsyms(name, filename, Xsyms, NXsyms)
+}
+
+// LoadFileWithXsyms loads a strategy BPF program from ELF file, linking with specified external symbols.
+// This is useful for unit testing.
+func LoadFileWithXsyms(name, filename string, xsyms unsafe.Pointer, nXsyms int) (sc *Strategy, e error) {
 	var prm C.struct_rte_bpf_prm
-	prm.xsym = (*C.struct_rte_bpf_xsym)(Xsyms)
-	prm.nb_xsym = (C.uint32_t)(NXsyms)
+	prm.xsym = (*C.struct_rte_bpf_xsym)(xsyms)
+	prm.nb_xsym = (C.uint32_t)(nXsyms)
 	prm.prog_arg._type = C.RTE_BPF_ARG_RAW
 
 	filenameC := C.CString(filename)
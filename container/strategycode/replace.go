@@ -0,0 +1,63 @@
+package strategycode
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Migrator switches users of a strategy, such as FIB entries, to another strategy.
+type Migrator interface {
+	// MigrateStrategy switches every user of oldSc to newSc.
+	// If resetScratch is true, strategy scratch areas associated with migrated users are cleared.
+	// Returns the number of migrated users.
+	MigrateStrategy(oldSc, newSc *Strategy, resetScratch bool) (int, error)
+}
+
+var (
+	migrators     = make(map[Migrator]bool)
+	migratorsLock sync.Mutex
+)
+
+// RegisterMigrator adds a Migrator to be invoked by Replace.
+func RegisterMigrator(m Migrator) {
+	migratorsLock.Lock()
+	defer migratorsLock.Unlock()
+	migrators[m] = true
+}
+
+// UnregisterMigrator removes a Migrator.
+func UnregisterMigrator(m Migrator) {
+	migratorsLock.Lock()
+	defer migratorsLock.Unlock()
+	delete(migrators, m)
+}
+
+// ErrReplaceSame indicates Replace is invoked with the same old and new strategy.
+var ErrReplaceSame = errors.New("old and new strategy are the same")
+
+// Replace switches all users of oldSc to newSc, and then unloads oldSc.
+// Each registered Migrator performs the switch, such as replacing FIB entries via RCU.
+// If resetScratch is true, strategy scratch areas are cleared; otherwise, newSc must be able to
+// interpret scratch areas written by oldSc.
+// oldSc is removed from the table, and its BPF program is unloaded after the last reference,
+// such as a FIB entry pending RCU reclamation, has been released.
+// Returns the number of migrated users.
+func Replace(oldSc, newSc *Strategy, resetScratch bool) (nMigrated int, e error) {
+	if oldSc == newSc {
+		return 0, ErrReplaceSame
+	}
+
+	migratorsLock.Lock()
+	defer migratorsLock.Unlock()
+	for m := range migrators {
+		n, e := m.MigrateStrategy(oldSc, newSc, resetScratch)
+		nMigrated += n
+		if e != nil {
+			return nMigrated, fmt.Errorf("MigrateStrategy: %w", e)
+		}
+	}
+
+	oldSc.Close()
+	return nMigrated, nil
+}
@@ -0,0 +1,69 @@
+package sgtestenv
+
+/*
+#include "../../../csrc/strategycode/test-ctx.h"
+*/
+import "C"
+import (
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+// CallKind indicates which strategy API function is called.
+type CallKind int
+
+// CallKind values.
+const (
+	CallSetTimer        CallKind = C.SGTEST_CALL_SET_TIMER
+	CallForwardInterest CallKind = C.SGTEST_CALL_FORWARD_INTEREST
+	CallReturnNacks     CallKind = C.SGTEST_CALL_RETURN_NACKS
+)
+
+// ForwardResult is the return value of SgForwardInterest.
+type ForwardResult int
+
+// ForwardResult values.
+const (
+	ForwardOK         ForwardResult = C.SGFWDI_OK
+	ForwardBadFace    ForwardResult = C.SGFWDI_BADFACE
+	ForwardAllocErr   ForwardResult = C.SGFWDI_ALLOCERR
+	ForwardNoNonce    ForwardResult = C.SGFWDI_NONONCE
+	ForwardSuppressed ForwardResult = C.SGFWDI_SUPPRESSED
+	ForwardHopZero    ForwardResult = C.SGFWDI_HOPZERO
+	ForwardDenied     ForwardResult = C.SGFWDI_DENIED
+)
+
+// Call records a strategy API call.
+type Call struct {
+	Kind CallKind
+
+	// SgSetTimer duration.
+	After time.Duration
+	// SgSetTimer return value.
+	TimerOK bool
+
+	// SgForwardInterest nexthop.
+	Nexthop iface.ID
+	// SgForwardInterest return value.
+	ForwardResult ForwardResult
+
+	// SgReturnNacks reason.
+	NackReason uint8
+}
+
+func makeCall(c C.SgTestCall) (call Call) {
+	call.Kind = CallKind(c.kind)
+	switch call.Kind {
+	case CallSetTimer:
+		call.After = eal.FromTscDuration(int64(c.after))
+		call.TimerOK = c.result != 0
+	case CallForwardInterest:
+		call.Nexthop = iface.ID(c.nh)
+		call.ForwardResult = ForwardResult(c.result)
+	case CallReturnNacks:
+		call.NackReason = uint8(c.reason)
+	}
+	return call
+}
@@ -0,0 +1,216 @@
+package sgtestenv
+
+/*
+#include "../../../csrc/strategycode/test-ctx.h"
+*/
+import "C"
+import (
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+// Event indicates why the strategy program is invoked.
+type Event int
+
+// Event values.
+const (
+	EventInterest Event = C.SGEVT_INTEREST
+	EventData     Event = C.SGEVT_DATA
+	EventNack     Event = C.SGEVT_NACK
+	EventTimer    Event = C.SGEVT_TIMER
+)
+
+// PitDn describes a PIT downstream record.
+type PitDn struct {
+	Face   iface.ID
+	Expiry eal.TscTime
+}
+
+// PitUp describes a PIT upstream record.
+type PitUp struct {
+	Face     iface.ID
+	Nack     uint8
+	LastTx   eal.TscTime
+	Suppress time.Duration
+	NTx      int
+}
+
+// Ctx is a synthetic strategy invocation context.
+type Ctx struct {
+	c *C.SgTestCtx
+}
+
+// NewCtx creates a Ctx.
+// The context initially contains an Interest event, an empty FIB entry, and an empty PIT entry.
+func NewCtx() *Ctx {
+	ctx := &Ctx{
+		c: (*C.SgTestCtx)(eal.Zmalloc("SgTestCtx", C.sizeof_SgTestCtx, eal.NumaSocket{})),
+	}
+	C.SgTestCtx_Init(ctx.c)
+	ctx.c.inner.eventKind = C.SGEVT_INTEREST
+	ctx.c.inner.now = C.TscTime(eal.TscNow())
+	return ctx
+}
+
+// Close releases the context.
+func (ctx *Ctx) Close() error {
+	eal.Free(ctx.c)
+	return nil
+}
+
+// Now returns current time seen by the strategy.
+func (ctx *Ctx) Now() eal.TscTime {
+	return eal.TscTime(ctx.c.inner.now)
+}
+
+// SetNow sets current time seen by the strategy.
+func (ctx *Ctx) SetNow(now eal.TscTime) {
+	ctx.c.inner.now = C.TscTime(now)
+}
+
+// Advance moves current time forward.
+func (ctx *Ctx) Advance(d time.Duration) {
+	ctx.SetNow(ctx.Now().Add(d))
+}
+
+// SetEvent sets event kind.
+// For EventData and EventNack, also call SetPacket.
+func (ctx *Ctx) SetEvent(evt Event) {
+	ctx.c.inner.eventKind = C.SgEvent(evt)
+}
+
+// SetFibNexthops sets FIB entry nexthops.
+// This clears the nexthop filter.
+func (ctx *Ctx) SetFibNexthops(nexthops ...iface.ID) {
+	if len(nexthops) > fibdef.MaxNexthops {
+		panic(fibdef.ErrNexthops)
+	}
+	for i, nh := range nexthops {
+		ctx.c.fibEntry.nexthops[i] = C.FaceID(nh)
+	}
+	ctx.c.fibEntry.nNexthops = C.uint8_t(len(nexthops))
+	ctx.c.inner.nhFlt = 0
+}
+
+// SetNexthopFilter sets a bitmask of FIB nexthops that should not be used.
+// Bit i rejects the i-th nexthop, as the forwarder does for the downstream face.
+func (ctx *Ctx) SetNexthopFilter(filter uint32) {
+	ctx.c.inner.nhFlt = C.SgFibNexthopFilter(filter)
+}
+
+// FibScratch returns a copy of the FIB entry scratch area.
+func (ctx *Ctx) FibScratch() []byte {
+	return C.GoBytes(unsafe.Pointer(&ctx.c.fibEntryDyn.scratch[0]), C.FibScratchSize)
+}
+
+// PitScratch returns a copy of the PIT entry scratch area.
+func (ctx *Ctx) PitScratch() []byte {
+	return C.GoBytes(unsafe.Pointer(&ctx.c.pitEntry.scratch[0]), C.SG_PIT_ENTRY_SCRATCH)
+}
+
+// ClearScratch zeros FIB entry and PIT entry scratch areas.
+func (ctx *Ctx) ClearScratch() {
+	ctx.c.fibEntryDyn.scratch = [C.FibScratchSize]C.char{}
+	ctx.c.pitEntry.scratch = [C.SG_PIT_ENTRY_SCRATCH / 8]C.uint64_t{}
+}
+
+// SetPacket sets the incoming Data or Nack packet.
+// nackReason should be zero for Data.
+func (ctx *Ctx) SetPacket(rxFace iface.ID, nackReason uint8, congMark uint8) {
+	ctx.c.pkt.rxFace = C.FaceID(rxFace)
+	ctx.c.pkt.nackReason = C.uint8_t(nackReason)
+	ctx.c.pkt.congMark = C.uint8_t(congMark)
+	ctx.c.pkt.timestamp = ctx.c.inner.now
+}
+
+// SetPitEntry replaces PIT downstream and upstream records.
+func (ctx *Ctx) SetPitEntry(dns []PitDn, ups []PitUp) {
+	if len(dns) > C.SG_PIT_ENTRY_MAX_DNS+C.SG_PIT_ENTRY_EXT_MAX_DNS ||
+		len(ups) > C.SG_PIT_ENTRY_MAX_UPS+C.SG_PIT_ENTRY_EXT_MAX_UPS {
+		panic("too many PIT records")
+	}
+
+	pe, ext := &ctx.c.pitEntry, &ctx.c.pitEntryExt
+	pe.dns = [C.SG_PIT_ENTRY_MAX_DNS]C.SgPitDn{}
+	pe.ups = [C.SG_PIT_ENTRY_MAX_UPS]C.SgPitUp{}
+	*ext = C.SgPitEntryExt{}
+	pe.ext = nil
+
+	for i, dn := range dns {
+		var c *C.SgPitDn
+		if i < C.SG_PIT_ENTRY_MAX_DNS {
+			c = &pe.dns[i]
+		} else {
+			c, pe.ext = &ext.dns[i-C.SG_PIT_ENTRY_MAX_DNS], ext
+		}
+		c.face = C.FaceID(dn.Face)
+		c.expiry = C.TscTime(dn.Expiry)
+	}
+
+	for i, up := range ups {
+		var c *C.SgPitUp
+		if i < C.SG_PIT_ENTRY_MAX_UPS {
+			c = &pe.ups[i]
+		} else {
+			c, pe.ext = &ext.ups[i-C.SG_PIT_ENTRY_MAX_UPS], ext
+		}
+		c.face = C.FaceID(up.Face)
+		c.nack = C.uint8_t(up.Nack)
+		c.lastTx = C.TscTime(up.LastTx)
+		c.suppress = C.TscDuration(eal.ToTscDuration(up.Suppress))
+		c.nTx = C.uint16_t(up.NTx)
+	}
+}
+
+// SetForwardResult predetermines SgForwardInterest result toward a nexthop.
+// Nexthops without a predetermined result receive ForwardOK.
+func (ctx *Ctx) SetForwardResult(nh iface.ID, res ForwardResult) {
+	n := int(ctx.c.nFwdiResults)
+	for i := 0; i < n; i++ {
+		if r := &ctx.c.fwdiResults[i]; r.nh == C.FaceID(nh) {
+			r.result = C.uint8_t(res)
+			return
+		}
+	}
+	if n >= C.SGTEST_MAX_FWDI_RESULTS {
+		panic("too many predetermined results")
+	}
+	ctx.c.fwdiResults[n] = C.SgTestFwdiResult{nh: C.FaceID(nh), result: C.uint8_t(res)}
+	ctx.c.nFwdiResults++
+}
+
+// SetTimerResult predetermines SgSetTimer result.
+func (ctx *Ctx) SetTimerResult(ok bool) {
+	ctx.c.setTimerResult = C.bool(ok)
+}
+
+// Invoke executes the strategy program, and returns its return value.
+// Calls recorded from a previous invocation are cleared.
+// The strategy should be loaded with Load or LoadFile in this package.
+func (ctx *Ctx) Invoke(sc *strategycode.Strategy) uint64 {
+	return uint64(C.SgTestCtx_Invoke(ctx.c, (*C.StrategyCode)(sc.Ptr())))
+}
+
+// Calls returns strategy API calls made during the last invocation.
+func (ctx *Ctx) Calls() (calls []Call) {
+	n := int(ctx.c.nCalls)
+	for i := 0; i < n; i++ {
+		calls = append(calls, makeCall(ctx.c.calls[i]))
+	}
+	return calls
+}
+
+// ForwardedTo returns nexthops that SgForwardInterest has been invoked on during the last invocation.
+func (ctx *Ctx) ForwardedTo() (nexthops []iface.ID) {
+	for _, call := range ctx.Calls() {
+		if call.Kind == CallForwardInterest {
+			nexthops = append(nexthops, call.Nexthop)
+		}
+	}
+	return nexthops
+}
@@ -0,0 +1,39 @@
+// Package sgtestenv provides an offline test harness for forwarding strategies.
+package sgtestenv
+
+/*
+#include "../../../csrc/strategycode/test-ctx.h"
+*/
+import "C"
+import (
+	"path"
+	"runtime"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+)
+
+var (
+	xsyms  unsafe.Pointer
+	nXsyms int
+)
+
+// LoadFile loads a strategy BPF program from ELF file, linking with mock strategy API functions.
+// The returned strategy can only be invoked by Ctx.Invoke, not by the forwarder.
+func LoadFile(name, filename string) (*strategycode.Strategy, error) {
+	return strategycode.LoadFileWithXsyms(name, filename, xsyms, nXsyms)
+}
+
+// Load loads a strategy BPF program compiled from strategy/*.c, linking with mock strategy API functions.
+// shortname is the strategy name without "ndndpdk-strategy-" prefix and ".o" suffix.
+func Load(shortname string) (*strategycode.Strategy, error) {
+	_, thisFile, _, _ := runtime.Caller(0)
+	elfFile := path.Join(path.Dir(thisFile), "../../../build/lib/bpf", "ndndpdk-strategy-"+shortname+".o")
+	return LoadFile(shortname, elfFile)
+}
+
+func init() {
+	var n C.int
+	xsyms = unsafe.Pointer(C.SgTestCtx_GetXsyms(&n))
+	nXsyms = int(n)
+}
@@ -0,0 +1,103 @@
+package sgtestenv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode/sgtestenv"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+func TestMulticast(t *testing.T) {
+	assert, require := makeAR(t)
+	defer strategycode.DestroyAll()
+	sc, e := sgtestenv.Load("multicast")
+	require.NoError(e)
+
+	ctx := sgtestenv.NewCtx()
+	defer ctx.Close()
+
+	tests := []struct {
+		nexthops []iface.ID
+		filter   uint32
+		expected []iface.ID
+	}{
+		{[]iface.ID{0x1001, 0x1002, 0x1003}, 0, []iface.ID{0x1001, 0x1002, 0x1003}},
+		{[]iface.ID{0x1001, 0x1002, 0x1003}, 0x2, []iface.ID{0x1001, 0x1003}},
+		{[]iface.ID{0x1001}, 0x1, nil},
+	}
+	for i, tt := range tests {
+		ctx.SetFibNexthops(tt.nexthops...)
+		ctx.SetNexthopFilter(tt.filter)
+		ctx.Invoke(sc)
+		assert.Equal(tt.expected, ctx.ForwardedTo(), "%d", i)
+	}
+}
+
+func TestSequential(t *testing.T) {
+	assert, require := makeAR(t)
+	defer strategycode.DestroyAll()
+	sc, e := sgtestenv.Load("sequential")
+	require.NoError(e)
+
+	ctx := sgtestenv.NewCtx()
+	defer ctx.Close()
+	ctx.SetFibNexthops(0x1001, 0x1002, 0x1003)
+	ctx.SetForwardResult(0x1002, sgtestenv.ForwardBadFace)
+
+	ctx.Invoke(sc)
+	assert.Equal([]iface.ID{0x1001}, ctx.ForwardedTo())
+
+	ctx.Invoke(sc)
+	calls := ctx.Calls()
+	require.Len(calls, 2)
+	assert.Equal(iface.ID(0x1002), calls[0].Nexthop)
+	assert.Equal(sgtestenv.ForwardBadFace, calls[0].ForwardResult)
+	assert.Equal(iface.ID(0x1003), calls[1].Nexthop)
+	assert.Equal(sgtestenv.ForwardOK, calls[1].ForwardResult)
+
+	ctx.Invoke(sc)
+	assert.Equal([]iface.ID{0x1001}, ctx.ForwardedTo())
+}
+
+func TestReject(t *testing.T) {
+	assert, require := makeAR(t)
+	defer strategycode.DestroyAll()
+	sc, e := sgtestenv.Load("reject")
+	require.NoError(e)
+
+	ctx := sgtestenv.NewCtx()
+	defer ctx.Close()
+	ctx.SetFibNexthops(0x1001)
+
+	ctx.Invoke(sc)
+	calls := ctx.Calls()
+	require.Len(calls, 1)
+	assert.Equal(sgtestenv.CallReturnNacks, calls[0].Kind)
+	assert.EqualValues(an.NackNoRoute, calls[0].NackReason)
+}
+
+func TestDelay(t *testing.T) {
+	assert, require := makeAR(t)
+	defer strategycode.DestroyAll()
+	sc, e := sgtestenv.Load("delay")
+	require.NoError(e)
+
+	ctx := sgtestenv.NewCtx()
+	defer ctx.Close()
+	ctx.SetFibNexthops(0x1001, 0x1002)
+
+	ctx.Invoke(sc)
+	calls := ctx.Calls()
+	require.Len(calls, 1)
+	assert.Equal(sgtestenv.CallSetTimer, calls[0].Kind)
+	assert.InDelta(200*time.Millisecond, calls[0].After, float64(time.Millisecond))
+	assert.True(calls[0].TimerOK)
+
+	ctx.SetEvent(sgtestenv.EventTimer)
+	ctx.SetForwardResult(0x1001, sgtestenv.ForwardSuppressed)
+	ctx.Invoke(sc)
+	assert.Equal([]iface.ID{0x1001, 0x1002}, ctx.ForwardedTo())
+}
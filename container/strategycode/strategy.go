@@ -44,7 +44,10 @@ func (sc *Strategy) CountRefs() int {
 func (sc *Strategy) Close() error {
 	tableLock.Lock()
 	defer tableLock.Unlock()
-	delete(table, sc.ID())
+	if id := sc.ID(); table[id] == sc {
+		delete(table, id)
+		gqlStrategyPublisher.Publish(gqlStrategyEvent{evtUnload, id, sc.Name()})
+	}
 	C.StrategyCode_Unref(sc.ptr())
 	return nil
 }
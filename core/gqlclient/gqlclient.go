@@ -3,7 +3,9 @@ package gqlclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/bhoriuchi/graphql-go-tools/handler"
 	"github.com/graphql-go/graphql"
+	"golang.org/x/net/websocket"
 )
 
 func jsonRoundtrip(input, ptr interface{}) error {
@@ -30,6 +33,7 @@ func jsonRoundtrip(input, ptr interface{}) error {
 // Client is a GraphQL client.
 type Client struct {
 	uri        string
+	wsURI      string
 	HTTPClient http.Client
 	wg         sync.WaitGroup
 }
@@ -113,7 +117,100 @@ func (c *Client) DoOperation(query, op string, vars interface{}, key string, res
 	return nil
 }
 
+// Subscribe executes a subscription on the GraphQL server.
+// This blocks until the subscription ends or ctx is canceled.
+//  query: a GraphQL document, may contain only one subscription.
+//  vars: query variables.
+//  key: if non-empty, unmarshal result.data[key] instead of result.data.
+//  onData: invoked with each result, which should be unmarshaled with json.Unmarshal.
+func (c *Client) Subscribe(ctx context.Context, query string, vars interface{}, key string, onData func(data json.RawMessage) error) error {
+	if c.wsURI == "" {
+		return errNoWebSocket
+	}
+	c.wg.Add(1)
+	defer c.wg.Done()
+
+	var start struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	start.Query = query
+	if e := jsonRoundtrip(vars, &start.Variables); e != nil {
+		return fmt.Errorf("json(vars): %w", e)
+	}
+
+	cfg, e := websocket.NewConfig(c.wsURI, c.uri)
+	if e != nil {
+		return fmt.Errorf("websocket.NewConfig: %w", e)
+	}
+	cfg.Protocol = []string{"graphql-ws"}
+	conn, e := websocket.DialConfig(cfg)
+	if e != nil {
+		return fmt.Errorf("websocket.Dial: %w", e)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	type message struct {
+		ID      string          `json:"id,omitempty"`
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload,omitempty"`
+	}
+	startPayload, _ := json.Marshal(start)
+	for _, msg := range []message{
+		{Type: "connection_init", Payload: json.RawMessage("{}")},
+		{ID: "1", Type: "start", Payload: startPayload},
+	} {
+		if e := websocket.JSON.Send(conn, msg); e != nil {
+			return fmt.Errorf("websocket.Send: %w", e)
+		}
+	}
+
+	for {
+		var msg message
+		if e := websocket.JSON.Receive(conn, &msg); e != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("websocket.Receive: %w", e)
+		}
+
+		switch msg.Type {
+		case "data":
+			var result struct {
+				Data   map[string]json.RawMessage `json:"data"`
+				Errors []json.RawMessage          `json:"errors"`
+			}
+			if e := json.Unmarshal(msg.Payload, &result); e != nil {
+				return fmt.Errorf("json.Unmarshal(payload): %w", e)
+			}
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("result.HasErrors: %s", result.Errors[0])
+			}
+			data, ok := result.Data[key]
+			if key == "" {
+				data, _ = json.Marshal(result.Data)
+			} else if !ok {
+				return fmt.Errorf("data[%s] missing", key)
+			}
+			if e := onData(data); e != nil {
+				return e
+			}
+		case "error", "connection_error":
+			return fmt.Errorf("subscription error: %s", msg.Payload)
+		case "complete":
+			return nil
+		}
+	}
+}
+
+var errNoWebSocket = errors.New("subscription requires http or https URI scheme")
+
 // New creates a Client.
+// Subscribe is only available if the URI scheme is http or https.
 func New(uri string) (*Client, error) {
 	u, e := url.Parse(uri)
 	if e != nil {
@@ -123,5 +220,15 @@ func New(uri string) (*Client, error) {
 	c := &Client{
 		uri: u.String(),
 	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return c, nil
+	}
+	c.wsURI = u.String()
 	return c, nil
 }
@@ -1,7 +1,10 @@
 package gqlclient_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/core/gqlclient"
 )
@@ -25,3 +28,45 @@ func TestClient(t *testing.T) {
 	assert.NoError(e)
 	assert.NotZero(reply.Version)
 }
+
+func TestNonHTTPScheme(t *testing.T) {
+	assert, require := makeAR(t)
+
+	c, e := gqlclient.New("unix:///run/ndn-dpdk.sock")
+	require.NoError(e)
+	assert.Error(c.Subscribe(context.Background(), `subscription { tick }`, nil, "", func(json.RawMessage) error { return nil }))
+}
+
+func TestSubscribe(t *testing.T) {
+	assert, require := makeAR(t)
+
+	c, e := gqlclient.New(serverURI)
+	require.NoError(e)
+
+	var ticks []int
+	e = c.Subscribe(context.Background(), `
+		subscription tick($count: Int!) {
+			tick(count: $count)
+		}
+	`, map[string]interface{}{
+		"count": 5,
+	}, "tick", func(data json.RawMessage) error {
+		var tick int
+		if e := json.Unmarshal(data, &tick); e != nil {
+			return e
+		}
+		ticks = append(ticks, tick)
+		return nil
+	})
+	assert.NoError(e)
+	assert.Equal([]int{0, 1, 2, 3, 4}, ticks)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	e = c.Subscribe(ctx, `
+		subscription {
+			tick(count: 1000000)
+		}
+	`, nil, "tick", func(data json.RawMessage) error { return nil })
+	assert.NoError(e)
+}
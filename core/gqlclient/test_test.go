@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gabstv/freeport"
+	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
 	"github.com/usnistgov/ndn-dpdk/core/testenv"
 )
@@ -22,8 +23,34 @@ func TestMain(m *testing.M) {
 	if e != nil {
 		panic(e)
 	}
+	gqlserver.AddSubscription(&graphql.Field{
+		Name: "tick",
+		Type: graphql.Int,
+		Args: graphql.FieldConfigArgument{
+			"count": &graphql.ArgumentConfig{
+				Type: gqlserver.NonNullInt,
+			},
+		},
+	}, func(p graphql.ResolveParams) (<-chan interface{}, error) {
+		count := p.Args["count"].(int)
+		ch := make(chan interface{})
+		go func() {
+			defer close(ch)
+			for i := 0; i < count; i++ {
+				select {
+				case <-p.Context.Done():
+					return
+				case ch <- i:
+				}
+			}
+		}()
+		return ch, nil
+	})
+
 	os.Setenv("GQLSERVER_HTTP", fmt.Sprintf("127.0.0.1:%d", port))
-	gqlserver.Start()
+	if e := gqlserver.Start(); e != nil {
+		panic(e)
+	}
 	time.Sleep(100 * time.Millisecond)
 
 	serverURI = fmt.Sprintf("http://127.0.0.1:%d/", port)
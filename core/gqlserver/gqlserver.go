@@ -3,6 +3,8 @@
 package gqlserver
 
 import (
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 
@@ -33,6 +35,20 @@ func AddMutation(f *graphql.Field) {
 	Schema.Mutation.AddFieldConfig(f.Name, f)
 }
 
+// DefaultHTTPAddr is the HTTP server listen address, if GQLSERVER_HTTP environment variable is absent.
+// A program that may run alongside the forwarder should change this before Start(), to avoid conflict.
+var DefaultHTTPAddr = "127.0.0.1:3030"
+
+var httpHandlers = map[string]http.Handler{}
+
+var theSchema *graphql.Schema
+
+// AddHTTPHandler adds an HTTP handler on the same server, such as a metrics endpoint.
+// This must be called before Start().
+func AddHTTPHandler(pattern string, h http.Handler) {
+	httpHandlers[pattern] = h
+}
+
 func init() {
 	AddQuery(&graphql.Field{
 		Name: "version",
@@ -44,23 +60,29 @@ func init() {
 }
 
 // Start starts the server.
-func Start() {
+// It returns an error if the HTTP server cannot listen on its address.
+// The schema remains usable via Local even if the HTTP server fails to start.
+func Start() error {
+	if nSubscriptions > 0 {
+		Schema.Subscription = subscription
+	}
 	sch, e := graphql.NewSchema(Schema)
 	if e != nil {
 		log.WithField("schema", Schema).WithError(e).Panic("graphql.NewSchema")
 	}
+	theSchema = &sch
 
-	go startHTTP(&sch)
+	return startHTTP(&sch)
 }
 
-func startHTTP(sch *graphql.Schema) {
+func startHTTP(sch *graphql.Schema) error {
 	addr := os.Getenv("GQLSERVER_HTTP")
 	switch addr {
 	case "0":
 		log.Warn("GraphQL HTTP server disabled")
-		return
+		return nil
 	case "":
-		addr = "127.0.0.1:3030"
+		addr = DefaultHTTPAddr
 	}
 
 	h := handler.New(&handler.Config{
@@ -68,13 +90,33 @@ func startHTTP(sch *graphql.Schema) {
 		Pretty:           true,
 		PlaygroundConfig: handler.NewDefaultPlaygroundConfig(),
 	})
-	log.WithField("addr", addr).Info("GraphQL HTTP server starting")
 
 	var mux http.ServeMux
 	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, req *http.Request) {
 		w.Header().Add("Content-Type", "text/plain")
 		w.Write([]byte("User-Agent: *\nDisallow: /\n"))
 	})
-	mux.Handle("/", h)
-	http.ListenAndServe(addr, &mux)
+	for pattern, h := range httpHandlers {
+		mux.Handle(pattern, h)
+	}
+	wsh := makeWebSocketHandler(sch)
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		if isWebSocketUpgrade(req) {
+			wsh.ServeHTTP(w, req)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+
+	listener, e := net.Listen("tcp", addr)
+	if e != nil {
+		return fmt.Errorf("GraphQL HTTP server listen %s: %w", addr, e)
+	}
+	log.WithField("addr", addr).Info("GraphQL HTTP server starting")
+	go func() {
+		if e := http.Serve(listener, &mux); e != nil {
+			log.WithField("addr", addr).WithError(e).Error("GraphQL HTTP server error")
+		}
+	}()
+	return nil
 }
@@ -0,0 +1,64 @@
+package gqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+var errNotStarted = errors.New("GraphQL server not started")
+
+// LocalClient executes operations on the GraphQL schema in the same process, without going through HTTP.
+// It has the same Do method as gqlclient.Client, and can be used after Start().
+type LocalClient struct{}
+
+// Local is the LocalClient instance.
+var Local LocalClient
+
+// Do executes an operation.
+//  query: a GraphQL document, may contain only one query or mutation.
+//  vars: query variables.
+//  key: if non-empty, unmarshal result.data[key] instead of result.data.
+//  res: pointer to result struct.
+func (LocalClient) Do(query string, vars interface{}, key string, res interface{}) error {
+	if theSchema == nil {
+		return errNotStarted
+	}
+
+	var varsM map[string]interface{}
+	if e := DecodeJSON(vars, &varsM); e != nil {
+		return fmt.Errorf("json(vars): %w", e)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *theSchema,
+		RequestString:  query,
+		VariableValues: varsM,
+		Context:        context.Background(),
+	})
+	if result.HasErrors() {
+		return fmt.Errorf("result.HasErrors: %w", result.Errors[0])
+	}
+
+	if res != nil {
+		data := result.Data
+		if key != "" {
+			m, _ := data.(map[string]interface{})
+			var ok bool
+			if data, ok = m[key]; !ok {
+				return fmt.Errorf("data[%s] missing", key)
+			}
+		}
+		j, e := json.Marshal(data)
+		if e != nil {
+			return fmt.Errorf("json.Marshal(data): %w", e)
+		}
+		if e := json.Unmarshal(j, res); e != nil {
+			return fmt.Errorf("json.Unmarshal(data): %w", e)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package gqlserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// SubscribeFunc starts a subscription.
+// It returns a channel that yields source values of the subscription field.
+// The channel should be closed when p.Context is canceled.
+type SubscribeFunc func(p graphql.ResolveParams) (<-chan interface{}, error)
+
+var (
+	subscription = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Subscription",
+		Fields: graphql.Fields{},
+	})
+	nSubscriptions = 0
+
+	errNotSubscription = errors.New("operation is not a subscription")
+	errSubscribeTwice  = errors.New("subscription must have exactly one top-level field")
+)
+
+type subscribeCtxKey struct{}
+
+type subscribeState struct {
+	called bool
+	field  string
+	ch     <-chan interface{}
+	e      error
+}
+
+// AddSubscription adds a top-level subscription field.
+// f.Resolve is overwritten.
+func AddSubscription(f *graphql.Field, subscribe SubscribeFunc) {
+	name := f.Name
+	f.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		if state, ok := p.Context.Value(subscribeCtxKey{}).(*subscribeState); ok {
+			if state.called {
+				return nil, errSubscribeTwice
+			}
+			state.called, state.field = true, name
+			state.ch, state.e = subscribe(p)
+			return nil, state.e
+		}
+		return p.Source.(map[string]interface{})[name], nil
+	}
+	subscription.AddFieldConfig(name, f)
+	nSubscriptions++
+}
+
+// Subscribe executes a subscription operation.
+// It returns a channel that yields a result for each source value, which is closed when the
+// subscription ends or ctx is canceled.
+func Subscribe(ctx context.Context, p graphql.Params) (<-chan *graphql.Result, error) {
+	if e := checkSubscription(p); e != nil {
+		return nil, e
+	}
+
+	var state subscribeState
+	p0 := p
+	p0.Context = context.WithValue(ctx, subscribeCtxKey{}, &state)
+	res := graphql.Do(p0)
+	switch {
+	case state.e != nil:
+		return nil, state.e
+	case !state.called && res.HasErrors():
+		return nil, res.Errors[0]
+	case !state.called:
+		return nil, errNotSubscription
+	}
+
+	results := make(chan *graphql.Result)
+	go func() {
+		defer close(results)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok := <-state.ch:
+				if !ok {
+					return
+				}
+				p1 := p
+				p1.Context = ctx
+				p1.RootObject = map[string]interface{}{state.field: value}
+				select {
+				case <-ctx.Done():
+					return
+				case results <- graphql.Do(p1):
+				}
+			}
+		}
+	}()
+	return results, nil
+}
+
+// IsSubscription determines whether the requested operation is a subscription.
+func IsSubscription(requestString, operationName string) bool {
+	op, e := findOperation(requestString, operationName)
+	return e == nil && op.Operation == ast.OperationTypeSubscription
+}
+
+func checkSubscription(p graphql.Params) error {
+	op, e := findOperation(p.RequestString, p.OperationName)
+	if e != nil {
+		return e
+	}
+	if op.Operation != ast.OperationTypeSubscription {
+		return errNotSubscription
+	}
+	return nil
+}
+
+func findOperation(requestString, operationName string) (found *ast.OperationDefinition, e error) {
+	doc, e := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(requestString),
+			Name: "GraphQL request",
+		}),
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || (operationName != "" && (op.Name == nil || op.Name.Value != operationName)) {
+			continue
+		}
+		if found != nil {
+			return nil, errors.New("operation name is required")
+		}
+		found = op
+	}
+	if found == nil {
+		return nil, errors.New("operation not found")
+	}
+	return found, nil
+}
+
+// Publisher delivers values to subscribers.
+// It is typically used by SubscribeFunc implementations that report events.
+type Publisher struct {
+	mutex sync.Mutex
+	subs  map[chan interface{}]*publisherSub
+}
+
+type publisherSub struct {
+	nDropped int
+}
+
+// PublisherQueueCapacity is the channel buffer size of each subscriber.
+// If a slow subscriber has this many pending values, further values are dropped.
+const PublisherQueueCapacity = 256
+
+// NewPublisher creates a Publisher.
+func NewPublisher() *Publisher {
+	return &Publisher{
+		subs: make(map[chan interface{}]*publisherSub),
+	}
+}
+
+// Publish delivers a value to all subscribers.
+// This does not block.
+// A warning is logged upon the first dropped value of each subscriber, and the number of dropped
+// values is logged when the subscriber leaves.
+func (pub *Publisher) Publish(value interface{}) {
+	pub.mutex.Lock()
+	defer pub.mutex.Unlock()
+	for ch, sub := range pub.subs {
+		select {
+		case ch <- value:
+		default:
+			if sub.nDropped++; sub.nDropped == 1 {
+				log.Warn("Publisher subscriber queue full, dropping values")
+			}
+		}
+	}
+}
+
+// Subscribe adds a subscriber.
+// It returns a channel that yields published values, which is closed when ctx is canceled.
+func (pub *Publisher) Subscribe(ctx context.Context) <-chan interface{} {
+	ch := make(chan interface{}, PublisherQueueCapacity)
+	pub.mutex.Lock()
+	sub := &publisherSub{}
+	pub.subs[ch] = sub
+	pub.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		pub.mutex.Lock()
+		defer pub.mutex.Unlock()
+		delete(pub.subs, ch)
+		close(ch)
+		if sub.nDropped > 0 {
+			log.WithField("dropped", sub.nDropped).Warn("Publisher subscriber left with dropped values")
+		}
+	}()
+	return ch
+}
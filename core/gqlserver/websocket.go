@@ -0,0 +1,178 @@
+package gqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocketProtocol is the WebSocket subprotocol for GraphQL operations.
+// This is the protocol defined by subscriptions-transport-ws package.
+const WebSocketProtocol = "graphql-ws"
+
+// WebSocket message types.
+const (
+	wsConnectionInit      = "connection_init"
+	wsConnectionAck       = "connection_ack"
+	wsConnectionError     = "connection_error"
+	wsConnectionTerminate = "connection_terminate"
+	wsStart               = "start"
+	wsStop                = "stop"
+	wsData                = "data"
+	wsError               = "error"
+	wsComplete            = "complete"
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsStartPayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+func isWebSocketUpgrade(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Upgrade"), "websocket")
+}
+
+func makeWebSocketHandler(sch *graphql.Schema) http.Handler {
+	return websocket.Server{
+		Handshake: func(cfg *websocket.Config, req *http.Request) error {
+			protocols := cfg.Protocol
+			cfg.Protocol = nil
+			for _, p := range protocols {
+				if p == WebSocketProtocol {
+					cfg.Protocol = []string{p}
+				}
+			}
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			ws := &wsConn{
+				sch:  sch,
+				conn: conn,
+				ops:  make(map[string]context.CancelFunc),
+			}
+			ws.run()
+		},
+	}
+}
+
+type wsConn struct {
+	sch       *graphql.Schema
+	conn      *websocket.Conn
+	sendMutex sync.Mutex
+	opsMutex  sync.Mutex
+	ops       map[string]context.CancelFunc
+}
+
+func (ws *wsConn) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		ws.conn.Close()
+	}()
+
+	for {
+		var msg wsMessage
+		if e := websocket.JSON.Receive(ws.conn, &msg); e != nil {
+			return
+		}
+
+		switch msg.Type {
+		case wsConnectionInit:
+			ws.send(wsMessage{Type: wsConnectionAck})
+		case wsConnectionTerminate:
+			return
+		case wsStart:
+			ws.start(ctx, msg)
+		case wsStop:
+			ws.stop(msg.ID)
+		default:
+			ws.sendError(msg.ID, wsConnectionError, gqlerrors.NewFormattedError("unknown message type"))
+		}
+	}
+}
+
+func (ws *wsConn) send(msg wsMessage) {
+	ws.sendMutex.Lock()
+	defer ws.sendMutex.Unlock()
+	websocket.JSON.Send(ws.conn, msg)
+}
+
+func (ws *wsConn) sendPayload(id, typ string, payload interface{}) {
+	j, _ := json.Marshal(payload)
+	ws.send(wsMessage{ID: id, Type: typ, Payload: j})
+}
+
+func (ws *wsConn) sendError(id, typ string, e error) {
+	ws.sendPayload(id, typ, gqlerrors.FormatError(e))
+}
+
+func (ws *wsConn) start(ctx context.Context, msg wsMessage) {
+	var payload wsStartPayload
+	if e := json.Unmarshal(msg.Payload, &payload); e != nil {
+		ws.sendError(msg.ID, wsError, e)
+		return
+	}
+
+	ws.opsMutex.Lock()
+	defer ws.opsMutex.Unlock()
+	if _, ok := ws.ops[msg.ID]; ok {
+		ws.sendError(msg.ID, wsError, gqlerrors.NewFormattedError("duplicate operation ID"))
+		return
+	}
+
+	params := graphql.Params{
+		Schema:         *ws.sch,
+		RequestString:  payload.Query,
+		VariableValues: payload.Variables,
+		OperationName:  payload.OperationName,
+		Context:        ctx,
+	}
+	if !IsSubscription(payload.Query, payload.OperationName) {
+		go func() {
+			ws.sendPayload(msg.ID, wsData, graphql.Do(params))
+			ws.send(wsMessage{ID: msg.ID, Type: wsComplete})
+		}()
+		return
+	}
+
+	opCtx, cancel := context.WithCancel(ctx)
+	results, e := Subscribe(opCtx, params)
+	if e != nil {
+		cancel()
+		ws.sendError(msg.ID, wsError, e)
+		return
+	}
+	ws.ops[msg.ID] = cancel
+
+	go func() {
+		for result := range results {
+			ws.sendPayload(msg.ID, wsData, result)
+		}
+		ws.opsMutex.Lock()
+		delete(ws.ops, msg.ID)
+		ws.opsMutex.Unlock()
+		cancel()
+		ws.send(wsMessage{ID: msg.ID, Type: wsComplete})
+	}()
+}
+
+func (ws *wsConn) stop(id string) {
+	ws.opsMutex.Lock()
+	defer ws.opsMutex.Unlock()
+	if cancel, ok := ws.ops[id]; ok {
+		cancel()
+	}
+}
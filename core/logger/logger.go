@@ -17,7 +17,7 @@ func New(pkg string) logrus.FieldLogger {
 // NewWithPrefix creates a logger with specified prefix.
 func NewWithPrefix(pkg string, prefix string) logrus.FieldLogger {
 	logger := logrus.New()
-	logger.SetLevel(parseLevel(pkg))
+	logger.SetLevel(parseLevel(GetLevel(pkg)))
 
 	formatter := &prefixFormatter{}
 	formatter.prefix = fmt.Sprintf("[%s] ", prefix)
@@ -25,6 +25,9 @@ func NewWithPrefix(pkg string, prefix string) logrus.FieldLogger {
 	formatter.TimestampFormat = time.StampMicro
 	logger.Formatter = formatter
 
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[pkg] = append(registry[pkg], logger)
 	return logger
 }
 
@@ -40,8 +43,7 @@ func GetLevel(pkg string) rune {
 	return rune(lvl[0])
 }
 
-func parseLevel(pkg string) logrus.Level {
-	lvl := GetLevel(pkg)
+func parseLevel(lvl rune) logrus.Level {
 	switch lvl {
 	case 'V', 'D':
 		return logrus.DebugLevel
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	registryLock sync.Mutex
+	registry     = make(map[string][]*logrus.Logger)
+)
+
+var levelLetters = map[logrus.Level]rune{
+	logrus.TraceLevel: 'V',
+	logrus.DebugLevel: 'D',
+	logrus.InfoLevel:  'I',
+	logrus.WarnLevel:  'W',
+	logrus.ErrorLevel: 'E',
+	logrus.FatalLevel: 'F',
+	logrus.PanicLevel: 'N',
+}
+
+// ListModules returns names of log modules that have Go loggers, in alphabetical order.
+func ListModules() (list []string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	for pkg := range registry {
+		list = append(list, pkg)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// GetModuleLevel returns current log level of a module's Go loggers as a letter.
+// It returns zero if the module has no Go logger.
+func GetModuleLevel(pkg string) rune {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	loggers := registry[pkg]
+	if len(loggers) == 0 {
+		return 0
+	}
+	return levelLetters[loggers[0].GetLevel()]
+}
+
+// SetModuleLevel changes log level of a module's Go loggers.
+// lvl is a letter, as accepted in environment variables.
+// It returns false if the module has no Go logger.
+func SetModuleLevel(pkg string, lvl rune) bool {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	loggers := registry[pkg]
+	for _, logger := range loggers {
+		logger.SetLevel(parseLevel(lvl))
+	}
+	return len(loggers) > 0
+}
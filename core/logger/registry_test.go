@@ -0,0 +1,30 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/core/logger"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+)
+
+var makeAR = testenv.MakeAR
+
+func TestRegistry(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	assert.False(logger.SetModuleLevel("LoggerTestNone", 'D'))
+	assert.Equal(rune(0), logger.GetModuleLevel("LoggerTestNone"))
+
+	logger.New("LoggerTestA")
+	logger.NewWithPrefix("LoggerTestA", "p")
+	logger.New("LoggerTestB")
+	assert.Subset(logger.ListModules(), []string{"LoggerTestA", "LoggerTestB"})
+	assert.Equal('I', logger.GetModuleLevel("LoggerTestA"))
+
+	assert.True(logger.SetModuleLevel("LoggerTestA", 'W'))
+	assert.Equal('W', logger.GetModuleLevel("LoggerTestA"))
+	assert.Equal('I', logger.GetModuleLevel("LoggerTestB"))
+
+	assert.True(logger.SetModuleLevel("LoggerTestA", 'D'))
+	assert.Equal('D', logger.GetModuleLevel("LoggerTestA"))
+}
@@ -0,0 +1,238 @@
+// Package metrics exposes counters in Prometheus text exposition format.
+// It is a singleton: collectors are registered via AddCollector, and served at /metrics on the GraphQL HTTP server.
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+)
+
+// Prefix is prepended to every metric name.
+const Prefix = "ndndpdk_"
+
+// Metric types.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+	TypeSummary = "summary"
+)
+
+// SummarySource provides values of a summary.
+// runningstat.Snapshot satisfies this interface.
+type SummarySource interface {
+	Count() uint64
+	Mean() float64
+	Min() float64
+	Max() float64
+}
+
+// QuantileSource provides quantiles of a summary.
+// If a SummarySource also implements this interface, quantiles listed in SummaryQuantiles are included.
+// runningstat.QuantileSnapshot satisfies this interface.
+type QuantileSource interface {
+	Quantile(q float64) float64
+}
+
+// SummaryQuantiles lists quantiles included in a summary whose source implements QuantileSource.
+var SummaryQuantiles = []float64{0.5, 0.9, 0.99, 0.999}
+
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples bytes.Buffer
+}
+
+// Writer collects metric samples.
+// Samples of the same metric family are grouped together in the output, regardless of the order they are added.
+type Writer struct {
+	families []*family
+	index    map[string]*family
+}
+
+// NewWriter creates a Writer.
+func NewWriter() *Writer {
+	return &Writer{
+		index: map[string]*family{},
+	}
+}
+
+func (w *Writer) family(name, typ, help string) *family {
+	name = Prefix + name
+	if f := w.index[name]; f != nil {
+		return f
+	}
+	f := &family{
+		name: name,
+		help: help,
+		typ:  typ,
+	}
+	w.families = append(w.families, f)
+	w.index[name] = f
+	return f
+}
+
+func (f *family) sample(suffix string, value float64, labels []string, extra ...string) {
+	f.samples.WriteString(f.name)
+	f.samples.WriteString(suffix)
+	writeLabels(&f.samples, append(append([]string{}, labels...), extra...))
+	f.samples.WriteByte(' ')
+	f.samples.WriteString(formatValue(value))
+	f.samples.WriteByte('\n')
+}
+
+// Counter adds a counter sample.
+// labels are key-value pairs.
+func (w *Writer) Counter(name, help string, value uint64, labels ...string) {
+	w.family(name, TypeCounter, help).sample("", float64(value), labels)
+}
+
+// Gauge adds a gauge sample.
+// labels are key-value pairs.
+func (w *Writer) Gauge(name, help string, value float64, labels ...string) {
+	w.family(name, TypeGauge, help).sample("", value, labels)
+}
+
+// Summary adds a summary sample.
+// Minimum and maximum are reported as quantile 0 and 1, and sum is estimated from the mean.
+// labels are key-value pairs.
+func (w *Writer) Summary(name, help string, s SummarySource, labels ...string) {
+	f := w.family(name, TypeSummary, help)
+	count := s.Count()
+	if count > 0 {
+		f.sample("", s.Min(), labels, "quantile", "0")
+		if qs, ok := s.(QuantileSource); ok {
+			for _, q := range SummaryQuantiles {
+				f.sample("", qs.Quantile(q), labels, "quantile", strconv.FormatFloat(q, 'f', -1, 64))
+			}
+		}
+		f.sample("", s.Max(), labels, "quantile", "1")
+	}
+	sum := 0.0
+	if mean := s.Mean(); count > 0 && !math.IsNaN(mean) {
+		sum = mean * float64(count)
+	}
+	f.sample("_sum", sum, labels)
+	f.sample("_count", float64(count), labels)
+}
+
+// Bytes returns the text exposition.
+func (w *Writer) Bytes() []byte {
+	var b bytes.Buffer
+	for _, f := range w.families {
+		b.WriteString("# HELP ")
+		b.WriteString(f.name)
+		b.WriteByte(' ')
+		b.WriteString(escapeHelp(f.help))
+		b.WriteString("\n# TYPE ")
+		b.WriteString(f.name)
+		b.WriteByte(' ')
+		b.WriteString(f.typ)
+		b.WriteByte('\n')
+		b.Write(f.samples.Bytes())
+	}
+	return b.Bytes()
+}
+
+func writeLabels(b *bytes.Buffer, labels []string) {
+	if len(labels) == 0 {
+		return
+	}
+	if len(labels)%2 != 0 {
+		panic("metrics: labels must be key-value pairs")
+	}
+	b.WriteByte('{')
+	for i := 0; i < len(labels); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(labels[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(labels[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Collector adds metric samples to a Writer.
+// It is invoked on every scrape, and should only read counters without blocking data plane threads.
+type Collector func(w *Writer)
+
+var (
+	collectorsLock sync.Mutex
+	collectors     = map[string]Collector{}
+)
+
+// AddCollector registers a collector.
+// If a collector with the same key exists, it is replaced.
+func AddCollector(key string, c Collector) {
+	collectorsLock.Lock()
+	defer collectorsLock.Unlock()
+	collectors[key] = c
+}
+
+// RemoveCollector unregisters a collector.
+func RemoveCollector(key string) {
+	collectorsLock.Lock()
+	defer collectorsLock.Unlock()
+	delete(collectors, key)
+}
+
+// Collect invokes all collectors.
+func Collect() *Writer {
+	collectorsLock.Lock()
+	keys := make([]string, 0, len(collectors))
+	for key := range collectors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make([]Collector, len(keys))
+	for i, key := range keys {
+		list[i] = collectors[key]
+	}
+	collectorsLock.Unlock()
+
+	w := NewWriter()
+	for _, c := range list {
+		c(w)
+	}
+	return w
+}
+
+// Handler serves metrics over HTTP.
+var Handler http.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	rw.Write(Collect().Bytes())
+})
+
+func init() {
+	gqlserver.AddHTTPHandler("/metrics", Handler)
+}
@@ -0,0 +1,85 @@
+package metrics_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+)
+
+var makeAR = testenv.MakeAR
+
+type summary struct {
+	count          uint64
+	mean, min, max float64
+}
+
+func (s summary) Count() uint64 { return s.count }
+func (s summary) Mean() float64 { return s.mean }
+func (s summary) Min() float64  { return s.min }
+func (s summary) Max() float64  { return s.max }
+
+type quantileSummary struct {
+	summary
+}
+
+func (s quantileSummary) Quantile(q float64) float64 { return q * 100 }
+
+func TestWriter(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	w := metrics.NewWriter()
+	w.Counter("a_total", "counter A", 1, "face", "1")
+	w.Gauge("b", "gauge\nB", 2.5)
+	w.Counter("a_total", "counter A", 3, "face", `"2"`)
+	w.Summary("c_nanoseconds", "summary C", summary{4, 10, 5, 20}, "type", "data")
+	w.Summary("c_nanoseconds", "summary C", summary{0, math.NaN(), math.NaN(), math.NaN()}, "type", "nack")
+	w.Summary("d_nanoseconds", "summary D", quantileSummary{summary{2, 50, 1, 100}})
+
+	assert.Equal(strings.Join([]string{
+		`# HELP ndndpdk_a_total counter A`,
+		`# TYPE ndndpdk_a_total counter`,
+		`ndndpdk_a_total{face="1"} 1`,
+		`ndndpdk_a_total{face="\"2\""} 3`,
+		`# HELP ndndpdk_b gauge\nB`,
+		`# TYPE ndndpdk_b gauge`,
+		`ndndpdk_b 2.5`,
+		`# HELP ndndpdk_c_nanoseconds summary C`,
+		`# TYPE ndndpdk_c_nanoseconds summary`,
+		`ndndpdk_c_nanoseconds{type="data",quantile="0"} 5`,
+		`ndndpdk_c_nanoseconds{type="data",quantile="1"} 20`,
+		`ndndpdk_c_nanoseconds_sum{type="data"} 40`,
+		`ndndpdk_c_nanoseconds_count{type="data"} 4`,
+		`ndndpdk_c_nanoseconds_sum{type="nack"} 0`,
+		`ndndpdk_c_nanoseconds_count{type="nack"} 0`,
+		`# HELP ndndpdk_d_nanoseconds summary D`,
+		`# TYPE ndndpdk_d_nanoseconds summary`,
+		`ndndpdk_d_nanoseconds{quantile="0"} 1`,
+		`ndndpdk_d_nanoseconds{quantile="0.5"} 50`,
+		`ndndpdk_d_nanoseconds{quantile="0.9"} 90`,
+		`ndndpdk_d_nanoseconds{quantile="0.99"} 99`,
+		`ndndpdk_d_nanoseconds{quantile="0.999"} 99.9`,
+		`ndndpdk_d_nanoseconds{quantile="1"} 100`,
+		`ndndpdk_d_nanoseconds_sum 100`,
+		`ndndpdk_d_nanoseconds_count 2`,
+		``,
+	}, "\n"), string(w.Bytes()))
+}
+
+func TestCollect(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	metrics.AddCollector("test-b", func(w *metrics.Writer) {
+		w.Gauge("x", "X", 2, "src", "b")
+	})
+	metrics.AddCollector("test-a", func(w *metrics.Writer) {
+		w.Gauge("x", "X", 1, "src", "a")
+	})
+	assert.Contains(string(metrics.Collect().Bytes()), "ndndpdk_x{src=\"a\"} 1\nndndpdk_x{src=\"b\"} 2\n")
+
+	metrics.RemoveCollector("test-a")
+	metrics.RemoveCollector("test-b")
+	assert.NotContains(string(metrics.Collect().Bytes()), "ndndpdk_x")
+}
@@ -0,0 +1,226 @@
+package runningstat
+
+/*
+#include "../../csrc/core/quantile-sketch.h"
+*/
+import "C"
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+const (
+	sketchSubBits  = C.QuantileSketchSubBits
+	sketchNBuckets = C.QuantileSketchNBuckets
+)
+
+// QuantileSketch estimates quantiles in constant memory.
+// Inputs are counted in log-linear buckets, so that a quantile estimate has a relative error below 1/32.
+// Inputs are unsigned integers; inputs at or above 2^40 are counted in the last bucket.
+type QuantileSketch C.QuantileSketch
+
+// NewQuantileSketch constructs a new QuantileSketch instance in Go memory.
+func NewQuantileSketch() *QuantileSketch {
+	return new(QuantileSketch)
+}
+
+// QuantileSketchFromPtr converts *C.QuantileSketch to QuantileSketch.
+func QuantileSketchFromPtr(ptr unsafe.Pointer) *QuantileSketch {
+	return (*QuantileSketch)(ptr)
+}
+
+func (s *QuantileSketch) ptr() *C.QuantileSketch {
+	return (*C.QuantileSketch)(s)
+}
+
+// Clear deletes collected data.
+func (s *QuantileSketch) Clear() {
+	C.QuantileSketch_Clear(s.ptr())
+}
+
+// Push adds an input.
+func (s *QuantileSketch) Push(x uint64) {
+	C.QuantileSketch_Push(s.ptr(), C.uint64_t(x))
+}
+
+// Read returns current counters as QuantileSnapshot.
+func (s *QuantileSketch) Read() (o QuantileSnapshot) {
+	c := s.ptr()
+	o.n = uint64(c.n)
+	o.counts = make([]uint64, sketchNBuckets)
+	for i := range o.counts {
+		o.counts[i] = uint64(c.counts[i])
+	}
+	return o
+}
+
+// QuantileSnapshot contains a snapshot of QuantileSketch reading.
+// The zero value is an empty snapshot.
+type QuantileSnapshot struct {
+	n      uint64
+	counts []uint64
+	scale  float64
+}
+
+// Count returns number of inputs.
+func (s QuantileSnapshot) Count() uint64 {
+	return s.n
+}
+
+func (s QuantileSnapshot) getScale() float64 {
+	if s.scale == 0 {
+		return 1
+	}
+	return s.scale
+}
+
+// bucketMid returns the representative value of a bucket.
+func bucketMid(b int) float64 {
+	if b < 1<<sketchSubBits {
+		return float64(b)
+	}
+	k, m := b>>sketchSubBits, b&(1<<sketchSubBits-1)
+	lower := float64(uint64(1<<sketchSubBits+m) << (k - 1))
+	width := float64(uint64(1) << (k - 1))
+	return lower + (width-1)/2
+}
+
+// Quantile returns the q-quantile, 0 <= q <= 1.
+// It returns NaN if the snapshot is empty.
+func (s QuantileSnapshot) Quantile(q float64) float64 {
+	if s.n == 0 || len(s.counts) == 0 {
+		return math.NaN()
+	}
+	rank := uint64(math.Ceil(q * float64(s.n)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var sum uint64
+	b := len(s.counts) - 1
+	for i, count := range s.counts {
+		if sum += count; sum >= rank {
+			b = i
+			break
+		}
+	}
+	return bucketMid(b) * s.getScale()
+}
+
+// Min returns an estimate of minimum value.
+func (s QuantileSnapshot) Min() float64 {
+	return s.Quantile(0)
+}
+
+// Max returns an estimate of maximum value.
+func (s QuantileSnapshot) Max() float64 {
+	return s.Quantile(1)
+}
+
+// Mean returns an estimate of mean value.
+func (s QuantileSnapshot) Mean() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for i, count := range s.counts {
+		sum += bucketMid(i) * float64(count)
+	}
+	return sum / float64(s.n) * s.getScale()
+}
+
+// Add merges another snapshot, such as a snapshot from another thread.
+// Both snapshots should have the same scale.
+func (s QuantileSnapshot) Add(o QuantileSnapshot) (sum QuantileSnapshot) {
+	if s.n == 0 {
+		return o
+	} else if o.n == 0 {
+		return s
+	}
+	sum.n = s.n + o.n
+	sum.scale = s.scale
+	sum.counts = make([]uint64, sketchNBuckets)
+	for i := range sum.counts {
+		if i < len(s.counts) {
+			sum.counts[i] += s.counts[i]
+		}
+		if i < len(o.counts) {
+			sum.counts[i] += o.counts[i]
+		}
+	}
+	return sum
+}
+
+// Sub subtracts counters in another snapshot, such as an earlier snapshot of the same sketch.
+func (s QuantileSnapshot) Sub(o QuantileSnapshot) (diff QuantileSnapshot) {
+	diff.n = s.n - o.n
+	diff.scale = s.scale
+	diff.counts = make([]uint64, sketchNBuckets)
+	for i := range diff.counts {
+		if i < len(s.counts) {
+			diff.counts[i] = s.counts[i]
+		}
+		if i < len(o.counts) {
+			diff.counts[i] -= o.counts[i]
+		}
+	}
+	return diff
+}
+
+// Scale multiplies every quantile by a ratio.
+func (s QuantileSnapshot) Scale(ratio float64) (o QuantileSnapshot) {
+	o = s
+	o.scale = s.getScale() * ratio
+	return o
+}
+
+// QuantileSummary contains quantiles included in JSON representation.
+var QuantileSummary = []float64{0.5, 0.9, 0.99, 0.999}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s QuantileSnapshot) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{})
+	m["count"] = s.n
+	m["scale"] = s.getScale()
+
+	buckets := [][2]uint64{}
+	for i, count := range s.counts {
+		if count > 0 {
+			buckets = append(buckets, [2]uint64{uint64(i), count})
+		}
+	}
+	m["buckets"] = buckets
+
+	if s.n > 0 {
+		quantiles := make(map[string]float64)
+		for _, q := range QuantileSummary {
+			quantiles[strconv.FormatFloat(q, 'f', -1, 64)] = s.Quantile(q)
+		}
+		m["quantiles"] = quantiles
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (s *QuantileSnapshot) UnmarshalJSON(p []byte) (e error) {
+	var m struct {
+		Count   uint64      `json:"count"`
+		Scale   float64     `json:"scale"`
+		Buckets [][2]uint64 `json:"buckets"`
+	}
+	if e = json.Unmarshal(p, &m); e != nil {
+		return e
+	}
+
+	s.n = m.Count
+	s.scale = m.Scale
+	s.counts = make([]uint64, sketchNBuckets)
+	for _, bucket := range m.Buckets {
+		if bucket[0] < uint64(len(s.counts)) {
+			s.counts[bucket[0]] = bucket[1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package runningstat_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/core/runningstat"
+)
+
+func TestQuantileSketch(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	a := runningstat.NewQuantileSketch()
+	b := runningstat.NewQuantileSketch()
+
+	o := a.Read()
+	assert.Equal(uint64(0), o.Count())
+	assert.True(math.IsNaN(o.Quantile(0.5)))
+
+	for x := uint64(1); x <= 10000; x++ {
+		if x%2 == 0 {
+			a.Push(x)
+		} else {
+			b.Push(x)
+		}
+	}
+	a.Push(0)
+	b.Push(1 << 50)
+
+	ar := a.Read()
+	br := b.Read()
+	o = ar.Add(br)
+	assert.Equal(uint64(10002), o.Count())
+	assert.Equal(0.0, o.Quantile(0))
+	assert.InEpsilon(5000, o.Quantile(0.5), 1.0/32)
+	assert.InEpsilon(9900, o.Quantile(0.99), 1.0/32)
+	assert.InEpsilon(math.Exp2(40), o.Quantile(1), 1.0/32)
+	assert.Equal(o.Quantile(0), o.Min())
+	assert.Equal(o.Quantile(1), o.Max())
+
+	small := runningstat.NewQuantileSketch()
+	for x := uint64(0); x < 10; x++ {
+		small.Push(x)
+	}
+	assert.Equal(4.0, small.Read().Quantile(0.5))
+	assert.InDelta(4.5, small.Read().Mean(), 0.01)
+
+	j, e := json.Marshal(o.Sub(ar).Scale(10))
+	assert.NoError(e)
+	var bs runningstat.QuantileSnapshot
+	e = json.Unmarshal(j, &bs)
+	assert.NoError(e)
+	assert.Equal(br.Count(), bs.Count())
+	assert.InDelta(br.Quantile(0.5)*10, bs.Quantile(0.5), 0.1)
+
+	a.Clear()
+	assert.Equal(uint64(0), a.Read().Count())
+}
@@ -1,19 +1,12 @@
 #include "logger.h"
 
-int
-ParseLogLevel(const char* module)
-{
-  char envKey[32];
-  snprintf(envKey, sizeof(envKey), "LOG_%s", module);
-  const char* lvl = getenv(envKey);
-  if (lvl == NULL) {
-    lvl = getenv("LOG");
-  }
-  if (lvl == NULL) {
-    lvl = "";
-  }
+LoggerRegistryEntry gLoggerRegistry[LoggerRegistryCapacity];
+int gLoggerRegistryCount = 0;
 
-  switch (lvl[0]) {
+static int
+ParseLevelLetter(char lvl)
+{
+  switch (lvl) {
     case 'V':
       return ZF_LOG_VERBOSE;
     case 'D':
@@ -29,6 +22,77 @@ ParseLogLevel(const char* module)
     case 'N':
       return ZF_LOG_NONE;
   }
-
   return ZF_LOG_INFO;
 }
+
+int
+ParseLogLevel(const char* module)
+{
+  char envKey[32];
+  snprintf(envKey, sizeof(envKey), "LOG_%s", module);
+  const char* lvl = getenv(envKey);
+  if (lvl == NULL) {
+    lvl = getenv("LOG");
+  }
+  if (lvl == NULL) {
+    lvl = "";
+  }
+
+  return ParseLevelLetter(lvl[0]);
+}
+
+void
+Logger_Register_(const char* module, int* lvl)
+{
+  // RTE_INIT constructors run on the main thread before main(), so no locking is needed.
+  if (gLoggerRegistryCount >= LoggerRegistryCapacity) {
+    return;
+  }
+  LoggerRegistryEntry* entry = &gLoggerRegistry[gLoggerRegistryCount++];
+  entry->module = module;
+  entry->lvl = lvl;
+}
+
+int
+Logger_SetLevel(const char* module, char lvl)
+{
+  int value = ParseLevelLetter(lvl);
+  int nChanged = 0;
+  for (int i = 0; i < gLoggerRegistryCount; ++i) {
+    LoggerRegistryEntry* entry = &gLoggerRegistry[i];
+    if (module != NULL && strcmp(entry->module, module) != 0) {
+      continue;
+    }
+    __atomic_store_n(entry->lvl, value, __ATOMIC_RELAXED);
+    ++nChanged;
+  }
+  return nChanged;
+}
+
+char
+Logger_LevelLetter(int lvl)
+{
+  switch (lvl) {
+    case ZF_LOG_VERBOSE:
+      return 'V';
+    case ZF_LOG_DEBUG:
+      return 'D';
+    case ZF_LOG_INFO:
+      return 'I';
+    case ZF_LOG_WARN:
+      return 'W';
+    case ZF_LOG_ERROR:
+      return 'E';
+    case ZF_LOG_FATAL:
+      return 'F';
+    case ZF_LOG_NONE:
+      return 'N';
+  }
+  return 'I';
+}
+
+char
+Logger_CompiledLevelLetter()
+{
+  return Logger_LevelLetter(_ZF_LOG_LEVEL);
+}
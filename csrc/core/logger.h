@@ -43,10 +43,54 @@
   RTE_INIT(InitLogOutputLvl)                                                                       \
   {                                                                                                \
     gZfLogOutputLvl = ParseLogLevel(#module);                                                      \
+    Logger_Register_(#module, &gZfLogOutputLvl);                                                   \
   }                                                                                                \
   struct AllowTrailingSemicolon_
 
 int
 ParseLogLevel(const char* module);
 
+/** @brief Maximum number of log level variables in the registry. */
+#define LoggerRegistryCapacity 256
+
+/**
+ * @brief Log level variable of a .c file.
+ *
+ * Multiple entries may share the same module name.
+ */
+typedef struct LoggerRegistryEntry
+{
+  const char* module;
+  int* lvl;
+} LoggerRegistryEntry;
+
+extern LoggerRegistryEntry gLoggerRegistry[LoggerRegistryCapacity];
+extern int gLoggerRegistryCount;
+
+/** @brief Record a log level variable, so that it can be changed at runtime. */
+void
+Logger_Register_(const char* module, int* lvl);
+
+/**
+ * @brief Change log level of a module.
+ * @param module module name, or NULL for all modules.
+ * @param lvl log level letter, as accepted in environment variables.
+ * @return number of log level variables changed.
+ */
+int
+Logger_SetLevel(const char* module, char lvl);
+
+/** @brief Convert log level to letter. */
+char
+Logger_LevelLetter(int lvl);
+
+/**
+ * @brief Return compile-time minimum log level as a letter.
+ *
+ * Logging statements below this level are removed at compile time, and cannot be enabled at runtime.
+ * This is INFO in RELEASE builds, and VERBOSE otherwise.
+ */
+char
+Logger_CompiledLevelLetter();
+
 #endif // NDNDPDK_CORE_LOGGER_H
@@ -0,0 +1,63 @@
+#ifndef NDNDPDK_CORE_QUANTILE_SKETCH_H
+#define NDNDPDK_CORE_QUANTILE_SKETCH_H
+
+/** @file */
+
+#include "common.h"
+
+/** @brief log2 of number of sub-buckets within each power of two. */
+#define QuantileSketchSubBits 4
+
+/** @brief Values at or above 2^QuantileSketchMaxBits are counted in the last bucket. */
+#define QuantileSketchMaxBits 40
+
+/** @brief Number of buckets in QuantileSketch. */
+#define QuantileSketchNBuckets                                                                     \
+  ((QuantileSketchMaxBits - QuantileSketchSubBits + 1) << QuantileSketchSubBits)
+
+/**
+ * @brief Constant-memory quantile sketch with log-linear buckets.
+ *
+ * Values below 2^QuantileSketchSubBits are counted exactly.
+ * Larger values are counted in buckets whose width is 1/(2^QuantileSketchSubBits) of the power of
+ * two that contains the value, so that relative error of a quantile estimate is bounded.
+ * A zero-initialized struct is an empty sketch.
+ */
+typedef struct QuantileSketch
+{
+  uint64_t n; ///< count of inputs
+  uint64_t counts[QuantileSketchNBuckets];
+} QuantileSketch;
+
+/** @brief Determine bucket index of a value. */
+static __rte_always_inline uint32_t
+QuantileSketch_BucketOf_(uint64_t x)
+{
+  if (x < (1 << QuantileSketchSubBits)) {
+    return x;
+  }
+  if (unlikely(x >= (UINT64_C(1) << QuantileSketchMaxBits))) {
+    return QuantileSketchNBuckets - 1;
+  }
+  int e = 63 - __builtin_clzll(x);
+  int shift = e - QuantileSketchSubBits;
+  return ((uint32_t)(shift + 1) << QuantileSketchSubBits) |
+         (uint32_t)((x >> shift) & ((1 << QuantileSketchSubBits) - 1));
+}
+
+/** @brief Clear @p s. */
+static inline void
+QuantileSketch_Clear(QuantileSketch* s)
+{
+  memset(s, 0, sizeof(*s));
+}
+
+/** @brief Add an input. */
+static __rte_always_inline void
+QuantileSketch_Push(QuantileSketch* s, uint64_t x)
+{
+  ++s->n;
+  ++s->counts[QuantileSketch_BucketOf_(x)];
+}
+
+#endif // NDNDPDK_CORE_QUANTILE_SKETCH_H
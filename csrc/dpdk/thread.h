@@ -31,4 +31,24 @@ ThreadStopFlag_FinishStop(ThreadStopFlag* flag)
   atomic_store_explicit(flag, true, memory_order_release);
 }
 
+/**
+ * @brief Thread load statistics.
+ *
+ * A polling thread reports the number of processed items after each poll. The fraction of empty
+ * polls indicates how idle the thread is.
+ */
+typedef struct ThreadLoadStat
+{
+  uint64_t nPolls[2]; ///< number of empty and valid polls
+  uint64_t nItems;    ///< number of processed items in valid polls
+} ThreadLoadStat;
+
+/** @brief Report the number of processed items in a poll. */
+static __rte_always_inline void
+ThreadLoadStat_Report(ThreadLoadStat* s, uint64_t count)
+{
+  ++s->nPolls[(int)(count > 0)];
+  s->nItems += count;
+}
+
 #endif // NDNDPDK_DPDK_THREAD_H
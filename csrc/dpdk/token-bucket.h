@@ -0,0 +1,84 @@
+#ifndef NDNDPDK_DPDK_TOKEN_BUCKET_H
+#define NDNDPDK_DPDK_TOKEN_BUCKET_H
+
+/** @file */
+
+#include "tsc.h"
+#include <rte_spinlock.h>
+
+/**
+ * @brief Token bucket rate limiter.
+ *
+ * Token quantities are stored in units of 1/tscHz token, so that the bucket gains @c rate units
+ * in every TSC cycle. This is safe for concurrent use by multiple threads.
+ */
+typedef struct TokenBucket
+{
+  rte_spinlock_t lock;
+  TscTime last;      ///< last refill time
+  uint64_t tokens;   ///< available tokens
+  uint64_t capacity; ///< maximum tokens
+  uint64_t rate;     ///< tokens per second
+  uint64_t cost;     ///< units of one token, equal to tscHz
+} TokenBucket;
+
+/**
+ * @brief Initialize a token bucket.
+ * @param rate refill rate in tokens per second, must be positive.
+ * @param burst bucket capacity in tokens, must be positive.
+ * @post The bucket is full.
+ */
+__attribute__((nonnull)) static inline void
+TokenBucket_Init(TokenBucket* tb, uint64_t rate, uint64_t burst)
+{
+  NDNDPDK_ASSERT(rate > 0 && burst > 0);
+  rte_spinlock_init(&tb->lock);
+  tb->last = rte_get_tsc_cycles();
+  tb->cost = rte_get_tsc_hz();
+  tb->capacity = burst * tb->cost;
+  tb->tokens = tb->capacity;
+  tb->rate = rate;
+}
+
+/**
+ * @brief Take one token.
+ * @param now current time; it is tolerated to go backwards slightly.
+ * @return whether a token was available.
+ */
+__attribute__((nonnull)) static inline bool
+TokenBucket_Take(TokenBucket* tb, TscTime now)
+{
+  rte_spinlock_lock(&tb->lock);
+  if (now > tb->last) {
+    uint64_t elapsed = now - tb->last;
+    uint64_t room = tb->capacity - tb->tokens;
+    if (elapsed >= room / tb->rate) {
+      tb->tokens = tb->capacity;
+    } else {
+      tb->tokens += elapsed * tb->rate;
+    }
+    tb->last = now;
+  }
+
+  bool ok = tb->tokens >= tb->cost;
+  if (likely(ok)) {
+    tb->tokens -= tb->cost;
+  }
+  rte_spinlock_unlock(&tb->lock);
+  return ok;
+}
+
+/**
+ * @brief Return one token previously taken with TokenBucket_Take.
+ *
+ * The bucket is not filled beyond its capacity.
+ */
+__attribute__((nonnull)) static inline void
+TokenBucket_Refund(TokenBucket* tb)
+{
+  rte_spinlock_lock(&tb->lock);
+  tb->tokens = RTE_MIN(tb->tokens + tb->cost, tb->capacity);
+  rte_spinlock_unlock(&tb->lock);
+}
+
+#endif // NDNDPDK_DPDK_TOKEN_BUCKET_H
@@ -1,83 +1,369 @@
 #include "eth-face.h"
 #include "../core/logger.h"
 
-uint8_t
-EthFaceEtherHdr_Init(EthFaceEtherHdr* hdr, const struct rte_ether_addr* local,
-                     const struct rte_ether_addr* remote, uint16_t vlan)
+#include <rte_hash_crc.h>
+
+INIT_ZF_LOG(EthFace);
+
+// EthFace currently only supports one TX queue,
+// so queue number is hardcoded with this macro.
+#define TX_QUEUE_0 0
+
+void
+EthFaceTxHdr_Init(EthFaceTxHdr* hdr, const EthFaceAddr* addr, bool txChecksumOffload)
 {
-  hdr->eth.ether_type = rte_cpu_to_be_16(NDN_ETHERTYPE);
-  hdr->vlan.eth_proto = rte_cpu_to_be_16(NDN_ETHERTYPE);
+  memset(hdr, 0, sizeof(*hdr));
+  uint16_t etherType = NDN_ETHERTYPE;
+  switch (addr->ipVersion) {
+    case 4:
+      etherType = RTE_ETHER_TYPE_IPV4;
+      break;
+    case 6:
+      etherType = RTE_ETHER_TYPE_IPV6;
+      break;
+  }
+
+  struct rte_ether_hdr* eth = (struct rte_ether_hdr*)hdr->buf;
+  rte_ether_addr_copy(&addr->remote, &eth->d_addr);
+  rte_ether_addr_copy(&addr->local, &eth->s_addr);
+  hdr->l2Len = RTE_ETHER_HDR_LEN;
+  if (addr->vlan == 0) {
+    eth->ether_type = rte_cpu_to_be_16(etherType);
+  } else {
+    eth->ether_type = rte_cpu_to_be_16(RTE_ETHER_TYPE_VLAN);
+    struct rte_vlan_hdr* vlan = RTE_PTR_ADD(hdr->buf, hdr->l2Len);
+    vlan->vlan_tci = rte_cpu_to_be_16(addr->vlan);
+    vlan->eth_proto = rte_cpu_to_be_16(etherType);
+    hdr->l2Len += sizeof(*vlan);
+  }
+
+  hdr->ipVersion = addr->ipVersion;
+  void* l3 = RTE_PTR_ADD(hdr->buf, hdr->l2Len);
+  switch (addr->ipVersion) {
+    case 4: {
+      struct rte_ipv4_hdr* ip = l3;
+      ip->version_ihl = 0x45; // IPv4, 20-octet header
+      ip->fragment_offset = rte_cpu_to_be_16(RTE_IPV4_HDR_DF_FLAG);
+      ip->time_to_live = 64;
+      ip->next_proto_id = IPPROTO_UDP;
+      rte_memcpy(&ip->src_addr, addr->localIP, sizeof(ip->src_addr));
+      rte_memcpy(&ip->dst_addr, addr->remoteIP, sizeof(ip->dst_addr));
+      hdr->l3Len = sizeof(*ip);
+      if (txChecksumOffload) {
+        hdr->olFlags = PKT_TX_IPV4 | PKT_TX_IP_CKSUM | PKT_TX_UDP_CKSUM;
+      }
+      break;
+    }
+    case 6: {
+      struct rte_ipv6_hdr* ip = l3;
+      ip->vtc_flow = rte_cpu_to_be_32(6 << 28);
+      ip->proto = IPPROTO_UDP;
+      ip->hop_limits = 64;
+      rte_memcpy(ip->src_addr, addr->localIP, sizeof(ip->src_addr));
+      rte_memcpy(ip->dst_addr, addr->remoteIP, sizeof(ip->dst_addr));
+      hdr->l3Len = sizeof(*ip);
+      if (txChecksumOffload) {
+        hdr->olFlags = PKT_TX_IPV6 | PKT_TX_UDP_CKSUM;
+      }
+      break;
+    }
+    default:
+      hdr->len = hdr->l2Len;
+      return;
+  }
 
-  rte_ether_addr_copy(remote, &hdr->eth.d_addr);
-  rte_ether_addr_copy(local, &hdr->eth.s_addr);
-  if (vlan == 0) {
-    return offsetof(EthFaceEtherHdr, vlan);
+  struct rte_udp_hdr* udp = RTE_PTR_ADD(l3, hdr->l3Len);
+  udp->src_port = rte_cpu_to_be_16(addr->localUDP);
+  udp->dst_port = rte_cpu_to_be_16(addr->remoteUDP);
+  hdr->len = hdr->l2Len + hdr->l3Len + sizeof(*udp);
+  if (!addr->vxlan) {
+    return;
   }
 
-  hdr->vlan.vlan_tci = rte_cpu_to_be_16(vlan);
-  hdr->eth.ether_type = rte_cpu_to_be_16(RTE_ETHER_TYPE_VLAN);
-  return sizeof(EthFaceEtherHdr);
+  struct rte_vxlan_hdr* vxlan = RTE_PTR_ADD(hdr->buf, hdr->len);
+  vxlan->vx_flags = rte_cpu_to_be_32(0x08000000); // I flag: VNI is valid
+  vxlan->vx_vni = rte_cpu_to_be_32(addr->vni << 8);
+  struct rte_ether_hdr* inner = RTE_PTR_ADD(vxlan, sizeof(*vxlan));
+  rte_ether_addr_copy(&addr->innerRemote, &inner->d_addr);
+  rte_ether_addr_copy(&addr->innerLocal, &inner->s_addr);
+  inner->ether_type = rte_cpu_to_be_16(NDN_ETHERTYPE);
+  hdr->len += EthFaceVxlanHdrLen;
+
+  // RFC 7348 recommends deriving outer source port from a hash of inner frame headers, within the
+  // dynamic port range, so that ECMP can distinguish flows. Inner headers are fixed for each face.
+  uint32_t hash = rte_hash_crc(inner, RTE_ETHER_HDR_LEN, addr->vni);
+  udp->src_port = rte_cpu_to_be_16(49152 + hash % 16384);
 }
 
-INIT_ZF_LOG(EthFace);
+/** @brief Fill length and checksum fields in IP and UDP headers. */
+__attribute__((nonnull)) static __rte_always_inline void
+EthFace_TxUdp(const EthFaceTxHdr* hdr, struct rte_mbuf* pkt, void* room)
+{
+  void* l3 = RTE_PTR_ADD(room, hdr->l2Len);
+  struct rte_udp_hdr* udp = RTE_PTR_ADD(l3, hdr->l3Len);
+  uint16_t udpLen = pkt->pkt_len - hdr->l2Len - hdr->l3Len;
+  udp->dgram_len = rte_cpu_to_be_16(udpLen);
+  pkt->l2_len = hdr->l2Len;
+  pkt->l3_len = hdr->l3Len;
+  pkt->ol_flags |= hdr->olFlags;
 
-// EthFace currently only supports one TX queue,
-// so queue number is hardcoded with this macro.
-#define TX_QUEUE_0 0
+  if (hdr->ipVersion == 4) {
+    struct rte_ipv4_hdr* ip = l3;
+    ip->total_length = rte_cpu_to_be_16(hdr->l3Len + udpLen);
+    if (hdr->olFlags != 0) {
+      udp->dgram_cksum = rte_ipv4_phdr_cksum(ip, pkt->ol_flags);
+    } else {
+      ip->hdr_checksum = rte_ipv4_cksum(ip);
+      // UDP checksum is optional in IPv4
+    }
+    return;
+  }
+
+  struct rte_ipv6_hdr* ip = l3;
+  ip->payload_len = rte_cpu_to_be_16(udpLen);
+  if (hdr->olFlags != 0) {
+    udp->dgram_cksum = rte_ipv6_phdr_cksum(ip, pkt->ol_flags);
+    return;
+  }
+
+  // UDP checksum is mandatory in IPv6, compute in software over segmented mbuf
+  uint16_t raw = 0;
+  rte_raw_cksum_mbuf(pkt, hdr->l2Len + hdr->l3Len, udpLen, &raw);
+  uint32_t sum = (uint32_t)raw + rte_ipv6_phdr_cksum(ip, 0);
+  sum = (sum & 0xFFFF) + (sum >> 16);
+  uint16_t cksum = (~sum) & 0xFFFF;
+  udp->dgram_cksum = cksum == 0 ? 0xFFFF : cksum;
+}
 
 uint16_t
 EthFace_TxBurst(Face* face, struct rte_mbuf** pkts, uint16_t nPkts)
 {
   EthFacePriv* priv = Face_GetPrivT(face, EthFacePriv);
+  const EthFaceTxHdr* hdr = &priv->txHdr;
+
+  uint64_t learnedRemote = 0;
+  if (unlikely(priv->learnRemote)) {
+    learnedRemote = __atomic_load_n(&priv->learnedRemote, __ATOMIC_ACQUIRE);
+    if (unlikely(learnedRemote == 0)) { // remote MAC address not yet learned
+      return 0;
+    }
+  }
 
   for (uint16_t i = 0; i < nPkts; ++i) {
-    char* room = rte_pktmbuf_prepend(pkts[i], priv->txHdrLen);
+    char* room = rte_pktmbuf_prepend(pkts[i], hdr->len);
     NDNDPDK_ASSERT(room != NULL); // enough headroom is required
-    rte_memcpy(room, &priv->txHdr, priv->txHdrLen);
+    rte_memcpy(room, hdr->buf, hdr->len);
+    if (learnedRemote != 0) {
+      rte_memcpy(room, &learnedRemote, RTE_ETHER_ADDR_LEN);
+    }
+    if (hdr->l3Len > 0) {
+      EthFace_TxUdp(hdr, pkts[i], room);
+    }
   }
   return rte_eth_tx_burst(priv->port, TX_QUEUE_0, pkts, nPkts);
 }
 
+bool
+EthFaceRxUdp_Parse(EthFaceRxUdp* rx, const struct rte_mbuf* frame, rte_be16_t etherType,
+                   uint16_t l2Len)
+{
+  uint16_t l3Len = 0;
+  if (etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_IPV4)) {
+    if (unlikely(frame->data_len < l2Len + sizeof(struct rte_ipv4_hdr) + sizeof(struct rte_udp_hdr))) {
+      return false;
+    }
+    const struct rte_ipv4_hdr* ip = rte_pktmbuf_mtod_offset(frame, const struct rte_ipv4_hdr*, l2Len);
+    l3Len = (ip->version_ihl & RTE_IPV4_HDR_IHL_MASK) * RTE_IPV4_IHL_MULTIPLIER;
+    if (ip->next_proto_id != IPPROTO_UDP || l3Len < sizeof(*ip) ||
+        (ip->fragment_offset & rte_cpu_to_be_16(RTE_IPV4_HDR_MF_FLAG | RTE_IPV4_HDR_OFFSET_MASK)) !=
+          0 ||
+        frame->data_len < l2Len + l3Len + sizeof(struct rte_udp_hdr)) {
+      return false;
+    }
+    rx->ipVersion = 4;
+    rx->srcIP = (const uint8_t*)&ip->src_addr;
+    rx->dstIP = (const uint8_t*)&ip->dst_addr;
+  } else if (etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_IPV6)) {
+    if (unlikely(frame->data_len < l2Len + sizeof(struct rte_ipv6_hdr) + sizeof(struct rte_udp_hdr))) {
+      return false;
+    }
+    const struct rte_ipv6_hdr* ip = rte_pktmbuf_mtod_offset(frame, const struct rte_ipv6_hdr*, l2Len);
+    if (ip->proto != IPPROTO_UDP) { // IPv6 extension headers are unsupported
+      return false;
+    }
+    l3Len = sizeof(*ip);
+    rx->ipVersion = 6;
+    rx->srcIP = ip->src_addr;
+    rx->dstIP = ip->dst_addr;
+  } else {
+    return false;
+  }
+
+  rx->udp = rte_pktmbuf_mtod_offset(frame, const struct rte_udp_hdr*, l2Len + l3Len);
+  rx->hdrLen = l2Len + l3Len + sizeof(struct rte_udp_hdr);
+  rx->dataLen = frame->data_len;
+  uint16_t udpLen = rte_be_to_cpu_16(rx->udp->dgram_len);
+  return udpLen >= sizeof(struct rte_udp_hdr) && l2Len + l3Len + udpLen <= frame->pkt_len;
+}
+
+bool
+EthFace_MatchVxlan(const EthFaceAddr* addr, const EthFaceRxUdp* rx)
+{
+  const struct rte_vxlan_hdr* vxlan = EthFaceRxUdp_GetVxlan(rx);
+  if (vxlan == NULL || vxlan->vx_vni != rte_cpu_to_be_32(addr->vni << 8)) {
+    return false;
+  }
+
+  const struct rte_ether_hdr* inner = RTE_PTR_ADD(vxlan, sizeof(*vxlan));
+  if (inner->ether_type != rte_cpu_to_be_16(NDN_ETHERTYPE)) {
+    return false;
+  }
+  if (rte_is_multicast_ether_addr(&addr->innerRemote)) {
+    return rte_is_same_ether_addr(&inner->d_addr, &addr->innerRemote);
+  }
+  return rte_is_same_ether_addr(&inner->d_addr, &addr->innerLocal) &&
+         rte_is_same_ether_addr(&inner->s_addr, &addr->innerRemote);
+}
+
+void
+EthFace_AcceptUdp(EthFacePriv* priv, struct rte_mbuf* frame, const EthFaceRxUdp* rx)
+{
+  if (unlikely(priv->learnRemote)) {
+    const struct rte_ether_hdr* eth = rte_pktmbuf_mtod(frame, const struct rte_ether_hdr*);
+    uint64_t remote = 0;
+    rte_memcpy(&remote, &eth->s_addr, RTE_ETHER_ADDR_LEN);
+    if (unlikely(remote != __atomic_load_n(&priv->learnedRemote, __ATOMIC_RELAXED))) {
+      char addrStr[RTE_ETHER_ADDR_FMT_SIZE];
+      rte_ether_format_addr(addrStr, sizeof(addrStr), &eth->s_addr);
+      ZF_LOGI("%" PRI_FaceID " learn-remote=%s", priv->faceID, addrStr);
+      __atomic_store_n(&priv->learnedRemote, remote, __ATOMIC_RELEASE);
+    }
+  }
+
+  uint32_t frameLen = rx->hdrLen - sizeof(struct rte_udp_hdr) + rte_be_to_cpu_16(rx->udp->dgram_len);
+  if (frame->pkt_len > frameLen) { // remove Ethernet padding
+    rte_pktmbuf_trim(frame, frame->pkt_len - frameLen);
+  }
+  rte_pktmbuf_adj(frame, rx->hdrLen + (priv->addr.vxlan ? EthFaceVxlanHdrLen : 0));
+}
+
 struct rte_flow*
 EthFace_SetupFlow(EthFacePriv* priv, struct rte_flow_error* error)
 {
-  const EthFaceEtherHdr* hdr = &priv->txHdr;
+  const EthFaceAddr* addr = &priv->addr;
+  const struct rte_ether_hdr* eth = (const struct rte_ether_hdr*)priv->txHdr.buf;
+  const struct rte_vlan_hdr* vlan = RTE_PTR_ADD(eth, RTE_ETHER_HDR_LEN);
   struct rte_flow_attr attr = {
     .group = 0,
     .priority = 1,
     .ingress = true,
   };
 
+  struct rte_flow_item pattern[7];
+  size_t nItems = 0;
+
   struct rte_flow_item_eth ethMask;
   memset(&ethMask, 0xFF, sizeof(ethMask));
-  struct rte_flow_item_eth ethSpec = { .type = hdr->eth.ether_type };
-  if (rte_is_multicast_ether_addr(&hdr->eth.d_addr)) {
-    rte_ether_addr_copy(&hdr->eth.d_addr, &ethSpec.dst);
+  struct rte_flow_item_eth ethSpec = { .type = eth->ether_type };
+  if (addr->ipVersion == 0 && rte_is_multicast_ether_addr(&addr->remote)) {
+    rte_ether_addr_copy(&addr->remote, &ethSpec.dst);
     memset(&ethMask.src, 0x00, sizeof(ethMask.src));
   } else { // unicast
-    rte_ether_addr_copy(&hdr->eth.s_addr, &ethSpec.dst);
-    rte_ether_addr_copy(&hdr->eth.d_addr, &ethSpec.src);
+    rte_ether_addr_copy(&addr->local, &ethSpec.dst);
+    rte_ether_addr_copy(&addr->remote, &ethSpec.src);
+    if (priv->learnRemote) {
+      memset(&ethMask.src, 0x00, sizeof(ethMask.src));
+    }
   }
-  struct rte_flow_item_vlan vlanMask = { .tci = rte_cpu_to_be_16(0x0FFF), .inner_type = 0xFFFF };
-  struct rte_flow_item_vlan vlanSpec = { .tci = hdr->vlan.vlan_tci,
-                                         .inner_type = hdr->vlan.eth_proto };
+  pattern[nItems++] = (struct rte_flow_item){
+    .type = RTE_FLOW_ITEM_TYPE_ETH,
+    .mask = &ethMask,
+    .spec = &ethSpec,
+  };
 
-  struct rte_flow_item pattern[3] = {
-    {
-      .type = RTE_FLOW_ITEM_TYPE_ETH,
-      .mask = &ethMask,
-      .spec = &ethSpec,
-    },
-    {
-      .type = priv->txHdrLen > offsetof(EthFaceEtherHdr, vlan) ? RTE_FLOW_ITEM_TYPE_VLAN
-                                                               : RTE_FLOW_ITEM_TYPE_END,
+  struct rte_flow_item_vlan vlanMask = { .tci = rte_cpu_to_be_16(0x0FFF), .inner_type = 0xFFFF };
+  struct rte_flow_item_vlan vlanSpec = { .tci = vlan->vlan_tci, .inner_type = vlan->eth_proto };
+  if (addr->vlan != 0) {
+    pattern[nItems++] = (struct rte_flow_item){
+      .type = RTE_FLOW_ITEM_TYPE_VLAN,
       .mask = &vlanMask,
       .spec = &vlanSpec,
-    },
-    {
-      .type = RTE_FLOW_ITEM_TYPE_END,
-    },
+    };
+  }
+
+  struct rte_flow_item_ipv4 ip4Spec = { 0 };
+  struct rte_flow_item_ipv6 ip6Spec = { 0 };
+  switch (addr->ipVersion) {
+    case 4:
+      rte_memcpy(&ip4Spec.hdr.src_addr, addr->remoteIP, sizeof(ip4Spec.hdr.src_addr));
+      rte_memcpy(&ip4Spec.hdr.dst_addr, addr->localIP, sizeof(ip4Spec.hdr.dst_addr));
+      pattern[nItems++] = (struct rte_flow_item){
+        .type = RTE_FLOW_ITEM_TYPE_IPV4,
+        .mask = &rte_flow_item_ipv4_mask,
+        .spec = &ip4Spec,
+      };
+      break;
+    case 6:
+      rte_memcpy(ip6Spec.hdr.src_addr, addr->remoteIP, sizeof(ip6Spec.hdr.src_addr));
+      rte_memcpy(ip6Spec.hdr.dst_addr, addr->localIP, sizeof(ip6Spec.hdr.dst_addr));
+      pattern[nItems++] = (struct rte_flow_item){
+        .type = RTE_FLOW_ITEM_TYPE_IPV6,
+        .mask = &rte_flow_item_ipv6_mask,
+        .spec = &ip6Spec,
+      };
+      break;
+  }
+
+  struct rte_flow_item_udp udpMask = rte_flow_item_udp_mask;
+  struct rte_flow_item_udp udpSpec = {
+    .hdr =
+      {
+        .src_port = rte_cpu_to_be_16(addr->remoteUDP),
+        .dst_port = rte_cpu_to_be_16(addr->localUDP),
+      },
   };
+  if (addr->vxlan) { // VXLAN source port is flow entropy
+    udpMask.hdr.src_port = 0;
+    udpSpec.hdr.src_port = 0;
+  }
+  if (addr->ipVersion != 0) {
+    pattern[nItems++] = (struct rte_flow_item){
+      .type = RTE_FLOW_ITEM_TYPE_UDP,
+      .mask = &udpMask,
+      .spec = &udpSpec,
+    };
+  }
+
+  struct rte_flow_item_vxlan vxlanSpec = {
+    .vni = { (uint8_t)(addr->vni >> 16), (uint8_t)(addr->vni >> 8), (uint8_t)addr->vni },
+  };
+  struct rte_flow_item_eth innerMask;
+  memset(&innerMask, 0xFF, sizeof(innerMask));
+  struct rte_flow_item_eth innerSpec = { .type = rte_cpu_to_be_16(NDN_ETHERTYPE) };
+  if (rte_is_multicast_ether_addr(&addr->innerRemote)) {
+    rte_ether_addr_copy(&addr->innerRemote, &innerSpec.dst);
+    memset(&innerMask.src, 0x00, sizeof(innerMask.src));
+  } else {
+    rte_ether_addr_copy(&addr->innerLocal, &innerSpec.dst);
+    rte_ether_addr_copy(&addr->innerRemote, &innerSpec.src);
+  }
+  if (addr->vxlan) {
+    pattern[nItems++] = (struct rte_flow_item){
+      .type = RTE_FLOW_ITEM_TYPE_VXLAN,
+      .mask = &rte_flow_item_vxlan_mask,
+      .spec = &vxlanSpec,
+    };
+    pattern[nItems++] = (struct rte_flow_item){
+      .type = RTE_FLOW_ITEM_TYPE_ETH,
+      .mask = &innerMask,
+      .spec = &innerSpec,
+    };
+  }
+
+  pattern[nItems++] = (struct rte_flow_item){
+    .type = RTE_FLOW_ITEM_TYPE_END,
+  };
+  NDNDPDK_ASSERT(nItems <= RTE_DIM(pattern));
 
   struct rte_flow_action_queue queue = { .index = priv->rxQueue };
 
@@ -95,14 +381,35 @@ uint16_t
 EthFace_FlowRxBurst(RxGroup* flowRxg, struct rte_mbuf** pkts, uint16_t nPkts)
 {
   EthFacePriv* priv = container_of(flowRxg, EthFacePriv, flowRxg);
-  uint16_t nRx = rte_eth_rx_burst(priv->port, priv->rxQueue, pkts, nPkts);
+  uint16_t nInput = rte_eth_rx_burst(priv->port, priv->rxQueue, pkts, nPkts);
   uint64_t now = rte_get_tsc_cycles();
-  for (uint16_t i = 0; i < nRx; ++i) {
+
+  if (priv->addr.ipVersion == 0) {
+    for (uint16_t i = 0; i < nInput; ++i) {
+      struct rte_mbuf* frame = pkts[i];
+      frame->port = priv->faceID;
+      // TODO offload timestamping to hardware where available
+      frame->timestamp = now;
+      rte_pktmbuf_adj(frame, priv->txHdr.len);
+    }
+    return nInput;
+  }
+
+  const struct rte_ether_hdr* eth = (const struct rte_ether_hdr*)priv->txHdr.buf;
+  const struct rte_vlan_hdr* vlan = RTE_PTR_ADD(eth, RTE_ETHER_HDR_LEN);
+  rte_be16_t etherType = priv->addr.vlan == 0 ? eth->ether_type : vlan->eth_proto;
+  uint16_t nRx = 0;
+  for (uint16_t i = 0; i < nInput; ++i) {
     struct rte_mbuf* frame = pkts[i];
+    EthFaceRxUdp rx;
+    if (unlikely(!EthFaceRxUdp_Parse(&rx, frame, etherType, priv->txHdr.l2Len))) {
+      rte_pktmbuf_free(frame);
+      continue;
+    }
+    EthFace_AcceptUdp(priv, frame, &rx);
     frame->port = priv->faceID;
-    // TODO offload timestamping to hardware where available
     frame->timestamp = now;
-    rte_pktmbuf_adj(frame, priv->txHdrLen);
+    pkts[nRx++] = frame;
   }
   return nRx;
 }
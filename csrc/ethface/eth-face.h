@@ -7,8 +7,11 @@
 #include "../iface/face.h"
 #include "../iface/rxloop.h"
 #include <rte_flow.h>
+#include <rte_ip.h>
+#include <rte_udp.h>
 
 #define NDN_ETHERTYPE 0x8624
+#define NDN_UDP_PORT 6363
 
 typedef struct EthFaceEtherHdr
 {
@@ -16,9 +19,57 @@ typedef struct EthFaceEtherHdr
   struct rte_vlan_hdr vlan;
 } __rte_packed __rte_aligned(2) EthFaceEtherHdr;
 
-uint8_t
-EthFaceEtherHdr_Init(EthFaceEtherHdr* hdr, const struct rte_ether_addr* local,
-                     const struct rte_ether_addr* remote, uint16_t vlan);
+enum
+{
+  /// VXLAN header and inner Ethernet header length
+  EthFaceVxlanHdrLen = sizeof(struct rte_vxlan_hdr) + RTE_ETHER_HDR_LEN,
+
+  /// maximum header length: Ethernet, VLAN, IPv6, UDP, VXLAN, inner Ethernet
+  EthFaceMaxHdrLen = sizeof(EthFaceEtherHdr) + sizeof(struct rte_ipv6_hdr) +
+                     sizeof(struct rte_udp_hdr) + EthFaceVxlanHdrLen,
+};
+
+/** @brief Addresses of an Ethernet face. */
+typedef struct EthFaceAddr
+{
+  struct rte_ether_addr local;
+  struct rte_ether_addr remote;
+  uint16_t vlan;        ///< VLAN ID, 0 if none
+  uint8_t ipVersion;    ///< 4 or 6 for NDN over UDP, 0 for NDN over Ethernet
+  uint8_t localIP[16];  ///< local IP address; IPv4 address occupies first 4 octets
+  uint8_t remoteIP[16]; ///< remote IP address; IPv4 address occupies first 4 octets
+  uint16_t localUDP;    ///< local UDP port
+  uint16_t remoteUDP;   ///< remote UDP port
+  bool vxlan;           ///< whether to use VXLAN encapsulation over UDP
+  uint32_t vni;         ///< VXLAN Network Identifier
+  struct rte_ether_addr innerLocal;  ///< VXLAN inner local address
+  struct rte_ether_addr innerRemote; ///< VXLAN inner remote address
+} EthFaceAddr;
+
+/** @brief Return last octet of remote IP address. */
+__attribute__((nonnull)) static inline uint8_t
+EthFaceAddr_RemoteIPLastOctet(const EthFaceAddr* addr)
+{
+  return addr->remoteIP[addr->ipVersion == 4 ? 3 : 15];
+}
+
+/** @brief Prepared headers of outgoing frames. */
+typedef struct EthFaceTxHdr
+{
+  uint64_t olFlags; ///< mbuf TX offload flags
+  uint8_t len;      ///< total header length
+  uint8_t l2Len;    ///< Ethernet and VLAN header length
+  uint8_t l3Len;    ///< IP header length, 0 for NDN over Ethernet
+  uint8_t ipVersion;
+  uint8_t buf[EthFaceMaxHdrLen];
+} EthFaceTxHdr;
+
+/**
+ * @brief Prepare headers of outgoing frames.
+ * @param txChecksumOffload whether the EthDev has enabled IPv4 and UDP checksum offloads.
+ */
+__attribute__((nonnull)) void
+EthFaceTxHdr_Init(EthFaceTxHdr* hdr, const EthFaceAddr* addr, bool txChecksumOffload);
 
 /**
  * @brief Ethernet face private data.
@@ -28,13 +79,86 @@ EthFaceEtherHdr_Init(EthFaceEtherHdr* hdr, const struct rte_ether_addr* local,
 typedef struct EthFacePriv
 {
   RxGroup flowRxg;
-  EthFaceEtherHdr txHdr;
+  EthFaceAddr addr;
+  EthFaceTxHdr txHdr;
   uint16_t port;
   uint16_t rxQueue;
   FaceID faceID;
-  uint8_t txHdrLen;
+  bool learnRemote; ///< whether to learn remote MAC address from incoming frames
+  /**
+   * @brief Learned remote MAC address in the first 6 octets, or 0 if not yet learned.
+   *
+   * This is written by the RX thread and read by the TX thread, both via atomic operations.
+   * The destination address in @c txHdr is not modified after initialization.
+   */
+  uint64_t learnedRemote;
 } EthFacePriv;
 
+/** @brief Locations of IP and UDP headers in an incoming frame. */
+typedef struct EthFaceRxUdp
+{
+  const uint8_t* srcIP;
+  const uint8_t* dstIP;
+  const struct rte_udp_hdr* udp;
+  uint16_t hdrLen;  ///< total header length including UDP header
+  uint16_t dataLen; ///< length of first segment
+  uint8_t ipVersion;
+} EthFaceRxUdp;
+
+/**
+ * @brief Locate IP and UDP headers in an incoming frame.
+ * @param etherType EtherType after VLAN header, in network byte order.
+ * @param l2Len Ethernet and VLAN header length.
+ * @return whether the frame contains a UDP datagram that is not an IP fragment.
+ */
+__attribute__((nonnull)) bool
+EthFaceRxUdp_Parse(EthFaceRxUdp* rx, const struct rte_mbuf* frame, rte_be16_t etherType,
+                   uint16_t l2Len);
+
+/**
+ * @brief Locate VXLAN header in an incoming UDP datagram.
+ * @return VXLAN header, or NULL if the datagram is too short.
+ */
+__attribute__((nonnull)) static inline const struct rte_vxlan_hdr*
+EthFaceRxUdp_GetVxlan(const EthFaceRxUdp* rx)
+{
+  if (unlikely(rx->dataLen < rx->hdrLen + EthFaceVxlanHdrLen)) {
+    return NULL;
+  }
+  return RTE_PTR_ADD(rx->udp, sizeof(struct rte_udp_hdr));
+}
+
+/** @brief Determine whether VXLAN header and inner Ethernet header match face addresses. */
+__attribute__((nonnull)) bool
+EthFace_MatchVxlan(const EthFaceAddr* addr, const EthFaceRxUdp* rx);
+
+/** @brief Determine whether an incoming UDP datagram matches face addresses. */
+__attribute__((nonnull)) static inline bool
+EthFace_MatchUdp(const EthFacePriv* priv, const EthFaceRxUdp* rx)
+{
+  const EthFaceAddr* addr = &priv->addr;
+  size_t ipLen = addr->ipVersion == 4 ? 4 : 16;
+  if (!(rx->ipVersion == addr->ipVersion &&
+        rx->udp->dst_port == rte_cpu_to_be_16(addr->localUDP) &&
+        memcmp(rx->srcIP, addr->remoteIP, ipLen) == 0 &&
+        memcmp(rx->dstIP, addr->localIP, ipLen) == 0)) {
+    return false;
+  }
+  if (addr->vxlan) { // VXLAN source port is flow entropy and does not identify the peer
+    return EthFace_MatchVxlan(addr, rx);
+  }
+  return rx->udp->src_port == rte_cpu_to_be_16(addr->remoteUDP);
+}
+
+/**
+ * @brief Accept an incoming UDP datagram that matches face addresses.
+ *
+ * This learns remote MAC address if requested, and then removes Ethernet, VLAN, IP, UDP headers,
+ * VXLAN and inner Ethernet headers if applicable, and Ethernet padding.
+ */
+__attribute__((nonnull)) void
+EthFace_AcceptUdp(EthFacePriv* priv, struct rte_mbuf* frame, const EthFaceRxUdp* rx);
+
 uint16_t
 EthFace_TxBurst(Face* face, struct rte_mbuf** pkts, uint16_t nPkts);
 
@@ -1,24 +1,72 @@
 #include "rxtable.h"
 #include "eth-face.h"
 
+static __rte_always_inline EthFacePriv*
+EthRxTable_MatchUdp(_Atomic FaceID* slot, const EthFaceRxUdp* rx)
+{
+  FaceID faceID = atomic_load_explicit(slot, memory_order_relaxed);
+  Face* face = Face_Get(faceID);
+  if (unlikely(face->impl == NULL || face->txBurstOp != EthFace_TxBurst)) {
+    return NULL;
+  }
+
+  EthFacePriv* priv = Face_GetPrivT(face, EthFacePriv);
+  if (unlikely(!EthFace_MatchUdp(priv, rx))) {
+    return NULL;
+  }
+  return priv;
+}
+
+static bool
+EthRxTable_AcceptUdp(EthRxTable* rxt, struct rte_mbuf* frame, rte_be16_t etherType, uint16_t l2Len)
+{
+  EthFaceRxUdp rx;
+  if (unlikely(!EthFaceRxUdp_Parse(&rx, frame, etherType, l2Len))) {
+    return false;
+  }
+
+  uint8_t slot = EthRxTable_UdpSlot(rx.srcIP[rx.ipVersion == 4 ? 3 : 15],
+                                    rte_be_to_cpu_16(rx.udp->src_port));
+  EthFacePriv* priv = EthRxTable_MatchUdp(&rxt->udp[slot], &rx);
+  if (priv == NULL) {
+    const struct rte_vxlan_hdr* vxlan = EthFaceRxUdp_GetVxlan(&rx);
+    if (vxlan == NULL) {
+      return false;
+    }
+    slot = (uint8_t)(rte_be_to_cpu_32(vxlan->vx_vni) >> 8);
+    priv = EthRxTable_MatchUdp(&rxt->vxlan[slot], &rx);
+    if (priv == NULL) {
+      return false;
+    }
+  }
+
+  EthFace_AcceptUdp(priv, frame, &rx);
+  frame->port = priv->faceID;
+  return true;
+}
+
 static bool
 EthRxTable_Accept(EthRxTable* rxt, struct rte_mbuf* frame, uint64_t now)
 {
   NDNDPDK_ASSERT(frame->data_len >= sizeof(EthFaceEtherHdr));
   const EthFaceEtherHdr* hdr = rte_pktmbuf_mtod(frame, const EthFaceEtherHdr*);
 
-  if (rte_is_multicast_ether_addr(&hdr->eth.d_addr)) {
-    frame->port = atomic_load_explicit(&rxt->multicast, memory_order_relaxed);
-  } else {
-    uint8_t srcLastOctet = hdr->eth.s_addr.addr_bytes[5];
-    frame->port = atomic_load_explicit(&rxt->unicast[srcLastOctet], memory_order_relaxed);
+  rte_be16_t etherType = hdr->eth.ether_type;
+  uint16_t l2Len = offsetof(EthFaceEtherHdr, vlan);
+  if (etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_VLAN)) {
+    etherType = hdr->vlan.eth_proto;
+    l2Len = sizeof(EthFaceEtherHdr);
   }
 
-  if (likely(hdr->eth.ether_type == rte_cpu_to_be_16(NDN_ETHERTYPE))) {
-    rte_pktmbuf_adj(frame, offsetof(EthFaceEtherHdr, vlan));
-  } else if (likely(hdr->vlan.eth_proto == rte_cpu_to_be_16(NDN_ETHERTYPE))) {
-    rte_pktmbuf_adj(frame, sizeof(EthFaceEtherHdr));
-  } else {
+  if (likely(etherType == rte_cpu_to_be_16(NDN_ETHERTYPE))) {
+    if (rte_is_multicast_ether_addr(&hdr->eth.d_addr)) {
+      frame->port = atomic_load_explicit(&rxt->multicast, memory_order_relaxed);
+    } else {
+      uint8_t srcLastOctet = hdr->eth.s_addr.addr_bytes[5];
+      frame->port = atomic_load_explicit(&rxt->unicast[srcLastOctet], memory_order_relaxed);
+    }
+    rte_pktmbuf_adj(frame, l2Len);
+  } else if (!EthRxTable_AcceptUdp(rxt, frame, etherType, l2Len)) {
     rte_pktmbuf_free(frame);
     return false;
   }
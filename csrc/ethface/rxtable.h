@@ -13,8 +13,17 @@ typedef struct EthRxTable
   uint16_t queue;
   _Atomic FaceID multicast;    ///< multicast face
   _Atomic FaceID unicast[256]; ///< unicast faces, by last octet of sender address
+  _Atomic FaceID udp[256];     ///< UDP faces, by EthRxTable_UdpSlot
+  _Atomic FaceID vxlan[256];   ///< VXLAN faces, by last octet of VNI
 } EthRxTable;
 
+/** @brief Determine UDP face slot from last octet of sender IP address and sender UDP port. */
+static inline uint8_t
+EthRxTable_UdpSlot(uint8_t srcIPLastOctet, uint16_t srcPort)
+{
+  return srcIPLastOctet ^ (uint8_t)srcPort;
+}
+
 uint16_t
 EthRxTable_RxBurst(RxGroup* rxg, struct rte_mbuf** pkts, uint16_t nPkts);
 
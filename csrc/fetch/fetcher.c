@@ -45,16 +45,25 @@ FetchProc_TxBurst(FetchProc* fp, FetchThread* fth)
 __attribute__((nonnull)) static bool
 FetchProc_Decode(FetchProc* fp, Packet* npkt, FetchLogicRxData* lpkt)
 {
-  if (unlikely(Packet_GetType(npkt) != PktData)) {
-    return false;
-  }
+  const PName* name = NULL;
   LpL3* lpl3 = Packet_GetLpL3Hdr(npkt);
+  switch (Packet_GetType(npkt)) {
+    case PktData:
+      name = &Packet_GetDataHdr(npkt)->name;
+      lpkt->nackReason = 0;
+      break;
+    case PktNack:
+      name = &Packet_GetNackHdr(npkt)->interest.name;
+      lpkt->nackReason = lpl3->nackReason;
+      break;
+    default:
+      return false;
+  }
   lpkt->congMark = lpl3->congMark;
 
-  const PData* data = Packet_GetDataHdr(npkt);
-  const uint8_t* seqNumComp = RTE_PTR_ADD(data->name.value, fp->tpl.prefixL);
-  return data->name.length > fp->tpl.prefixL + 1 &&
-         memcmp(data->name.value, fp->tpl.prefixV, fp->tpl.prefixL + 1) == 0 &&
+  const uint8_t* seqNumComp = RTE_PTR_ADD(name->value, fp->tpl.prefixL);
+  return name->length > fp->tpl.prefixL + 1 &&
+         memcmp(name->value, fp->tpl.prefixV, fp->tpl.prefixL + 1) == 0 &&
          Nni_Decode(seqNumComp[1], RTE_PTR_ADD(seqNumComp, 2), &lpkt->segNum);
 }
 
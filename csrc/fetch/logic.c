@@ -1,6 +1,7 @@
 #include "logic.h"
 
 #include "../core/logger.h"
+#include "../ndni/an.h"
 
 INIT_ZF_LOG(FetchLogic);
 
@@ -83,6 +84,7 @@ FetchLogic_RxData(FetchLogic* fl, TscTime now, uint64_t segNum, bool hasCongMark
     // RTT valid only if no retx was sent
     TscDuration rtt = now - seg->txTime;
     RttEst_Push(&fl->rtte, now, rtt);
+    QuantileSketch_Push(&fl->rttQuantiles, rtt);
   }
 
   if (unlikely(hasCongMark)) {
@@ -95,12 +97,37 @@ FetchLogic_RxData(FetchLogic* fl, TscTime now, uint64_t segNum, bool hasCongMark
   FetchWindow_Delete(&fl->win, segNum);
 }
 
+static inline void
+FetchLogic_RxNack(FetchLogic* fl, TscTime now, uint64_t segNum, uint8_t reason, bool hasCongMark)
+{
+  if (likely(reason != NackCongestion && !hasCongMark)) {
+    return;
+  }
+
+  FetchSeg* seg = FetchWindow_Get(&fl->win, segNum);
+  if (unlikely(seg == NULL || seg->inRetxQ)) {
+    return;
+  }
+  ++fl->nRxCongNacks;
+
+  // cancel RTO timer and schedule retransmission
+  --fl->nInFlight;
+  MinTmr_Cancel(&seg->rtoExpiry);
+  FetchLogic_DecreaseCwnd(fl, "RxNackCongestion", segNum, now);
+  seg->inRetxQ = true;
+  TAILQ_INSERT_TAIL(&fl->retxQ, seg, retxQ);
+}
+
 void
 FetchLogic_RxDataBurst(FetchLogic* fl, const FetchLogicRxData* pkts, size_t count)
 {
   TscTime now = rte_get_tsc_cycles();
   for (size_t i = 0; i < count; ++i) {
-    FetchLogic_RxData(fl, now, pkts[i].segNum, pkts[i].congMark > 0);
+    if (unlikely(pkts[i].nackReason != 0)) {
+      FetchLogic_RxNack(fl, now, pkts[i].segNum, pkts[i].nackReason, pkts[i].congMark > 0);
+    } else {
+      FetchLogic_RxData(fl, now, pkts[i].segNum, pkts[i].congMark > 0);
+    }
   }
 }
 
@@ -130,6 +157,7 @@ FetchLogic_Init_(FetchLogic* fl)
   TAILQ_INIT(&fl->retxQ);
   fl->nTxRetx = 0;
   fl->nRxData = 0;
+  fl->nRxCongNacks = 0;
   fl->finalSegNum = UINT64_MAX;
   fl->hiDataSegNum = 0;
   fl->cwndDecreaseInterestSegNum = 0;
@@ -3,6 +3,7 @@
 
 /** @file */
 
+#include "../core/quantile-sketch.h"
 #include "rttest.h"
 #include "tcpcubic.h"
 #include "window.h"
@@ -14,11 +15,13 @@ typedef struct FetchLogic
 {
   FetchWindow win;
   RttEst rtte;
+  QuantileSketch rttQuantiles; ///< RTT samples in TSC unit
   TcpCubic ca;
   FetchRetxQueue retxQ;
   MinSched* sched;
   uint64_t nTxRetx;
   uint64_t nRxData;
+  uint64_t nRxCongNacks;
   uint64_t finalSegNum;
   uint64_t hiDataSegNum;
   uint64_t cwndDecreaseInterestSegNum;
@@ -57,12 +60,17 @@ typedef struct FetchLogicRxData
 {
   uint64_t segNum;
   uint8_t congMark;
+  uint8_t nackReason; ///< Nack reason, or 0 for Data
 } FetchLogicRxData;
 
 /**
- * @brief Notify Data arrival.
- * @param pkts fields extracted from arrived Data.
+ * @brief Notify Data or Nack arrival.
+ * @param pkts fields extracted from arrived Data or Nack.
  * @param count size of segNums array.
+ *
+ * A Data carrying congestion mark, a Nack carrying congestion mark, or a Nack of reason Congestion
+ * reduces the congestion window. Such a Nack also causes immediate retransmission; other Nacks are
+ * ignored and the Interest would be retransmitted after RTO.
  */
 void
 FetchLogic_RxDataBurst(FetchLogic* fl, const FetchLogicRxData* pkts, size_t count);
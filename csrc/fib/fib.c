@@ -42,7 +42,7 @@ Fib_AllocBulk(Fib* fib, FibEntry* entries[], unsigned count)
 }
 
 void
-Fib_Write(Fib* fib, FibEntry* entry)
+Fib_Write(Fib* fib, FibEntry* entry, bool keepSeqNum)
 {
   FibEntry* newReal = entry;
   if (entry->height > 0) {
@@ -53,8 +53,9 @@ Fib_Write(Fib* fib, FibEntry* entry)
   if (newReal != NULL) {
     NDNDPDK_ASSERT(newReal->height == 0);
     NDNDPDK_ASSERT(newReal->nNexthops > 0);
-    StrategyCode_Ref(newReal->strategy);
-    newReal->seqNum = ++fib->insertSeqNum;
+    if (!keepSeqNum) {
+      newReal->seqNum = ++fib->insertSeqNum;
+    }
   }
 
   LName name = { .length = entry->nameL, .value = entry->nameV };
@@ -73,6 +74,9 @@ __attribute__((nonnull)) static void
 Fib_RcuFree_(struct rcu_head* rcuhead)
 {
   FibEntry* entry = container_of(rcuhead, FibEntry, rcuhead);
+  if (entry->height == 0) {
+    StrategyCode_Unref(entry->strategy);
+  }
   rte_mempool_put(rte_mempool_from_obj(entry), entry);
 }
 
@@ -28,10 +28,12 @@ Fib_AllocBulk(Fib* fib, FibEntry* entries[], unsigned count);
 /**
  * @brief Insert or replace a FIB entry.
  * @param entry an entry allocated from FIB mempool.
+ * @param keepSeqNum if true, the real entry retains its current sequence number, so that
+ *                   PIT entries referencing a replaced entry with the same number remain valid.
  * @pre Calling thread holds rcu_read_lock.
  */
 __attribute__((nonnull)) void
-Fib_Write(Fib* fib, FibEntry* entry);
+Fib_Write(Fib* fib, FibEntry* entry, bool keepSeqNum);
 
 /**
  * @brief Erase given FIB entry.
@@ -43,6 +45,8 @@ Fib_Erase(Fib* fib, FibEntry* entry);
 /**
  * @brief Free given entry after RCU.
  * @pre Calling thread holds rcu_read_lock.
+ *
+ * If @p entry is a real entry, its strategy reference is released after RCU.
  */
 __attribute__((nonnull)) void
 Fib_DeferredFree(Fib* fib, FibEntry* entry);
@@ -1,6 +1,7 @@
 #include "crypto.h"
 
 #include "../core/logger.h"
+#include "../hrlog/post.h"
 
 INIT_ZF_LOG(FwCrypto);
 
@@ -39,10 +40,12 @@ FwCrypto_Input(FwCrypto* fwc)
     return;
   }
 
+  TscTime now = Hrlog_Begin();
   uint16_t posS = 0, posM = nDeq;
   for (uint16_t i = 0; i < nDeq; ++i) {
     Packet* npkt = npkts[i];
     struct rte_mbuf* pkt = Packet_ToMbuf(npkts[i]);
+    Hrlog_Mark(pkt, now);
     if (likely(pkt->nb_segs == 1)) {
       DataDigest_Prepare(npkt, ops[posS++]);
     } else {
@@ -70,11 +73,21 @@ FwCrypto_Output(FwCrypto* fwc, const CryptoQueuePair* cqp)
     }
   }
 
+  TscTime now = Hrlog_Begin();
+  HrlogEntry hrl[FW_CRYPTO_BURST_SIZE];
+  uint16_t nHrls = 0;
   for (uint16_t i = 0; i < nFinish; ++i) {
     Packet* npkt = npkts[i];
+    TscDuration duration;
+    if (unlikely(now != 0) && Hrlog_SinceMark(Packet_ToMbuf(npkt), now, &duration)) {
+      hrl[nHrls++] = HrlogEntry_New(HRLOG_CD, duration);
+    }
     PData* data = Packet_GetDataHdr(npkt);
     InputDemux_Dispatch(&fwc->output, npkt, &data->name);
   }
+  if (nHrls > 0) {
+    Hrlog_PostBulk(hrl, nHrls);
+  }
 }
 
 void
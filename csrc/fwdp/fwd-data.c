@@ -35,6 +35,9 @@ FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
   ZF_LOGD("^ pit-entry=%p pit-key=%s", ctx->pitEntry,
           PitEntry_ToDebugString(ctx->pitEntry, debugStringBuffer));
 
+  // propagate congestion mark from upstream, in addition to marks recorded from downstream
+  uint8_t congMark = Packet_GetLpL3Hdr(ctx->npkt)->congMark;
+
   PitDnIt it;
   for (PitDnIt_Init(&it, ctx->pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
     PitDn* dn = it.dn;
@@ -52,6 +55,11 @@ FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
       ZF_LOGD("^ no-data-to=%" PRI_FaceID " drop=face-down", dn->face);
       continue;
     }
+    if (unlikely(
+          !FwFwd_AclOutbound(fwd, dn->face, PktData, &Packet_GetDataHdr(ctx->npkt)->name))) {
+      ZF_LOGD("^ no-data-to=%" PRI_FaceID " drop=acl-denied", dn->face);
+      continue;
+    }
 
     Packet* outNpkt = Packet_Clone(ctx->npkt, fwd->headerMp, fwd->indirectMp);
     ZF_LOGD("^ data-to=%" PRI_FaceID " npkt=%p dn-token=%016" PRIx64, dn->face, outNpkt, dn->token);
@@ -61,7 +69,10 @@ FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
       outPkt->timestamp = ctx->rxTime;
       LpL3* lpl3 = Packet_GetLpL3Hdr(outNpkt);
       lpl3->pitToken = dn->token;
-      lpl3->congMark = dn->congMark;
+      lpl3->congMark = RTE_MAX(dn->congMark, congMark);
+      lpl3->trafficClass =
+        Face_TxClassify(dn->face, PName_ToLName(&Packet_GetDataHdr(ctx->npkt)->name));
+      lpl3->traceId = ctx->traceId;
       Face_Tx(dn->face, outNpkt);
     }
   }
@@ -71,6 +82,7 @@ FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
     uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
     ZF_LOGD("^ fib-entry-depth=%" PRIu8 " sg-id=%d sg-res=%" PRIu64, ctx->fibEntry->nComps,
             ctx->fibEntry->strategy->id, res);
+    FwFwdCtx_Trace(ctx, PktTraceStageStrategy, 0);
   }
 }
 
@@ -80,8 +92,27 @@ FwFwd_RxData(FwFwd* fwd, FwFwdCtx* ctx)
   ZF_LOGD("data-from=%" PRI_FaceID " npkt=%p up-token=%016" PRIx64, ctx->rxFace, ctx->npkt,
           ctx->rxToken);
 
+  // apply inbound face ACL
+  if (unlikely(!FwFwd_AclInbound(fwd, ctx, &Packet_GetDataHdr(ctx->npkt)->name))) {
+    ZF_LOGD("^ drop=acl-denied");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    rte_pktmbuf_free(ctx->pkt);
+    FwFwd_NULLize(ctx->pkt);
+    return;
+  }
+
+  // apply /localhost scope control
+  if (unlikely(!FwFwd_ScopeInbound(fwd, ctx, &Packet_GetDataHdr(ctx->npkt)->name))) {
+    ZF_LOGD("^ drop=scope-violation");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    rte_pktmbuf_free(ctx->pkt);
+    FwFwd_NULLize(ctx->pkt);
+    return;
+  }
+
   PitFindResult pitFound = Pit_FindByData(fwd->pit, ctx->npkt);
   if (PitFindResult_Is(pitFound, PIT_FIND_NONE)) {
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultNoPit);
     FwFwd_DataUnsolicited(fwd, ctx);
     return;
   }
@@ -90,6 +121,7 @@ FwFwd_RxData(FwFwd* fwd, FwFwdCtx* ctx)
     return;
   }
 
+  FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultPit);
   ctx->nhFlt = ~0; // disallow all forwarding
   rcu_read_lock();
 
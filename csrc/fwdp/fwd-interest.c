@@ -3,6 +3,7 @@
 #include "token.h"
 
 #include "../core/logger.h"
+#include "../pcct/pit-iterator.h"
 
 INIT_ZF_LOG(FwFwd);
 
@@ -75,6 +76,7 @@ FwFwd_InterestForward(FwFwd* fwd, FwFwdCtx* ctx)
   uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
   FwFwd_NULLize(ctx->pitEntry); // strategy may have deleted PIT entry via SgReturnNacks
   ZF_LOGD("^ sg-res=%" PRIu64 " sg-forwarded=%d", res, ctx->nForwarded);
+  FwFwdCtx_Trace(ctx, PktTraceStageStrategy, ctx->nForwarded);
   if (unlikely(ctx->nForwarded == 0)) {
     ++fwd->nSgNoFwd;
   }
@@ -83,6 +85,14 @@ FwFwd_InterestForward(FwFwd* fwd, FwFwdCtx* ctx)
 __attribute__((nonnull)) static void
 FwFwd_InterestHitCs(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry)
 {
+  if (unlikely(!FwFwd_AclOutbound(fwd, ctx->rxFace, PktData,
+                                  &Packet_GetDataHdr(csEntry->data)->name))) {
+    ZF_LOGD("^ cs-entry=%p no-data-to=%" PRI_FaceID " drop=acl-denied", csEntry, ctx->rxFace);
+    rte_pktmbuf_free(ctx->pkt);
+    FwFwd_NULLize(ctx->pkt);
+    return;
+  }
+
   Packet* outNpkt = Packet_Clone(csEntry->data, fwd->headerMp, fwd->indirectMp);
   ZF_LOGD("^ cs-entry=%p data-to=%" PRI_FaceID " npkt=%p dn-token=%016" PRIx64, csEntry,
           ctx->rxFace, outNpkt, ctx->rxToken);
@@ -93,12 +103,111 @@ FwFwd_InterestHitCs(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry)
     LpL3* lpl3 = Packet_GetLpL3Hdr(outNpkt);
     lpl3->pitToken = ctx->rxToken;
     lpl3->congMark = Packet_GetLpL3Hdr(ctx->npkt)->congMark;
+    lpl3->trafficClass =
+      Face_TxClassify(ctx->rxFace, PName_ToLName(&Packet_GetDataHdr(csEntry->data)->name));
+    lpl3->traceId = ctx->traceId;
     Face_Tx(ctx->rxFace, outNpkt);
   }
   rte_pktmbuf_free(ctx->pkt);
   FwFwd_NULLize(ctx->pkt);
 }
 
+/**
+ * @brief Apply /localhost and /localhop scope control on an outgoing Interest.
+ * @return whether the Interest may be forwarded to @p nh .
+ *
+ * A /localhost Interest cannot be forwarded to a non-local face.
+ * A /localhop Interest cannot be forwarded to a non-local face, if any downstream is non-local.
+ */
+__attribute__((nonnull)) static bool
+FwFwd_InterestScope(FwFwd* fwd, PitEntry* pitEntry, FaceID nh)
+{
+  if (Face_IsLocal(nh)) {
+    return true;
+  }
+
+  switch (FwScope_Classify(PName_ToLName(&Packet_GetInterestHdr(pitEntry->npkt)->name))) {
+    case FwScopeLocalhost:
+      ++fwd->nLocalhostViolations;
+      return false;
+    case FwScopeLocalhop: {
+      PitDnIt it;
+      for (PitDnIt_Init(&it, pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
+        if (it.dn->face == 0) {
+          break;
+        }
+        if (!Face_IsLocal(it.dn->face)) {
+          ++fwd->nLocalhopViolations;
+          return false;
+        }
+      }
+      return true;
+    }
+    default:
+      return true;
+  }
+}
+
+/**
+ * @brief Apply Interest rate limiters.
+ * @pre RCU read lock is held.
+ * @return whether the Interest is admitted; otherwise, the Interest is dropped or Nacked.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+FwFwd_InterestLimit(FwFwd* fwd, FwFwdCtx* ctx)
+{
+  FwLimiter* limiter = rcu_dereference(fwd->limiter);
+  if (likely(limiter == NULL)) {
+    return true;
+  }
+
+  LName name = PName_ToLName(&Packet_GetInterestHdr(ctx->npkt)->name);
+  FwLimiterRule* rule = FwLimiter_Check(limiter, ctx->rxFace, name, ctx->rxTime);
+  if (likely(rule == NULL)) {
+    return true;
+  }
+
+  FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+  if (rule->action == FwLimiterNack) {
+    ZF_LOGD("^ drop=rate-limited nack-to=%" PRI_FaceID, ctx->rxFace);
+    Face_Tx(ctx->rxFace, Nack_FromInterest(ctx->npkt, NackCongestion));
+  } else {
+    ZF_LOGD("^ drop=rate-limited");
+    rte_pktmbuf_free(ctx->pkt);
+  }
+  FwFwd_NULLize(ctx->pkt);
+  return false;
+}
+
+/**
+ * @brief Hand off an Interest to the new forwarding thread of an NDT element in transition.
+ * @return whether the Interest has been handed off.
+ *
+ * The Interest stays in this thread if it matches an existing PIT entry or Dead Nonce List entry,
+ * so that it is aggregated with, or detected as looping against, state created before the move.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+FwFwd_InterestTransit(FwFwd* fwd, FwFwdCtx* ctx)
+{
+  if (likely(fwd->ndt == NULL || !Ndt_HasTransit(fwd->ndt))) {
+    return false;
+  }
+
+  PInterest* interest = Packet_GetInterestHdr(ctx->npkt);
+  uint64_t index = 0;
+  Ndt_Lookup(fwd->ndt, &interest->name, &index);
+  uint8_t dest = Ndt_ReadTransit(fwd->ndt, index);
+  if (likely(dest == NdtNoTransit || dest == fwd->id) ||
+      Pit_FindByInterest(fwd->pit, ctx->npkt) != NULL || Pit_HasDeadNonce(fwd->pit, ctx->npkt)) {
+    return false;
+  }
+
+  ZF_LOGD("^ transit-to=%" PRIu8, dest);
+  InputDemux_PassTo(&fwd->transitDemux, ctx->npkt, dest);
+  FwFwd_NULLize(ctx->pkt);
+  return true;
+}
+
 void
 FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
 {
@@ -108,11 +217,50 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
   ZF_LOGD("interest-from=%" PRI_FaceID " npkt=%p dn-token=%016" PRIx64, ctx->rxFace, ctx->npkt,
           ctx->rxToken);
 
-  // query FIB, reply Nack if no FIB match
+  // hand off to new forwarding thread if NDT element is in transition
+  if (unlikely(FwFwd_InterestTransit(fwd, ctx))) {
+    return;
+  }
+
+  // apply inbound face ACL
+  if (unlikely(!FwFwd_AclInbound(fwd, ctx, &interest->name))) {
+    ZF_LOGD("^ drop=acl-denied");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    rte_pktmbuf_free(ctx->pkt);
+    FwFwd_NULLize(ctx->pkt);
+    return;
+  }
+
+  // apply /localhost scope control
+  if (unlikely(!FwFwd_ScopeInbound(fwd, ctx, &interest->name))) {
+    ZF_LOGD("^ drop=scope-violation");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    rte_pktmbuf_free(ctx->pkt);
+    FwFwd_NULLize(ctx->pkt);
+    return;
+  }
+
+  // detect looping Interest whose PIT entry has been erased
+  if (unlikely(Pit_HasDeadNonce(fwd->pit, ctx->npkt))) {
+    ZF_LOGD("^ drop=dead-nonce nack-to=%" PRI_FaceID, ctx->rxFace);
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    Face_Tx(ctx->rxFace, Nack_FromInterest(ctx->npkt, NackDuplicate));
+    return;
+  }
+
   rcu_read_lock();
+
+  // apply rate limits, drop or reply Nack if exceeded
+  if (unlikely(!FwFwd_InterestLimit(fwd, ctx))) {
+    rcu_read_unlock();
+    return;
+  }
+
+  // query FIB, reply Nack if no FIB match
   FwFwdCtx_SetFibEntry(ctx, FwFwd_InterestLookupFib(fwd, ctx->npkt, &ctx->nhFlt));
   if (unlikely(ctx->fibEntry == NULL)) {
     ZF_LOGD("^ drop=no-FIB-match nack-to=%" PRI_FaceID, ctx->rxFace);
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultNoRoute);
     Face_Tx(ctx->rxFace, Nack_FromInterest(ctx->npkt, NackNoRoute));
     ++fwd->nNoFibMatch;
     rcu_read_unlock();
@@ -123,21 +271,27 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
   ++ctx->fibEntryDyn->nRxInterests;
 
   // lookup PIT-CS
+  TscTime pitBegin = Hrlog_Begin();
   PitInsertResult pitIns = Pit_Insert(fwd->pit, ctx->npkt, ctx->fibEntry);
   switch (PitInsertResult_GetKind(pitIns)) {
     case PIT_INSERT_PIT0:
     case PIT_INSERT_PIT1: {
       ctx->pitEntry = PitInsertResult_GetPitEntry(pitIns);
+      HrlogBuffer_AddSince(&fwd->hrlog, HRLOG_PI, pitBegin);
+      FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultPit);
       FwFwd_InterestForward(fwd, ctx);
       break;
     }
     case PIT_INSERT_CS: {
       CsEntry* csEntry = CsEntry_GetDirect(PitInsertResult_GetCsEntry(pitIns));
+      HrlogBuffer_AddSince(&fwd->hrlog, HRLOG_CS, pitBegin);
+      FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultCsHit);
       FwFwd_InterestHitCs(fwd, ctx, csEntry);
       break;
     }
     case PIT_INSERT_FULL:
       ZF_LOGD("^ drop=PIT-full nack-to=%" PRI_FaceID, ctx->rxFace);
+      FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultPitFull);
       Face_Tx(ctx->rxFace, Nack_FromInterest(ctx->npkt, NackCongestion));
       break;
     default:
@@ -161,6 +315,17 @@ SgForwardInterest(SgCtx* ctx0, FaceID nh)
     return SGFWDI_BADFACE;
   }
 
+  if (unlikely(!FwFwd_AclOutbound(fwd, nh, PktInterest,
+                                  &Packet_GetInterestHdr(ctx->pitEntry->npkt)->name))) {
+    ZF_LOGD("^ no-interest-to=%" PRI_FaceID " drop=acl-denied", nh);
+    return SGFWDI_DENIED;
+  }
+
+  if (unlikely(!FwFwd_InterestScope(fwd, ctx->pitEntry, nh))) {
+    ZF_LOGD("^ no-interest-to=%" PRI_FaceID " drop=scope-violation", nh);
+    return SGFWDI_DENIED;
+  }
+
   PitUp* up = PitEntry_ReserveUp(ctx->pitEntry, fwd->pit, nh);
   if (unlikely(up == NULL)) {
     ZF_LOGD("^ no-interest-to=%" PRI_FaceID " drop=PitUp-full", nh);
@@ -193,7 +358,11 @@ SgForwardInterest(SgCtx* ctx0, FaceID nh)
   }
 
   uint64_t token = FwToken_New(fwd->id, PitEntry_GetToken(ctx->pitEntry));
-  Packet_GetLpL3Hdr(outNpkt)->pitToken = token;
+  LpL3* lpl3 = Packet_GetLpL3Hdr(outNpkt);
+  lpl3->pitToken = token;
+  lpl3->trafficClass =
+    Face_TxClassify(nh, PName_ToLName(&Packet_GetInterestHdr(ctx->pitEntry->npkt)->name));
+  lpl3->traceId = ctx->traceId;
   Packet_ToMbuf(outNpkt)->timestamp = ctx->rxTime; // for latency stats
 
   ZF_LOGD("^ interest-to=%" PRI_FaceID " npkt=%p nonce=%08" PRIx32 " lifetime=%" PRIu32
@@ -8,7 +8,8 @@
 INIT_ZF_LOG(FwFwd);
 
 __attribute__((nonnull)) static void
-FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, uint8_t nackHopLimit)
+FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, uint8_t nackHopLimit,
+              uint8_t congMark, uint32_t traceId)
 {
   PitDnIt it;
   for (PitDnIt_Init(&it, pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
@@ -24,6 +25,11 @@ FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, ui
       ZF_LOGD("^ no-nack-to=%" PRI_FaceID " drop=face-down", dn->face);
       continue;
     }
+    if (unlikely(!FwFwd_AclOutbound(fwd, dn->face, PktNack,
+                                    &Packet_GetInterestHdr(pitEntry->npkt)->name))) {
+      ZF_LOGD("^ no-nack-to=%" PRI_FaceID " drop=acl-denied", dn->face);
+      continue;
+    }
 
     Packet* output = Interest_ModifyGuiders(pitEntry->npkt, dn->nonce, 0, nackHopLimit,
                                             fwd->headerMp, fwd->indirectMp);
@@ -33,7 +39,12 @@ FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, ui
     }
 
     output = Nack_FromInterest(output, reason);
-    Packet_GetLpL3Hdr(output)->pitToken = dn->token;
+    LpL3* lpl3 = Packet_GetLpL3Hdr(output);
+    lpl3->pitToken = dn->token;
+    lpl3->congMark = RTE_MAX(dn->congMark, congMark);
+    lpl3->trafficClass =
+      Face_TxClassify(dn->face, PName_ToLName(&Packet_GetInterestHdr(pitEntry->npkt)->name));
+    lpl3->traceId = traceId;
     ZF_LOGD("^ nack-to=%" PRI_FaceID " reason=%s npkt=%p nonce=%08" PRIx32 " dn-token=%016" PRIx64,
             dn->face, NackReason_ToString(reason), output, dn->nonce, dn->token);
     Face_Tx(dn->face, output);
@@ -46,7 +57,8 @@ SgReturnNacks(SgCtx* ctx0, SgNackReason reason)
   FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
   NDNDPDK_ASSERT(ctx->eventKind == SGEVT_INTEREST);
 
-  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), (NackReason)reason, 1);
+  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), (NackReason)reason, 1, 0,
+                ctx->traceId);
 }
 
 __attribute__((nonnull)) static bool
@@ -71,7 +83,11 @@ FwFwd_RxNackDuplicate(FwFwd* fwd, FwFwdCtx* ctx)
   }
 
   uint64_t token = FwToken_New(fwd->id, PitEntry_GetToken(ctx->pitEntry));
-  Packet_GetLpL3Hdr(outNpkt)->pitToken = token;
+  LpL3* lpl3 = Packet_GetLpL3Hdr(outNpkt);
+  lpl3->pitToken = token;
+  lpl3->trafficClass = Face_TxClassify(
+    ctx->pitUp->face, PName_ToLName(&Packet_GetInterestHdr(ctx->pitEntry->npkt)->name));
+  lpl3->traceId = ctx->traceId;
   Packet_ToMbuf(outNpkt)->timestamp = ctx->pkt->timestamp; // for latency stats
 
   ZF_LOGD("^ interest-to=%" PRI_FaceID " npkt=%p nonce=%08" PRIx32 " lifetime=%" PRIu32
@@ -96,12 +112,28 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
   ZF_LOGD("nack-from=%" PRI_FaceID " npkt=%p up-token=%016" PRIx64 " reason=%" PRIu8, ctx->rxFace,
           ctx->npkt, ctx->rxToken, reason);
 
+  // apply inbound face ACL
+  if (unlikely(!FwFwd_AclInbound(fwd, ctx, &nack->interest.name))) {
+    ZF_LOGD("^ drop=acl-denied");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    return;
+  }
+
+  // apply /localhost scope control
+  if (unlikely(!FwFwd_ScopeInbound(fwd, ctx, &nack->interest.name))) {
+    ZF_LOGD("^ drop=scope-violation");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultDropped);
+    return;
+  }
+
   // find PIT entry
   ctx->pitEntry = Pit_FindByNack(fwd->pit, ctx->npkt);
   if (unlikely(ctx->pitEntry == NULL)) {
     ZF_LOGD("^ drop=no-PIT-entry");
+    FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultNoPit);
     return;
   }
+  FwFwdCtx_Trace(ctx, PktTraceStagePitCs, PktTraceResultPit);
 
   // verify nonce in Nack matches nonce in PitUp
   // count remaining pending upstreams and find least severe Nack reason
@@ -156,6 +188,7 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
     uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
     ZF_LOGD("^ fib-entry-depth=%" PRIu8 " sg-id=%d sg-res=%" PRIu64, ctx->fibEntry->nComps,
             ctx->fibEntry->strategy->id, res);
+    FwFwdCtx_Trace(ctx, PktTraceStageStrategy, ctx->nForwarded);
   }
   FwFwd_NULLize(ctx->fibEntry); // fibEntry is inaccessible upon RCU unlock
   rcu_read_unlock();
@@ -167,7 +200,10 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
   }
 
   // return Nacks to downstream and erase PIT entry
-  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit);
+  rcu_read_lock();
+  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit,
+                Packet_GetLpL3Hdr(ctx->npkt)->congMark, ctx->traceId);
+  rcu_read_unlock();
   Pit_Erase(fwd->pit, ctx->pitEntry);
   FwFwd_NULLize(ctx->pitEntry);
 }
@@ -34,7 +34,7 @@ static const FwFwd_RxFunc FwFwd_RxFuncs[PktMax] = {
   FwFwd_RxNack,
 };
 
-static __rte_always_inline void
+static __rte_always_inline uint32_t
 FwFwd_RxByType(FwFwd* fwd, PktType pktType)
 {
   NDNDPDK_ASSERT(pktType < PktMax);
@@ -53,13 +53,37 @@ FwFwd_RxByType(FwFwd* fwd, PktType pktType)
     ctx.rxFace = ctx.pkt->port;
     ctx.rxTime = ctx.pkt->timestamp;
     ctx.rxToken = Packet_GetLpL3Hdr(ctx.npkt)->pitToken;
+    ctx.traceId = Packet_GetLpL3Hdr(ctx.npkt)->traceId;
     ctx.eventKind = (SgEvent)pktType;
 
     TscDuration timeSinceRx = now - ctx.rxTime;
     RunningStat_Push1(&fwd->latencyStat, timeSinceRx);
+    QuantileSketch_Push(&fwd->latencyQuantiles, timeSinceRx);
+    FwFwdCtx_Trace(&ctx, PktTraceStageFwd, timeSinceRx);
 
     (*FwFwd_RxFuncs[pktType])(fwd, &ctx);
   }
+  return pop.count;
+}
+
+static __rte_always_inline void
+FwFwd_RunWalk(FwFwd* fwd)
+{
+  PcctWalk* walk = __atomic_load_n(&fwd->walk, __ATOMIC_ACQUIRE);
+  if (likely(walk == NULL)) {
+    return;
+  }
+  if (PcctWalk_Run(Pcct_FromPit(fwd->pit), walk, FwFwdWalkBudget)) {
+    __atomic_store_n(&fwd->walk, NULL, __ATOMIC_RELEASE);
+  }
+}
+
+FwLimiter*
+FwFwd_SetLimiter(FwFwd* fwd, FwLimiter* limiter)
+{
+  ZF_LOGI("%p SetLimiter(%p) nFaceRules=%" PRIu32 " nPrefixRules=%" PRIu32, fwd, limiter,
+          limiter == NULL ? 0 : limiter->nFaceRules, limiter == NULL ? 0 : limiter->nPrefixRules);
+  return rcu_xchg_pointer(&fwd->limiter, limiter);
 }
 
 int
@@ -76,9 +100,12 @@ FwFwd_Run(FwFwd* fwd)
     rcu_quiescent_state();
     Pit_TriggerTimers(fwd->pit);
 
-    FwFwd_RxByType(fwd, PktInterest);
-    FwFwd_RxByType(fwd, PktData);
-    FwFwd_RxByType(fwd, PktNack);
+    uint32_t count = FwFwd_RxByType(fwd, PktInterest) + FwFwd_RxByType(fwd, PktData) +
+                     FwFwd_RxByType(fwd, PktNack);
+    ThreadLoadStat_Report(&fwd->loadStat, count);
+    HrlogBuffer_Flush(&fwd->hrlog);
+
+    FwFwd_RunWalk(fwd);
   }
 
   ZF_LOGI("Stop(%" PRIu8 ")", fwd->id);
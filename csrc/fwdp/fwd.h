@@ -3,15 +3,22 @@
 
 /** @file */
 
+#include "../core/quantile-sketch.h"
 #include "../core/running-stat.h"
 #include "../dpdk/thread.h"
 #include "../fib/fib.h"
 #include "../fib/nexthop-filter.h"
+#include "../hrlog/post.h"
 #include "../iface/face.h"
+#include "../iface/input-demux.h"
 #include "../iface/pktqueue.h"
 #include "../pcct/cs.h"
 #include "../pcct/pit.h"
+#include "../pcct/walk.h"
+#include "../pkttrace/pkttrace.h"
 #include "../strategyapi/api.h"
+#include "limiter.h"
+#include "scope.h"
 
 /** @brief Forwarding thread. */
 typedef struct FwFwd
@@ -30,24 +37,77 @@ typedef struct FwFwd
   uint8_t id;          ///< fwd process id
   uint8_t fibDynIndex; ///< FibEntry.dyn index
   ThreadStopFlag stop;
+  ThreadLoadStat loadStat;
 
   uint64_t nNoFibMatch;   ///< Interests dropped due to no FIB match
   uint64_t nDupNonce;     ///< Interests dropped due duplicate nonce
   uint64_t nSgNoFwd;      ///< Interests not forwarded by strategy
   uint64_t nNackMismatch; ///< Nack dropped due to outdated nonce
+  uint64_t nAclDrops;     ///< packets dropped due to face ACL
+
+  uint64_t nLocalhostViolations; ///< /localhost packets dropped on non-local faces
+  uint64_t nLocalhopViolations;  ///< /localhop Interests not forwarded beyond one hop
 
   struct rte_mempool* headerMp;   ///< mempool for Interest/Data header/guider
   struct rte_mempool* indirectMp; ///< mempool for indirect mbufs
 
   struct rte_ring* crypto; ///< queue to crypto helper
 
+  PcctWalk* walk; ///< pending PCCT walk request from management
+
+  FwLimiter* limiter; ///< Interest rate limiters, RCU protected; NULL disables rate limiting
+
+  const Ndt* ndt;           ///< NDT, for detecting elements in transition
+  InputDemux transitDemux; ///< hands off Interests of NDT elements in transition, dest[i] is fwd i
+
   /** @brief Statistics of latency from packet arrival to start processing. */
   RunningStat latencyStat;
+  /** @brief Quantiles of latency from packet arrival to start processing. */
+  QuantileSketch latencyQuantiles;
+
+  HrlogBuffer hrlog; ///< high resolution log entries, posted after each burst
 } FwFwd;
 
 int
 FwFwd_Run(FwFwd* fwd);
 
+/**
+ * @brief Replace Interest rate limiters.
+ * @return old limiter table, which may be freed after an RCU grace period.
+ */
+__attribute__((nonnull(1))) FwLimiter*
+FwFwd_SetLimiter(FwFwd* fwd, FwLimiter* limiter);
+
+/** @brief Number of PCC entries visited by a PCCT walk in each loop iteration. */
+#define FwFwdWalkBudget 64
+
+/**
+ * @brief Request the forwarding thread to perform a PCCT walk.
+ * @pre No other walk is pending.
+ */
+__attribute__((nonnull)) static inline void
+FwFwd_PostWalk(FwFwd* fwd, PcctWalk* walk)
+{
+  __atomic_store_n(&fwd->walk, walk, __ATOMIC_RELEASE);
+}
+
+/** @brief Determine whether a PCCT walk request is pending. */
+__attribute__((nonnull)) static inline bool
+FwFwd_HasWalk(FwFwd* fwd)
+{
+  return __atomic_load_n(&fwd->walk, __ATOMIC_ACQUIRE) != NULL;
+}
+
+/**
+ * @brief Withdraw a pending PCCT walk request.
+ * @pre The forwarding thread is stopped.
+ */
+__attribute__((nonnull)) static inline void
+FwFwd_ClearWalk(FwFwd* fwd)
+{
+  __atomic_store_n(&fwd->walk, NULL, __ATOMIC_RELEASE);
+}
+
 /**
  * @brief Per-packet context in forwarding.
  *
@@ -78,6 +138,7 @@ typedef struct FwFwdCtx
 
   PitUp* pitUp;     // N
   uint64_t rxToken; // F,I,D,N
+  uint32_t traceId; // F,I,D,N
   uint32_t dnNonce; // I
   int nForwarded;   // T,I,N
   FaceID rxFace;    // F,I,D
@@ -94,6 +155,65 @@ FwFwdCtx_SetFibEntry(FwFwdCtx* ctx, FibEntry* fibEntry)
   }
 }
 
+/**
+ * @brief Post a packet trace record if the incoming packet is being traced.
+ * @param value stage specific value.
+ */
+__attribute__((nonnull)) static __rte_always_inline void
+FwFwdCtx_Trace(FwFwdCtx* ctx, PktTraceStage stage, uint64_t value)
+{
+  PktTrace_Post(ctx->traceId, stage, (PktType)ctx->eventKind, ctx->rxFace, value);
+}
+
+/**
+ * @brief Apply inbound face ACL.
+ * @param name packet name; Nack uses the Interest name.
+ * @return whether the packet is allowed; otherwise, the caller should drop the packet.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+FwFwd_AclInbound(FwFwd* fwd, FwFwdCtx* ctx, const PName* name)
+{
+  rcu_read_lock();
+  bool ok = Face_AclCheck(ctx->rxFace, FaceAclInbound, (PktType)ctx->eventKind, name);
+  rcu_read_unlock();
+  if (unlikely(!ok)) {
+    ++fwd->nAclDrops;
+  }
+  return ok;
+}
+
+/**
+ * @brief Apply /localhost scope control on an incoming packet.
+ * @param name packet name; Nack uses the Interest name.
+ * @return whether the packet is allowed; otherwise, the caller should drop the packet.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+FwFwd_ScopeInbound(FwFwd* fwd, FwFwdCtx* ctx, const PName* name)
+{
+  if (Face_IsLocal(ctx->rxFace) || FwScope_Classify(PName_ToLName(name)) != FwScopeLocalhost) {
+    return true;
+  }
+  ++fwd->nLocalhostViolations;
+  return false;
+}
+
+/**
+ * @brief Apply outbound face ACL.
+ * @param pktType PktInterest, PktData, or PktNack.
+ * @param name packet name; Nack uses the Interest name.
+ * @return whether the packet is allowed; otherwise, the caller should not transmit the packet.
+ * @pre Calling thread holds rcu_read_lock.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+FwFwd_AclOutbound(FwFwd* fwd, FaceID face, PktType pktType, const PName* name)
+{
+  bool ok = Face_AclCheck(face, FaceAclOutbound, pktType, name);
+  if (unlikely(!ok)) {
+    ++fwd->nAclDrops;
+  }
+  return ok;
+}
+
 __attribute__((nonnull)) void
 FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx);
 
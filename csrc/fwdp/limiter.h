@@ -0,0 +1,88 @@
+#ifndef NDNDPDK_FWDP_LIMITER_H
+#define NDNDPDK_FWDP_LIMITER_H
+
+/** @file */
+
+#include "../dpdk/token-bucket.h"
+#include "../iface/faceid.h"
+#include "../ndni/name.h"
+#include "enum.h"
+
+/** @brief Interest rate limiter. */
+typedef struct FwLimiterRule
+{
+  TokenBucket tb;
+  uint64_t nPassed; ///< Interests within rate limit
+  uint64_t nShed;   ///< Interests exceeding rate limit
+  FaceID face;      ///< incoming face of a per-face limiter, or 0 for a per-prefix limiter
+  FwLimiterAction action;
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+} FwLimiterRule;
+
+/**
+ * @brief Interest rate limiter table, shared among forwarding threads.
+ *
+ * Per-face limiters are followed by per-prefix limiters sorted by descending prefix length.
+ * An Interest is subject to the limiter of its incoming face, and the per-prefix limiter with
+ * longest matching prefix.
+ */
+typedef struct FwLimiter
+{
+  uint32_t nFaceRules;
+  uint32_t nPrefixRules;
+  FwLimiterRule rules[FwMaxLimiterRules];
+} FwLimiter;
+
+/**
+ * @brief Apply rate limits to an incoming Interest.
+ * @param now Interest arrival time.
+ * @return the limiter that rejects the Interest, or NULL if the Interest is admitted.
+ *
+ * A token is taken from each applicable limiter only if the Interest is admitted by all of them.
+ * If the per-prefix limiter rejects the Interest, the token taken from the per-face limiter is
+ * returned, and the Interest is counted as passed by neither limiter.
+ */
+__attribute__((nonnull)) static inline FwLimiterRule*
+FwLimiter_Check(FwLimiter* lim, FaceID face, LName name, TscTime now)
+{
+  FwLimiterRule* faceRule = NULL;
+  for (uint32_t i = 0; i < lim->nFaceRules; ++i) {
+    if (lim->rules[i].face == face) {
+      faceRule = &lim->rules[i];
+      break;
+    }
+  }
+
+  FwLimiterRule* prefixRule = NULL;
+  FwLimiterRule* prefixRules = &lim->rules[lim->nFaceRules];
+  for (uint32_t i = 0; i < lim->nPrefixRules; ++i) {
+    FwLimiterRule* rule = &prefixRules[i];
+    if (LName_IsPrefix(LName_Init(rule->prefixL, rule->prefixV), name) >= 0) {
+      prefixRule = rule;
+      break;
+    }
+  }
+
+  if (faceRule != NULL && unlikely(!TokenBucket_Take(&faceRule->tb, now))) {
+    __atomic_fetch_add(&faceRule->nShed, 1, __ATOMIC_RELAXED);
+    return faceRule;
+  }
+  if (prefixRule != NULL && unlikely(!TokenBucket_Take(&prefixRule->tb, now))) {
+    if (faceRule != NULL) {
+      TokenBucket_Refund(&faceRule->tb);
+    }
+    __atomic_fetch_add(&prefixRule->nShed, 1, __ATOMIC_RELAXED);
+    return prefixRule;
+  }
+
+  if (faceRule != NULL) {
+    __atomic_fetch_add(&faceRule->nPassed, 1, __ATOMIC_RELAXED);
+  }
+  if (prefixRule != NULL) {
+    __atomic_fetch_add(&prefixRule->nPassed, 1, __ATOMIC_RELAXED);
+  }
+  return NULL;
+}
+
+#endif // NDNDPDK_FWDP_LIMITER_H
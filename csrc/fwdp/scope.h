@@ -0,0 +1,36 @@
+#ifndef NDNDPDK_FWDP_SCOPE_H
+#define NDNDPDK_FWDP_SCOPE_H
+
+/** @file */
+
+#include "../ndni/name.h"
+
+/** @brief Name scope in /localhost and /localhop scope control. */
+typedef enum FwScope
+{
+  FwScopeGlobal,    ///< name is not under a scope-restricted prefix
+  FwScopeLocalhost, ///< name is under /localhost
+  FwScopeLocalhop,  ///< name is under /localhop
+} FwScope;
+
+/** @brief Determine name scope from the first name component. */
+static inline FwScope
+FwScope_Classify(LName name)
+{
+  static const uint8_t localhost[] = { TtGenericNameComponent, 9, 'l', 'o', 'c', 'a', 'l', 'h',
+                                       'o', 's', 't' };
+  static const uint8_t localhop[] = { TtGenericNameComponent, 8, 'l', 'o', 'c', 'a', 'l', 'h', 'o',
+                                      'p' };
+  if (likely(name.length < sizeof(localhop) || name.value[2] != 'l')) {
+    return FwScopeGlobal;
+  }
+  if (LName_IsPrefix(LName_Init(sizeof(localhost), localhost), name) >= 0) {
+    return FwScopeLocalhost;
+  }
+  if (LName_IsPrefix(LName_Init(sizeof(localhop), localhop), name) >= 0) {
+    return FwScopeLocalhop;
+  }
+  return FwScopeGlobal;
+}
+
+#endif // NDNDPDK_FWDP_SCOPE_H
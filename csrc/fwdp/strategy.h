@@ -13,11 +13,14 @@ SgGetXsyms(int* nXsyms);
 __attribute__((nonnull)) void
 SgTriggerTimer(Pit* pit, PitEntry* pitEntry, void* fwd0);
 
-/** @brief Invoke the strategy. */
+/** @brief Invoke the strategy, and log its execution time as HRLOG_SG. */
 static inline uint64_t
 SgInvoke(StrategyCode* strategy, FwFwdCtx* ctx)
 {
-  return StrategyCode_Execute(strategy, ctx, sizeof(SgCtx));
+  TscTime begin = Hrlog_Begin();
+  uint64_t res = StrategyCode_Execute(strategy, ctx, sizeof(SgCtx));
+  HrlogBuffer_AddSince(&ctx->fwd->hrlog, HRLOG_SG, begin);
+  return res;
 }
 
 #endif // NDNDPDK_FWDP_STRATEGY_H
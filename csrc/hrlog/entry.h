@@ -8,9 +8,14 @@
 /** @brief Action identifier in high resolution log. */
 typedef enum HrlogAction
 {
-  HRLOG_OI = 1, // Interest TX since RX
-  HRLOG_OD = 2, // retrieved Data TX since RX
-  HRLOG_OC = 4, // cached Data TX since Interest RX
+  HRLOG_OI = 1,   // Interest TX since RX
+  HRLOG_OD = 2,   // retrieved Data TX since RX
+  HRLOG_OC = 4,   // cached Data TX since Interest RX
+  HRLOG_PI = 8,   // PIT-CS lookup that inserts or finds a PIT entry
+  HRLOG_SG = 16,  // strategy execution
+  HRLOG_CS = 32,  // PIT-CS lookup that finds a CS entry
+  HRLOG_CD = 64,  // Data digest computation in crypto helper
+  HRLOG_TQ = 128, // sojourn time in face before-Tx queue
 } HrlogAction;
 
 /** @brief A high resolution log entry. */
@@ -3,20 +3,115 @@
 
 /** @file */
 
+#include "../dpdk/tsc.h"
 #include "entry.h"
 
 #include <rte_lcore.h>
+#include <rte_mbuf.h>
 #include <rte_ring.h>
 
 extern struct rte_ring* theHrlogRing;
+extern bool theHrlogActive;
 static_assert(sizeof(HrlogEntry) == sizeof(void*), "");
 
+/**
+ * @brief Determine whether a collection is running.
+ *
+ * Callers should skip timestamp reads and log entry preparation when this returns false.
+ */
+static __rte_always_inline bool
+Hrlog_IsActive()
+{
+  return __atomic_load_n(&theHrlogActive, __ATOMIC_RELAXED);
+}
+
 static inline void
 Hrlog_PostBulk(HrlogEntry* entries, uint16_t count)
 {
-  if (theHrlogRing != NULL) {
+  if (Hrlog_IsActive()) {
     rte_ring_enqueue_bulk(theHrlogRing, (void**)entries, count, NULL);
   }
 }
 
+/** @brief Capacity of HrlogBuffer. */
+#define HrlogBufferCapacity 64
+
+/** @brief Per-thread buffer of log entries, posted in bulk. */
+typedef struct HrlogBuffer
+{
+  uint16_t count;
+  HrlogEntry entries[HrlogBufferCapacity];
+} HrlogBuffer;
+
+/** @brief Post buffered log entries. */
+__attribute__((nonnull)) static inline void
+HrlogBuffer_Flush(HrlogBuffer* buf)
+{
+  if (buf->count > 0) {
+    Hrlog_PostBulk(buf->entries, buf->count);
+    buf->count = 0;
+  }
+}
+
+/** @brief Append a log entry, and post the buffer if it is full. */
+__attribute__((nonnull)) static inline void
+HrlogBuffer_Add(HrlogBuffer* buf, HrlogAction act, uint64_t value)
+{
+  buf->entries[buf->count++] = HrlogEntry_New(act, value);
+  if (unlikely(buf->count == HrlogBufferCapacity)) {
+    HrlogBuffer_Flush(buf);
+  }
+}
+
+/**
+ * @brief Read TSC as the start time of an action, or return 0 if collection is not running.
+ * @sa HrlogBuffer_AddSince
+ */
+static __rte_always_inline TscTime
+Hrlog_Begin()
+{
+  return likely(!Hrlog_IsActive()) ? 0 : rte_get_tsc_cycles();
+}
+
+/** @brief Append a log entry of duration since @c Hrlog_Begin , unless @p begin is 0. */
+__attribute__((nonnull)) static __rte_always_inline void
+HrlogBuffer_AddSince(HrlogBuffer* buf, HrlogAction act, TscTime begin)
+{
+  if (likely(begin == 0)) {
+    return;
+  }
+  HrlogBuffer_Add(buf, act, rte_get_tsc_cycles() - begin);
+}
+
+/**
+ * @brief Record current time in a packet, to be retrieved by @c Hrlog_SinceMark .
+ * @param now return value of @c Hrlog_Begin ; 0 clears the mark.
+ *
+ * This uses mbuf hash field, which is unused after RX processing.
+ * The mark must be written unconditionally, so that a packet marked while collection was not
+ * running does not carry leftover RSS hash as a timestamp.
+ * Only the lower 32 bits of TSC are stored, with the lowest bit set to distinguish from an
+ * unmarked packet, so that durations longer than 2^32 TSC cycles (about one second) would wrap around.
+ */
+__attribute__((nonnull)) static __rte_always_inline void
+Hrlog_Mark(struct rte_mbuf* pkt, TscTime now)
+{
+  pkt->hash.usr = now == 0 ? 0 : ((uint32_t)now | 1);
+}
+
+/**
+ * @brief Compute duration since @c Hrlog_Mark .
+ * @return whether the packet has been marked.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+Hrlog_SinceMark(const struct rte_mbuf* pkt, TscTime now, TscDuration* duration)
+{
+  uint32_t mark = pkt->hash.usr;
+  if (mark == 0) {
+    return false;
+  }
+  *duration = (uint32_t)((uint32_t)now - mark);
+  return true;
+}
+
 #endif // NDNDPDK_HRLOG_POST_H
@@ -7,6 +7,7 @@
 #include <unistd.h>
 
 struct rte_ring* theHrlogRing = NULL;
+bool theHrlogActive = false;
 
 int
 Hrlog_RunWriter(const char* filename, int nSkip, int nTotal, ThreadStopFlag* stop)
@@ -38,7 +39,12 @@ Hrlog_RunWriter(const char* filename, int nSkip, int nTotal, ThreadStopFlag* sto
   }
   HrlogEntry* output = RTE_PTR_ADD(map, sizeof(hdr));
 
+  // discard entries left over from a previous collection, before producers resume posting
+  while (rte_ring_dequeue_burst(theHrlogRing, buf, RTE_DIM(buf), NULL) > 0) {
+  }
+
   int nCollected = 0;
+  __atomic_store_n(&theHrlogActive, true, __ATOMIC_RELAXED);
   while (ThreadStopFlag_ShouldContinue(stop) && nCollected < nTotal) {
     int count = rte_ring_dequeue_burst(theHrlogRing, buf, RTE_DIM(buf), NULL);
     if (unlikely(nSkip > 0)) {
@@ -48,6 +54,7 @@ Hrlog_RunWriter(const char* filename, int nSkip, int nTotal, ThreadStopFlag* sto
       nCollected += count;
     }
   }
+  __atomic_store_n(&theHrlogActive, false, __ATOMIC_RELAXED);
 
   if (msync(map, fileSize, MS_SYNC) == -1) {
     return __LINE__;
@@ -0,0 +1,58 @@
+#ifndef NDNDPDK_IFACE_ACL_H
+#define NDNDPDK_IFACE_ACL_H
+
+/** @file */
+
+#include "common.h"
+
+/** @brief Face ACL rule. */
+typedef struct FaceAclRule
+{
+  uint64_t nMatches; ///< number of matched packets, updated atomically
+  uint64_t hash;     ///< prefix hash, computed in the same way as FIB lookup
+  uint8_t pktTypes;  ///< bitmask of matched packet types, indexed by PktType
+  bool allow;        ///< whether matched packets are allowed
+  uint16_t nComps;   ///< number of name components in prefix
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+} FaceAclRule;
+
+/**
+ * @brief Face ACL in one direction.
+ *
+ * Rules are evaluated in order. A packet is allowed or denied by the first rule whose prefix matches
+ * the packet name and whose packet types include the packet type; if no rule matches, the packet is
+ * allowed. This struct is immutable after creation except for counters.
+ */
+typedef struct FaceAcl
+{
+  uint32_t nRules;
+  FaceAclRule rules[MaxAclRules];
+} FaceAcl;
+
+/**
+ * @brief Evaluate ACL rules.
+ * @param pktType PktInterest, PktData, or PktNack.
+ * @param name packet name; Nack uses the Interest name.
+ * @return whether the packet is allowed.
+ *
+ * The prefix hashes of @p name are the same as those used in FIB lookup. They are computed once and
+ * cached in @c PName, so that each rule is compared by hash before comparing name octets.
+ */
+__attribute__((nonnull)) static inline bool
+FaceAcl_Check(FaceAcl* acl, PktType pktType, const PName* name)
+{
+  for (uint32_t i = 0; i < acl->nRules; ++i) {
+    FaceAclRule* rule = &acl->rules[i];
+    if ((rule->pktTypes & (1 << pktType)) == 0 || rule->nComps > name->nComps ||
+        PName_ComputePrefixHash(name, rule->nComps) != rule->hash ||
+        LName_IsPrefix(LName_Init(rule->prefixL, rule->prefixV), PName_ToLName(name)) < 0) {
+      continue;
+    }
+    __atomic_fetch_add(&rule->nMatches, 1, __ATOMIC_RELAXED);
+    return rule->allow;
+  }
+  return true;
+}
+
+#endif // NDNDPDK_IFACE_ACL_H
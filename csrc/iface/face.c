@@ -1,3 +1,10 @@
 #include "face.h"
 
 Face gFaces[UINT16_MAX + 1];
+
+FaceAcl*
+Face_SetAcl(FaceID faceID, FaceAclDir dir, FaceAcl* acl)
+{
+  Face* face = Face_Get(faceID);
+  return rcu_xchg_pointer(&face->impl->acl[dir], acl);
+}
@@ -3,11 +3,13 @@
 
 /** @file */
 
+#include "acl.h"
 #include "faceid.h"
 #include "rx-proc.h"
 #include "tx-proc.h"
 
 #include "../core/urcu.h"
+#include "../hrlog/post.h"
 #include <urcu/rcuhlist.h>
 
 typedef struct Face Face;
@@ -21,10 +23,48 @@ typedef struct FaceCounters FaceCounters;
  */
 typedef uint16_t (*FaceImpl_TxBurst)(Face* face, struct rte_mbuf** pkts, uint16_t nPkts);
 
+/** @brief Traffic class on the send path. */
+typedef struct FaceTxClass
+{
+  struct rte_ring* queue;     ///< before-Tx queue, MP/SC
+  uint64_t nDrops;            ///< packets dropped due to full queue, updated atomically
+  uint64_t nPkts;             ///< dequeued packets, updated by TxLoop
+  uint64_t nOctets;           ///< dequeued octets, updated by TxLoop
+  uint64_t nCongMarks;        ///< inserted congestion marks, updated by TxLoop
+  int32_t quantum;            ///< deficit round robin quantum in octets
+  int32_t deficit;            ///< deficit round robin counter, updated by TxLoop
+  uint32_t congMarkThreshold; ///< mark Data/Nack if queue has this many packets; 0 disables
+} FaceTxClass;
+
+/** @brief Traffic classification rule. */
+typedef struct FaceTxClassRule
+{
+  uint8_t txClass;
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+} FaceTxClassRule;
+
+/**
+ * @brief Traffic classification rules.
+ *
+ * Rules are sorted by descending prefix length. A packet is assigned to the traffic class of the
+ * first rule whose prefix matches its name; if no rule matches, it is assigned to class 0.
+ * This struct is immutable after face creation.
+ */
+typedef struct FaceTxClassifier
+{
+  uint32_t nRules;
+  FaceTxClassRule rules[MaxTxClassRules];
+} FaceTxClassifier;
+
 typedef struct FaceImpl
 {
   RxProc rx;
   TxProc tx;
+  FaceTxClass txClass[NTxClasses];
+  FaceTxClassifier* txClassifier; ///< NULL if there's no rule
+  FaceAcl* acl[2];                ///< ACL of each FaceAclDir, RCU protected; NULL allows all
+  uint8_t txDrrNext;              ///< next DRR class, updated by TxLoop
   char priv[0];
 } FaceImpl;
 
@@ -35,8 +75,8 @@ typedef struct Face
   FaceImpl_TxBurst txBurstOp;
   FaceID id;
   FaceState state;
+  bool isLocal; ///< whether the face is local, for /localhost and /localhop scope control
 
-  struct rte_ring* outputQueue;
   struct cds_hlist_node txlNode;
 } __rte_cache_aligned Face;
 
@@ -65,11 +105,124 @@ Face_IsDown(FaceID faceID)
   return face->state != FaceStateUp;
 }
 
+/** @brief Return whether the face is local. */
+static inline bool
+Face_IsLocal(FaceID faceID)
+{
+  return Face_Get(faceID)->isLocal;
+}
+
+/**
+ * @brief Determine whether a packet is allowed by face ACL.
+ * @param dir FaceAclInbound or FaceAclOutbound.
+ * @param pktType PktInterest, PktData, or PktNack.
+ * @param name packet name; Nack uses the Interest name.
+ * @pre Calling thread holds rcu_read_lock.
+ */
+__attribute__((nonnull)) static inline bool
+Face_AclCheck(FaceID faceID, FaceAclDir dir, PktType pktType, const PName* name)
+{
+  Face* face = Face_Get(faceID);
+  if (unlikely(face->state != FaceStateUp)) {
+    return true;
+  }
+  FaceAcl* acl = rcu_dereference(face->impl->acl[dir]);
+  if (likely(acl == NULL)) {
+    return true;
+  }
+  return FaceAcl_Check(acl, pktType, name);
+}
+
 /**
- * @brief Enqueue a burst of packets on the output queue to be transmitted by the output thread.
+ * @brief Replace face ACL in one direction.
+ * @param acl new ACL, or NULL to allow all.
+ * @return old ACL; it may be freed after an RCU grace period.
+ */
+FaceAcl*
+Face_SetAcl(FaceID faceID, FaceAclDir dir, FaceAcl* acl);
+
+/** @brief Determine traffic class by name. */
+__attribute__((nonnull)) static inline uint8_t
+FaceTxClassifier_Match(const FaceTxClassifier* classifier, LName name)
+{
+  for (uint32_t i = 0; i < classifier->nRules; ++i) {
+    const FaceTxClassRule* rule = &classifier->rules[i];
+    if (LName_IsPrefix(LName_Init(rule->prefixL, rule->prefixV), name) >= 0) {
+      return rule->txClass;
+    }
+  }
+  return 0;
+}
+
+/**
+ * @brief Determine traffic class of a packet with @p name to be transmitted on a face.
+ *
+ * The forwarder uses this function to set @c LpL3.trafficClass on unparsed packets.
+ */
+static inline uint8_t
+Face_TxClassify(FaceID faceID, LName name)
+{
+  Face* face = Face_Get(faceID);
+  if (unlikely(face->state != FaceStateUp) || face->impl->txClassifier == NULL) {
+    return 0;
+  }
+  return FaceTxClassifier_Match(face->impl->txClassifier, name);
+}
+
+/**
+ * @brief Determine traffic class of a packet.
+ *
+ * If @c LpL3.trafficClass is nonzero, it is used as the traffic class.
+ * Otherwise, a parsed packet is classified by its name.
+ */
+__attribute__((nonnull)) static inline uint8_t
+Face_TxClassOf_(FaceImpl* impl, Packet* npkt)
+{
+  uint8_t tc = Packet_GetLpL3Hdr(npkt)->trafficClass;
+  if (tc != 0) {
+    return RTE_MIN(tc, (uint8_t)(NTxClasses - 1));
+  }
+  if (likely(impl->txClassifier == NULL)) {
+    return 0;
+  }
+
+  switch (Packet_GetType(npkt)) {
+    case PktInterest:
+      return FaceTxClassifier_Match(impl->txClassifier,
+                                    PName_ToLName(&Packet_GetInterestHdr(npkt)->name));
+    case PktData:
+      return FaceTxClassifier_Match(impl->txClassifier,
+                                    PName_ToLName(&Packet_GetDataHdr(npkt)->name));
+    case PktNack:
+      return FaceTxClassifier_Match(impl->txClassifier,
+                                    PName_ToLName(&Packet_GetNackHdr(npkt)->interest.name));
+    default:
+      return 0;
+  }
+}
+
+__attribute__((nonnull)) static inline void
+Face_TxEnqueue_(FaceTxClass* tc, Packet** npkts, uint16_t count)
+{
+  TscTime now = Hrlog_Begin();
+  for (uint16_t i = 0; i < count; ++i) {
+    Hrlog_Mark(Packet_ToMbuf(npkts[i]), now);
+  }
+
+  uint16_t nQueued = rte_ring_enqueue_burst(tc->queue, (void**)npkts, count, NULL);
+  uint16_t nRejects = count - nQueued;
+  if (unlikely(nRejects > 0)) {
+    rte_pktmbuf_free_bulk_((struct rte_mbuf**)&npkts[nQueued], nRejects);
+    __atomic_fetch_add(&tc->nDrops, nRejects, __ATOMIC_RELAXED);
+  }
+}
+
+/**
+ * @brief Enqueue a burst of packets on the before-Tx queues to be transmitted by the output thread.
  * @param npkts array of L3 packets; face takes ownership.
  * @param count size of @p npkts array.
  *
+ * Each packet is placed into the queue of its traffic class, see @c Face_TxClassOf_ .
  * This function is thread-safe.
  */
 __attribute__((nonnull)) static inline void
@@ -80,15 +233,26 @@ Face_TxBurst(FaceID faceID, Packet** npkts, uint16_t count)
     rte_pktmbuf_free_bulk_((struct rte_mbuf**)npkts, count);
     return;
   }
+  if (unlikely(count == 0)) {
+    return;
+  }
 
-  uint16_t nQueued = rte_ring_enqueue_burst(face->outputQueue, (void**)npkts, count, NULL);
-  uint16_t nRejects = count - nQueued;
-  rte_pktmbuf_free_bulk_((struct rte_mbuf**)&npkts[nQueued], nRejects);
-  // TODO count nRejects
+  FaceImpl* impl = face->impl;
+  uint16_t start = 0;
+  uint8_t tc = Face_TxClassOf_(impl, npkts[0]);
+  for (uint16_t i = 1; i < count; ++i) {
+    uint8_t tci = Face_TxClassOf_(impl, npkts[i]);
+    if (tci != tc) {
+      Face_TxEnqueue_(&impl->txClass[tc], &npkts[start], i - start);
+      start = i;
+      tc = tci;
+    }
+  }
+  Face_TxEnqueue_(&impl->txClass[tc], &npkts[start], count - start);
 }
 
 /**
- * @brief Enqueue a packet on the output queue to be transmitted by the output thread.
+ * @brief Enqueue a packet on the before-Tx queues to be transmitted by the output thread.
  * @param npkt an L3 packet; face takes ownership.
  */
 __attribute__((nonnull)) static inline void
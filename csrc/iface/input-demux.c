@@ -1,6 +1,7 @@
 #include "input-demux.h"
 
 #include "../core/logger.h"
+#include "../pkttrace/pkttrace.h"
 
 INIT_ZF_LOG(InputDemux);
 
@@ -11,12 +12,13 @@ InputDemux_Drop(InputDemux* demux, Packet* npkt, const char* reason)
   ZF_LOGD("%s-from=%" PRI_FaceID " npkt=%p token=%016" PRIx64 " drop=%s",
           PktType_ToString(Packet_GetType(npkt)), pkt->port, npkt,
           Packet_GetLpL3Hdr(npkt)->pitToken, reason);
+  PktTrace_PostPacket(npkt, PktTraceStageDemux, pkt->port, PktTraceDemuxDropped);
 
   ++demux->nDrops;
   rte_pktmbuf_free(pkt);
 }
 
-static void
+void
 InputDemux_PassTo(InputDemux* demux, Packet* npkt, uint8_t index)
 {
   InputDemuxDest* dest = &demux->dest[index];
@@ -30,9 +32,19 @@ InputDemux_PassTo(InputDemux* demux, Packet* npkt, uint8_t index)
           PktType_ToString(Packet_GetType(npkt)), pkt->port, npkt,
           Packet_GetLpL3Hdr(npkt)->pitToken, index);
 
+  // trace record is posted before enqueuing, because the destination may process and free the
+  // packet afterwards; another record is posted if the packet is rejected
+  uint32_t traceId = Packet_GetLpL3Hdr(npkt)->traceId;
+  PktType pktType = Packet_GetType(npkt);
+  FaceID rxFace = pkt->port;
+  PktTrace_Post(traceId, PktTraceStageDemux, pktType, rxFace, index);
+
   uint32_t nRej = PktQueue_PushPlain(dest->queue, &pkt, 1);
   dest->nDropped += nRej;
   dest->nQueued += 1 - nRej;
+  if (unlikely(nRej > 0)) {
+    PktTrace_Post(traceId, PktTraceStageDemux, pktType, rxFace, index | PktTraceDemuxRejected);
+  }
 }
 
 void
@@ -65,6 +77,13 @@ void
 InputDemux_DispatchByNdt(InputDemux* demux, Packet* npkt, const PName* name)
 {
   uint8_t index = Ndtt_Lookup(demux->ndt, demux->ndtt, name);
+  uint32_t traceId = Packet_GetLpL3Hdr(npkt)->traceId;
+  if (unlikely(traceId != 0)) {
+    uint64_t ndtIndex = 0;
+    Ndt_Lookup(demux->ndt, name, &ndtIndex);
+    PktTrace_Post(traceId, PktTraceStageNdt, Packet_GetType(npkt), Packet_ToMbuf(npkt)->port,
+                  (ndtIndex << 8) | index);
+  }
   InputDemux_PassTo(demux, npkt, index);
 }
 
@@ -19,6 +19,10 @@ typedef struct InputDemux InputDemux;
 
 typedef void (*InputDemux_DispatchFunc)(InputDemux* demux, Packet* npkt, const PName* name);
 
+/** @brief Pass a packet to a destination, or drop it if the destination is absent. */
+void
+InputDemux_PassTo(InputDemux* demux, Packet* npkt, uint8_t index);
+
 void
 InputDemux_DispatchDrop(InputDemux* demux, Packet* npkt, const PName* name);
 
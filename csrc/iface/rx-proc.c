@@ -4,6 +4,29 @@
 
 INIT_ZF_LOG(RxProc);
 
+struct cds_hlist_head theRxProcReassList_;
+
+__attribute__((nonnull)) static Packet*
+RxProc_Reassemble(RxProc* rx, Packet* npkt)
+{
+  RxProcThread* rxt = &rx->reassThread;
+  npkt = Reassembler_Accept(&rx->reass, npkt);
+  if (npkt == NULL) {
+    return NULL;
+  }
+
+  if (unlikely(!Packet_ParseL3(npkt))) {
+    struct rte_mbuf* pkt = Packet_ToMbuf(npkt);
+    ++rxt->nDecodeErr;
+    ZF_LOGD("%" PRI_FaceID " reass decode-error", pkt->port);
+    rte_pktmbuf_free(pkt);
+    return NULL;
+  }
+
+  ++rxt->nFrames[Packet_GetType(npkt)];
+  return npkt;
+}
+
 Packet*
 RxProc_Input(RxProc* rx, int thread, struct rte_mbuf* frame)
 {
@@ -26,27 +49,40 @@ RxProc_Input(RxProc* rx, int thread, struct rte_mbuf* frame)
     return npkt;
   }
 
-  if (unlikely(thread != 0)) {
-    // reassembler is available on thread 0 only
-    ZF_LOGW("%" PRI_FaceID "-%d lp-reassembler-unavail", faceID, thread);
+  if (thread == RXPROC_REASS_THREAD && rte_spinlock_trylock(&rx->reassLock)) {
+    npkt = RxProc_Reassemble(rx, npkt);
+    rte_spinlock_unlock(&rx->reassLock);
+    return npkt;
+  }
+
+  if (unlikely(rte_ring_mp_enqueue(rx->reassQueue, npkt) != 0)) {
+    ++rxt->nReassQueueDrop;
+    ZF_LOGD("%" PRI_FaceID "-%d lp-reassembler-queue-full", faceID, thread);
     rte_pktmbuf_free(frame);
     return NULL;
   }
+  ++rxt->nReassRedirect;
+  return NULL;
+}
 
-  npkt = Reassembler_Accept(&rx->reass, npkt);
-  frame = NULL; // disallow further usage of 'frame'
-  if (npkt == NULL) {
-    return NULL;
+uint16_t
+RxProc_Drain_(RxProc* rx, Packet** npkts, uint16_t count)
+{
+  if (!rte_spinlock_trylock(&rx->reassLock)) {
+    return 0;
   }
 
-  if (unlikely(!Packet_ParseL3(npkt))) {
-    ++rxt->nDecodeErr;
-    ZF_LOGD("%" PRI_FaceID "-%d decode-error", faceID, thread);
-    rte_pktmbuf_free(Packet_ToMbuf(npkt));
-    return NULL;
+  void* fragments[MaxBurstSize];
+  uint16_t nFragments =
+    rte_ring_sc_dequeue_burst(rx->reassQueue, fragments, RTE_MIN(count, RTE_DIM(fragments)), NULL);
+  uint16_t nPkts = 0;
+  for (uint16_t i = 0; i < nFragments; ++i) {
+    Packet* npkt = RxProc_Reassemble(rx, (Packet*)fragments[i]);
+    if (npkt != NULL) {
+      npkts[nPkts++] = npkt;
+    }
   }
 
-  pktType = Packet_GetType(npkt);
-  ++rxt->nFrames[pktType];
-  return npkt;
+  rte_spinlock_unlock(&rx->reassLock);
+  return nPkts;
 }
@@ -3,26 +3,48 @@
 
 /** @file */
 
+#include "../core/urcu.h"
 #include "reassembler.h"
+#include <rte_ring.h>
+#include <rte_spinlock.h>
+#include <urcu/rcuhlist.h>
 
 #define RXPROC_MAX_THREADS 8
 
+/** @brief RX thread number that reassembles fragments directly. */
+#define RXPROC_REASS_THREAD 0
+
 /** @brief RxProc per-thread information. */
 typedef struct RxProcThread
 {
   uint64_t nFrames[PktMax]; ///< accepted L3 packets; nFrames[0] is nOctets
   uint64_t nDecodeErr;      ///< decode errors
+  uint64_t nReassRedirect;  ///< fragments handed off to reassembler queue
+  uint64_t nReassQueueDrop; ///< fragments dropped due to full handoff queue
 } __rte_cache_aligned RxProcThread;
 
 /**
  * @brief Incoming frame processing procedure.
+ *
+ * The reassembler is protected by @c reassLock .
+ * RXPROC_REASS_THREAD reassembles a fragment directly if it can acquire the lock.
+ * Otherwise, and on other threads, the fragment is handed off via @c reassQueue .
+ * Every RX thread drains handoff queues of all faces once per loop iteration,
+ * so that fragments are reassembled even if RXPROC_REASS_THREAD does not receive the face.
  */
 typedef struct RxProc
 {
   Reassembler reass;
+  rte_spinlock_t reassLock;
+  struct rte_ring* reassQueue;     ///< handoff queue toward reassembler, MP/SC under reassLock
+  struct cds_hlist_node reassNode; ///< node in theRxProcReassList_
+  RxProcThread reassThread;        ///< counters of reassembled packets, updated under reassLock
   RxProcThread threads[RXPROC_MAX_THREADS];
 } RxProc;
 
+/** @brief List of RxProc whose handoff queue should be drained. */
+extern struct cds_hlist_head theRxProcReassList_;
+
 /**
  * @brief Process an incoming L2 frame.
  * @param pkt incoming L2 frame, starting from NDNLP header;
@@ -34,4 +56,25 @@ typedef struct RxProc
 __attribute__((nonnull)) Packet*
 RxProc_Input(RxProc* rx, int thread, struct rte_mbuf* pkt);
 
+__attribute__((nonnull)) uint16_t
+RxProc_Drain_(RxProc* rx, Packet** npkts, uint16_t count);
+
+/**
+ * @brief Reassemble fragments handed off via the queue.
+ * @param[out] npkts L3 packets after @c Packet_ParseL3;
+ *                   RxProc releases ownership of these packets.
+ * @param count capacity of @p npkts .
+ * @return number of L3 packets.
+ *
+ * This has no effect if the queue is empty or another thread holds the reassembler lock.
+ */
+__attribute__((nonnull)) static inline uint16_t
+RxProc_Drain(RxProc* rx, Packet** npkts, uint16_t count)
+{
+  if (likely(rte_ring_empty(rx->reassQueue))) {
+    return 0;
+  }
+  return RxProc_Drain_(rx, npkts, count);
+}
+
 #endif // NDNDPDK_IFACE_RX_PROC_H
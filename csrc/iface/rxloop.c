@@ -1,8 +1,37 @@
 #include "rxloop.h"
+#include "../pkttrace/pkttrace.h"
 
 RxGroup theChanRxGroup_;
 
 __attribute__((nonnull)) static void
+RxLoop_Dispatch(RxLoop* rxl, Packet* npkt)
+{
+  switch (Packet_GetType(npkt)) {
+    case PktInterest: {
+      PInterest* interest = Packet_GetInterestHdr(npkt);
+      PktTrace_Rx(npkt, &interest->name);
+      InputDemux_Dispatch(&rxl->demuxI, npkt, &interest->name);
+      break;
+    }
+    case PktData: {
+      PData* data = Packet_GetDataHdr(npkt);
+      PktTrace_Rx(npkt, &data->name);
+      InputDemux_Dispatch(&rxl->demuxD, npkt, &data->name);
+      break;
+    }
+    case PktNack: {
+      PNack* nack = Packet_GetNackHdr(npkt);
+      PktTrace_Rx(npkt, &nack->interest.name);
+      InputDemux_Dispatch(&rxl->demuxN, npkt, &nack->interest.name);
+      break;
+    }
+    default:
+      NDNDPDK_ASSERT(false);
+      break;
+  }
+}
+
+__attribute__((nonnull)) static uint16_t
 RxLoop_Transfer(RxLoop* rxl, RxGroup* rxg)
 {
   struct rte_mbuf* frames[MaxBurstSize];
@@ -15,30 +44,25 @@ RxLoop_Transfer(RxLoop* rxl, RxGroup* rxg)
       continue;
     }
 
-    Packet* npkt = RxProc_Input(&face->impl->rx, rxg->rxThread, frame);
-    if (npkt == NULL) {
-      continue;
+    RxProc* rx = &face->impl->rx;
+    Packet* npkt = RxProc_Input(rx, rxg->rxThread, frame);
+    if (npkt != NULL) {
+      RxLoop_Dispatch(rxl, npkt);
     }
+  }
+  return nRx;
+}
 
-    switch (Packet_GetType(npkt)) {
-      case PktInterest: {
-        PInterest* interest = Packet_GetInterestHdr(npkt);
-        InputDemux_Dispatch(&rxl->demuxI, npkt, &interest->name);
-        break;
-      }
-      case PktData: {
-        PData* data = Packet_GetDataHdr(npkt);
-        InputDemux_Dispatch(&rxl->demuxD, npkt, &data->name);
-        break;
-      }
-      case PktNack: {
-        PNack* nack = Packet_GetNackHdr(npkt);
-        InputDemux_Dispatch(&rxl->demuxN, npkt, &nack->interest.name);
-        break;
-      }
-      default:
-        NDNDPDK_ASSERT(false);
-        break;
+__attribute__((nonnull)) static void
+RxLoop_DrainReass(RxLoop* rxl)
+{
+  RxProc* rx;
+  struct cds_hlist_node* pos;
+  cds_hlist_for_each_entry_rcu (rx, pos, &theRxProcReassList_, reassNode) {
+    Packet* npkts[MaxBurstSize];
+    uint16_t nPkts = RxProc_Drain(rx, npkts, RTE_DIM(npkts));
+    for (uint16_t i = 0; i < nPkts; ++i) {
+      RxLoop_Dispatch(rxl, npkts[i]);
     }
   }
 }
@@ -51,12 +75,15 @@ RxLoop_Run(RxLoop* rxl)
     rcu_quiescent_state();
     rcu_read_lock();
 
+    uint64_t count = 0;
     RxGroup* rxg;
     struct cds_hlist_node* pos;
     cds_hlist_for_each_entry_rcu (rxg, pos, &rxl->head, rxlNode) {
-      RxLoop_Transfer(rxl, rxg);
+      count += RxLoop_Transfer(rxl, rxg);
     }
+    RxLoop_DrainReass(rxl);
     rcu_read_unlock();
+    ThreadLoadStat_Report(&rxl->loadStat, count);
   }
   rcu_unregister_thread();
   return 0;
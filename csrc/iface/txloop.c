@@ -1,5 +1,6 @@
 #include "txloop.h"
 #include "../hrlog/post.h"
+#include "../pkttrace/pkttrace.h"
 
 __attribute__((nonnull)) static void
 TxLoop_TxFrames(Face* face, struct rte_mbuf** frames, uint16_t count)
@@ -25,17 +26,101 @@ TxLoop_TxFrames(Face* face, struct rte_mbuf** frames, uint16_t count)
   }
 }
 
+/** @brief Maximum burst size when dequeuing from a deficit round robin class. */
+#define TXLOOP_DRR_BURST 8
+
+/** @brief Place congestion marks on Data and Nack packets. */
 __attribute__((nonnull)) static void
+TxLoop_CongMark(FaceTxClass* tc, Packet** npkts, uint16_t count)
+{
+  for (uint16_t i = 0; i < count; ++i) {
+    switch (PktType_ToFull(Packet_GetType(npkts[i]))) {
+      case PktData:
+      case PktNack:
+        break;
+      default:
+        continue;
+    }
+    LpL3* lpl3 = Packet_GetLpL3Hdr(npkts[i]);
+    if (lpl3->congMark == 0) {
+      lpl3->congMark = 1;
+      ++tc->nCongMarks;
+    }
+  }
+}
+
+__attribute__((nonnull)) static __rte_always_inline uint16_t
+TxLoop_DequeueClass(FaceTxClass* tc, Packet** npkts, uint16_t max, uint32_t* nOctets)
+{
+  uint16_t count = rte_ring_sc_dequeue_burst(tc->queue, (void**)npkts, max, NULL);
+  *nOctets = 0;
+  for (uint16_t i = 0; i < count; ++i) {
+    *nOctets += Packet_ToMbuf(npkts[i])->pkt_len;
+  }
+  tc->nPkts += count;
+  tc->nOctets += *nOctets;
+
+  if (tc->congMarkThreshold > 0 && count > 0 &&
+      unlikely(rte_ring_count(tc->queue) >= tc->congMarkThreshold)) {
+    TxLoop_CongMark(tc, npkts, count);
+  }
+  return count;
+}
+
+/**
+ * @brief Dequeue a burst of packets from before-Tx queues.
+ *
+ * TxClassPriority is served first with strict priority.
+ * Remaining capacity of the burst is shared among other classes with deficit round robin.
+ * A class may overdraw its deficit counter by one burst, which is repaid in the next round.
+ */
+__attribute__((nonnull)) static uint16_t
+TxLoop_Dequeue(FaceImpl* impl, Packet** npkts)
+{
+  uint32_t nOctets;
+  uint16_t count =
+    TxLoop_DequeueClass(&impl->txClass[TxClassPriority], npkts, MaxBurstSize, &nOctets);
+
+  for (int nIdle = 0; count < MaxBurstSize && nIdle < TxClassPriority;) {
+    FaceTxClass* tc = &impl->txClass[impl->txDrrNext];
+    if (tc->deficit <= 0) {
+      tc->deficit += tc->quantum;
+      if (tc->deficit <= 0) {
+        impl->txDrrNext = (impl->txDrrNext + 1) % TxClassPriority;
+        continue;
+      }
+    }
+
+    uint16_t n = TxLoop_DequeueClass(tc, &npkts[count],
+                                     RTE_MIN(MaxBurstSize - count, TXLOOP_DRR_BURST), &nOctets);
+    count += n;
+    tc->deficit -= (int32_t)nOctets;
+    if (n == 0) {
+      tc->deficit = 0;
+      ++nIdle;
+    } else {
+      nIdle = 0;
+    }
+
+    if (n == 0 || tc->deficit <= 0) {
+      impl->txDrrNext = (impl->txDrrNext + 1) % TxClassPriority;
+    }
+  }
+  return count;
+}
+
+__attribute__((nonnull)) static uint16_t
 TxLoop_Transfer(Face* face)
 {
   TxProc* tx = &face->impl->tx;
   Packet* npkts[MaxBurstSize];
-  uint16_t count = rte_ring_dequeue_burst(face->outputQueue, (void**)npkts, MaxBurstSize, NULL);
+  uint16_t count = TxLoop_Dequeue(face->impl, npkts);
 
   struct rte_mbuf* frames[MaxBurstSize + LpMaxFragments];
   uint16_t nFrames = 0;
-  HrlogEntry hrl[MaxBurstSize];
+  HrlogEntry hrl[2 * MaxBurstSize];
   uint16_t nHrls = 0;
+  bool hrlogActive = Hrlog_IsActive();
 
   TscTime now = rte_get_tsc_cycles();
   for (uint16_t i = 0; i < count; ++i) {
@@ -43,16 +128,23 @@ TxLoop_Transfer(Face* face)
     TscDuration latency = now - Packet_ToMbuf(npkt)->timestamp;
     PktType framePktType = PktType_ToFull(Packet_GetType(npkt));
     RunningStat_Push1(&tx->latency[framePktType], latency);
-    switch (framePktType) {
-      case PktInterest:
-        hrl[nHrls++] = HrlogEntry_New(HRLOG_OI, latency);
-        break;
-      case PktData:
-        hrl[nHrls++] = HrlogEntry_New(
-          Packet_ToMbuf(npkt)->port == MBUF_INVALID_PORT ? HRLOG_OC : HRLOG_OD, latency);
-        break;
-      default:
-        break;
+    PktTrace_PostPacket(npkt, PktTraceStageTx, face->id, latency);
+    if (unlikely(hrlogActive)) {
+      TscDuration sojourn;
+      if (Hrlog_SinceMark(Packet_ToMbuf(npkt), now, &sojourn)) {
+        hrl[nHrls++] = HrlogEntry_New(HRLOG_TQ, sojourn);
+      }
+      switch (framePktType) {
+        case PktInterest:
+          hrl[nHrls++] = HrlogEntry_New(HRLOG_OI, latency);
+          break;
+        case PktData:
+          hrl[nHrls++] = HrlogEntry_New(
+            Packet_ToMbuf(npkt)->port == MBUF_INVALID_PORT ? HRLOG_OC : HRLOG_OD, latency);
+          break;
+        default:
+          break;
+      }
     }
 
     nFrames += TxProc_Output(tx, npkt, &frames[nFrames]);
@@ -65,9 +157,10 @@ TxLoop_Transfer(Face* face)
   if (likely(nFrames > 0)) {
     TxLoop_TxFrames(face, frames, nFrames);
   }
-  if (likely(nHrls > 0)) {
+  if (nHrls > 0) {
     Hrlog_PostBulk(hrl, nHrls);
   }
+  return count;
 }
 
 int
@@ -78,12 +171,14 @@ TxLoop_Run(TxLoop* txl)
     rcu_quiescent_state();
     rcu_read_lock();
 
+    uint64_t count = 0;
     Face* face;
     struct cds_hlist_node* pos;
     cds_hlist_for_each_entry_rcu (face, pos, &txl->head, txlNode) {
-      TxLoop_Transfer(face);
+      count += TxLoop_Transfer(face);
     }
     rcu_read_unlock();
+    ThreadLoadStat_Report(&txl->loadStat, count);
   }
   rcu_unregister_thread();
   return 0;
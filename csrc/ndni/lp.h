@@ -38,6 +38,8 @@ typedef struct LpL3
   uint64_t pitToken;
   uint8_t nackReason;
   uint8_t congMark;
+  uint8_t trafficClass; ///< TX traffic class, not encoded; zero means unspecified
+  uint32_t traceId;     ///< packet trace identifier, not encoded; zero means not traced
 } LpL3;
 
 /** @brief Parsed NDNLPv2 header. */
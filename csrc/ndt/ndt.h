@@ -12,10 +12,18 @@ typedef struct NdtThread
   uint16_t nHits[0];
 } NdtThread;
 
+enum
+{
+  /// Ndt.transit value of an element that is not in transition
+  NdtNoTransit = UINT8_MAX,
+};
+
 /** @brief The Name Dispatch Table (NDT). */
 typedef struct Ndt
 {
   _Atomic uint8_t* table;
+  _Atomic uint8_t* transit;  ///< new value of each element in transition, or NdtNoTransit
+  _Atomic uint32_t nTransit; ///< number of elements in transition
   uint64_t indexMask;
   uint64_t sampleMask;
   uint16_t prefixLen;
@@ -43,6 +51,53 @@ Ndt_Read(const Ndt* ndt, uint64_t index)
   return atomic_load_explicit(&ndt->table[index], memory_order_relaxed);
 }
 
+/**
+ * @brief Begin moving an NDT element to a new value.
+ *
+ * During the transition, the table entry keeps its old value, so that Interests continue to be
+ * dispatched to the old forwarding thread. That thread may hand off an Interest to the new value
+ * returned by Ndt_ReadTransit, if the Interest does not match an existing PIT entry.
+ */
+__attribute__((nonnull)) static inline void
+Ndt_BeginTransit(Ndt* ndt, uint64_t index, uint8_t value)
+{
+  NDNDPDK_ASSERT(index == (index & ndt->indexMask));
+  NDNDPDK_ASSERT(value != NdtNoTransit);
+  uint8_t old = atomic_exchange_explicit(&ndt->transit[index], value, memory_order_relaxed);
+  if (old == NdtNoTransit) {
+    atomic_fetch_add_explicit(&ndt->nTransit, 1, memory_order_release);
+  }
+}
+
+/** @brief Complete moving an NDT element, updating the table entry to the new value. */
+__attribute__((nonnull)) static inline void
+Ndt_EndTransit(Ndt* ndt, uint64_t index)
+{
+  NDNDPDK_ASSERT(index == (index & ndt->indexMask));
+  uint8_t value = atomic_load_explicit(&ndt->transit[index], memory_order_relaxed);
+  if (value == NdtNoTransit) {
+    return;
+  }
+  Ndt_Update(ndt, index, value);
+  atomic_store_explicit(&ndt->transit[index], NdtNoTransit, memory_order_relaxed);
+  atomic_fetch_sub_explicit(&ndt->nTransit, 1, memory_order_release);
+}
+
+/** @brief Determine whether any NDT element is in transition. */
+__attribute__((nonnull)) static __rte_always_inline bool
+Ndt_HasTransit(const Ndt* ndt)
+{
+  return atomic_load_explicit(&ndt->nTransit, memory_order_acquire) > 0;
+}
+
+/** @brief Read new value of an NDT element in transition, or NdtNoTransit. */
+__attribute__((nonnull)) static __rte_always_inline uint8_t
+Ndt_ReadTransit(const Ndt* ndt, uint64_t index)
+{
+  NDNDPDK_ASSERT(index == (index & ndt->indexMask));
+  return atomic_load_explicit(&ndt->transit[index], memory_order_relaxed);
+}
+
 /** @brief Query NDT without counting. */
 __attribute__((nonnull)) static inline uint8_t
 Ndt_Lookup(const Ndt* ndt, const PName* name, uint64_t* index)
@@ -4,6 +4,7 @@
 /** @file */
 
 #include "common.h"
+#include "cs-enum.h"
 
 /** @brief The prev-next pointers common in CsEntry and CsList. */
 typedef struct CsNode CsNode;
@@ -44,6 +45,29 @@ typedef enum CsArcListId
   CSL_ARC_DEL,
 } CsArcListId;
 
+/** @brief CS admission rule. */
+typedef struct CsAdmitRule
+{
+  uint64_t nAdmitted;
+  uint64_t nRejected;
+  uint64_t randThreshold; ///< admit if 32-bit random number is less than this
+  CsAdmitPolicy policy;
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+} CsAdmitRule;
+
+/**
+ * @brief CS admission policy table.
+ *
+ * Rules are sorted by descending prefix length. Data is admitted under the first rule whose prefix
+ * matches the Data name; if no rule matches, Data is admitted.
+ */
+typedef struct CsAdmit
+{
+  uint32_t nRules;
+  CsAdmitRule rules[CsMaxAdmitRules];
+} CsAdmit;
+
 /**
  * @brief The Content Store (CS).
  *
@@ -53,6 +77,7 @@ typedef struct Cs
 {
   CsArc direct;    ///< ARC lists of direct entries
   CsList indirect; ///< LRU list of indirect entries
+  CsAdmit* admit;  ///< admission policy, RCU protected; NULL admits all
 } Cs;
 
 #endif // NDNDPDK_PCCT_CS_STRUCT_H
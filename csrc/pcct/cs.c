@@ -2,6 +2,8 @@
 #include "pit.h"
 
 #include "../core/logger.h"
+#include "../core/urcu.h"
+#include <rte_random.h>
 
 INIT_ZF_LOG(Cs);
 
@@ -134,6 +136,55 @@ Cs_CountEntries(Cs* cs, CsListID cslId)
   return Cs_GetList_(cs, cslId)->count;
 }
 
+CsAdmit*
+Cs_SetAdmit(Cs* cs, CsAdmit* admit)
+{
+  ZF_LOGI("%p SetAdmit(%p) nRules=%" PRIu32, cs, admit, admit == NULL ? 0 : admit->nRules);
+  return rcu_xchg_pointer(&cs->admit, admit);
+}
+
+/** @brief Determine whether @p npkt should be admitted according to admission policy. */
+__attribute__((nonnull)) static __rte_always_inline bool
+Cs_Admit(Cs* cs, Packet* npkt, PitFindResult pitFound)
+{
+  CsAdmit* admit = rcu_dereference(cs->admit);
+  if (likely(admit == NULL)) {
+    return true;
+  }
+
+  LName name = PName_ToLName(&Packet_GetDataHdr(npkt)->name);
+  for (uint32_t i = 0; i < admit->nRules; ++i) {
+    CsAdmitRule* rule = &admit->rules[i];
+    if (LName_IsPrefix(LName_Init(rule->prefixL, rule->prefixV), name) < 0) {
+      continue;
+    }
+
+    bool ok = false;
+    switch (rule->policy) {
+      case CsAdmitAlways:
+        ok = true;
+        break;
+      case CsAdmitNever:
+        break;
+      case CsAdmitMustBeFresh:
+        ok = PitFindResult_Is(pitFound, PIT_FIND_PIT1);
+        break;
+      case CsAdmitRandom:
+        ok = (rte_rand() >> 32) < rule->randThreshold;
+        break;
+    }
+    ZF_LOGD("%p Admit(%p) rule=%" PRIu32 " policy=%d %s", cs, npkt, i, rule->policy,
+            ok ? "admit" : "reject");
+    if (ok) {
+      ++rule->nAdmitted;
+    } else {
+      ++rule->nRejected;
+    }
+    return ok;
+  }
+  return true;
+}
+
 /** @brief Add or refresh a direct entry for @p npkt in @p pccEntry . */
 static CsEntry*
 Cs_PutDirect(Cs* cs, Packet* npkt, PccEntry* pccEntry)
@@ -255,6 +306,15 @@ Cs_Insert(Cs* cs, Packet* npkt, PitFindResult pitFound)
   PInterest* interest = PitFindResult_GetInterest_(pitFound);
   CsEntry* direct = NULL;
 
+  if (unlikely(!Cs_Admit(cs, npkt, pitFound))) {
+    Pit_RawErase01_(&pcct->pit, pccEntry);
+    rte_pktmbuf_free(pkt);
+    if (likely(!pccEntry->hasCsEntry)) {
+      Pcct_Erase(pcct, pccEntry);
+    }
+    return;
+  }
+
   // if Interest name differs from Data name, insert a direct entry elsewhere
   if (unlikely(interest->name.nComps != data->name.nComps)) {
     direct = Cs_InsertDirect(cs, npkt, interest);
@@ -20,12 +20,22 @@ Cs_GetCapacity(Cs* cs, CsListID cslId);
 __attribute__((nonnull)) uint32_t
 Cs_CountEntries(Cs* cs, CsListID cslId);
 
+/**
+ * @brief Replace the admission policy table.
+ * @param admit new table allocated with rte_malloc, or NULL to admit all Data.
+ * @return old table; it may be freed after an RCU grace period.
+ */
+__attribute__((nonnull(1))) CsAdmit*
+Cs_SetAdmit(Cs* cs, CsAdmit* admit);
+
 /**
  * @brief Insert a CS entry.
  * @param npkt the Data packet. CS takes ownership.
  * @param pitFound result of Pit_FindByData that contains PIT entries
  *                 satisfied by this Data; its kind must not be PIT_FIND_NONE.
+ * @pre Calling thread is registered as RCU read-side thread.
  * @post PIT entries contained in @p pitFound are removed.
+ *       If the admission policy rejects the Data, it is released.
  */
 __attribute__((nonnull)) void
 Cs_Insert(Cs* cs, Packet* npkt, PitFindResult pitFound);
@@ -0,0 +1,88 @@
+#include "dead-nonce.h"
+
+#include "../core/logger.h"
+#include "../dpdk/hashtable.h"
+#include <rte_malloc.h>
+
+INIT_ZF_LOG(DeadNonceList);
+
+DeadNonceList*
+DeadNonceList_New(const char* id, uint32_t capacity, TscDuration lifetime, int numaSocket)
+{
+  capacity = rte_align32pow2(RTE_MAX(capacity, 64));
+  DeadNonceList* dnl = rte_zmalloc_socket(
+    "DeadNonceList", sizeof(DeadNonceList) + capacity * sizeof(DeadNonceRecord), 0, numaSocket);
+  if (unlikely(dnl == NULL)) {
+    return NULL;
+  }
+
+  dnl->ht = HashTable_New((struct rte_hash_parameters){
+    .name = id,
+    .entries = 2 * capacity, // keep occupancy under 50%
+    .key_len = sizeof(uint64_t),
+    .socket_id = numaSocket,
+  });
+  if (unlikely(dnl->ht == NULL)) {
+    rte_free(dnl);
+    return NULL;
+  }
+
+  dnl->lifetime = lifetime;
+  dnl->mask = capacity - 1;
+  ZF_LOGI("%p New() capacity=%" PRIu32 " lifetime=%" PRIu64, dnl, capacity, lifetime);
+  return dnl;
+}
+
+void
+DeadNonceList_Close(DeadNonceList* dnl)
+{
+  ZF_LOGI("%p Close()", dnl);
+  rte_hash_free(dnl->ht);
+  rte_free(dnl);
+}
+
+__attribute__((nonnull)) static inline void
+DeadNonceList_PopHead_(DeadNonceList* dnl)
+{
+  DeadNonceRecord* rec = &dnl->ring[dnl->head];
+  rte_hash_del_key(dnl->ht, &rec->key);
+  dnl->head = (dnl->head + 1) & dnl->mask;
+  --dnl->count;
+}
+
+void
+DeadNonceList_Expire_(DeadNonceList* dnl, TscTime now)
+{
+  while (dnl->count > 0 && dnl->ring[dnl->head].expiry <= now) {
+    DeadNonceList_PopHead_(dnl);
+  }
+}
+
+void
+DeadNonceList_Insert(DeadNonceList* dnl, uint64_t nameHash, uint32_t nonce, TscTime now)
+{
+  DeadNonceList_Expire_(dnl, now);
+
+  uint64_t key = DeadNonceList_MakeKey_(nameHash, nonce);
+  if (rte_hash_lookup(dnl->ht, &key) >= 0) {
+    return;
+  }
+
+  if (unlikely(dnl->count > dnl->mask)) {
+    DeadNonceList_PopHead_(dnl);
+    ++dnl->nEvict;
+  }
+
+  if (unlikely(rte_hash_add_key(dnl->ht, &key) < 0)) {
+    ZF_LOGW("%p Insert(%016" PRIx64 ") hashtable-full", dnl, key);
+    return;
+  }
+
+  uint32_t pos = (dnl->head + dnl->count) & dnl->mask;
+  dnl->ring[pos] = (const DeadNonceRecord){
+    .key = key,
+    .expiry = now + dnl->lifetime,
+  };
+  ++dnl->count;
+  ++dnl->nInsert;
+}
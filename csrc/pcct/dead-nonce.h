@@ -0,0 +1,87 @@
+#ifndef NDNDPDK_PCCT_DEAD_NONCE_H
+#define NDNDPDK_PCCT_DEAD_NONCE_H
+
+/** @file */
+
+#include "common.h"
+#include <rte_hash.h>
+
+/** @brief A record in the Dead Nonce List. */
+typedef struct DeadNonceRecord
+{
+  uint64_t key;   ///< combined name hash and nonce
+  TscTime expiry; ///< when this record expires
+} DeadNonceRecord;
+
+/**
+ * @brief Dead Nonce List, a bounded time-expiring set of (name hash, nonce).
+ *
+ * Records are kept in a FIFO ring in insertion order. Since every record has the same lifetime,
+ * the oldest record is also the earliest to expire. When the ring is full, the oldest record is
+ * evicted before its expiry.
+ */
+typedef struct DeadNonceList
+{
+  struct rte_hash* ht;  ///< set of keys
+  TscDuration lifetime; ///< record lifetime
+  uint32_t mask;        ///< ring capacity minus one
+  uint32_t head;        ///< ring position of oldest record
+  uint32_t count;       ///< number of records
+
+  uint64_t nInsert; ///< how many records were inserted
+  uint64_t nEvict;  ///< how many records were evicted before expiry
+  uint64_t nHit;    ///< how many lookups found a record
+
+  DeadNonceRecord ring[];
+} DeadNonceList;
+
+/**
+ * @brief Create a Dead Nonce List.
+ * @param id memzone identifier, must be unique.
+ * @param capacity maximum number of records, will be rounded up to power of 2.
+ * @param lifetime record lifetime.
+ * @return the Dead Nonce List.
+ * @retval NULL error. Error code is in @c rte_errno .
+ */
+DeadNonceList*
+DeadNonceList_New(const char* id, uint32_t capacity, TscDuration lifetime, int numaSocket);
+
+/** @brief Destroy a Dead Nonce List. */
+__attribute__((nonnull)) void
+DeadNonceList_Close(DeadNonceList* dnl);
+
+__attribute__((nonnull)) void
+DeadNonceList_Expire_(DeadNonceList* dnl, TscTime now);
+
+static __rte_always_inline uint64_t
+DeadNonceList_MakeKey_(uint64_t nameHash, uint32_t nonce)
+{
+  return nameHash ^ ((uint64_t)nonce * UINT64_C(0x9E3779B97F4A7C15));
+}
+
+/**
+ * @brief Insert (name hash, nonce) into the Dead Nonce List.
+ *
+ * If the same record exists, its expiry is not extended.
+ */
+__attribute__((nonnull)) void
+DeadNonceList_Insert(DeadNonceList* dnl, uint64_t nameHash, uint32_t nonce, TscTime now);
+
+/** @brief Determine whether (name hash, nonce) exists in the Dead Nonce List. */
+__attribute__((nonnull)) static inline bool
+DeadNonceList_Has(DeadNonceList* dnl, uint64_t nameHash, uint32_t nonce, TscTime now)
+{
+  DeadNonceList_Expire_(dnl, now);
+  if (likely(dnl->count == 0)) {
+    return false;
+  }
+
+  uint64_t key = DeadNonceList_MakeKey_(nameHash, nonce);
+  if (likely(rte_hash_lookup(dnl->ht, &key) < 0)) {
+    return false;
+  }
+  ++dnl->nHit;
+  return true;
+}
+
+#endif // NDNDPDK_PCCT_DEAD_NONCE_H
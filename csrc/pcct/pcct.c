@@ -2,6 +2,7 @@
 
 #include "cs.h"
 #include "pit.h"
+#include "walk.h"
 
 #include "../core/logger.h"
 #include "../dpdk/hashtable.h"
@@ -60,6 +61,12 @@ Pcct_Clear(Pcct* pcct)
   if (pcct->tokenHt != NULL) {
     rte_hash_free(pcct->tokenHt);
   }
+  if (pcct->pit.dnl != NULL) {
+    DeadNonceList_Close(pcct->pit.dnl);
+    pcct->pit.dnl = NULL;
+  }
+  rte_free(pcct->cs.admit);
+  pcct->cs.admit = NULL;
   HASH_CLEAR(hh, pcct->keyHt);
 }
 
@@ -97,6 +104,15 @@ Pcct_Insert(Pcct* pcct, PccSearch* search, bool* isNew)
   return entry;
 }
 
+PccEntry*
+Pcct_Find(Pcct* pcct, PccSearch* search)
+{
+  uint64_t hash = PccSearch_ComputeHash(search);
+  PccEntry* entry = NULL;
+  HASH_FIND_BYHASHVALUE(hh, pcct->keyHt, search, 0, hash, entry);
+  return entry;
+}
+
 void
 Pcct_Erase(Pcct* pcct, PccEntry* entry)
 {
@@ -173,6 +189,10 @@ PcctEraseBatch_EraseBurst_(PcctEraseBatch* peb)
     ZF_LOGD("%p Erase(%p)", peb->pcct, entry);
     NDNDPDK_ASSERT(!entry->hasEntries);
     Pcct_RemoveToken(peb->pcct, entry);
+    PcctWalk* walk = peb->pcct->walk;
+    if (unlikely(walk != NULL) && walk->cursor == entry) {
+      walk->cursor = entry->hh.next;
+    }
     HASH_DELETE(hh, peb->pcct->keyHt, entry);
 
     nObjs += PccKey_StripExts(&entry->key, (PccKeyExt**)&peb->objs[nObjs]);
@@ -7,6 +7,8 @@
 #include "pcc-entry.h"
 #include "pit-struct.h"
 
+typedef struct PcctWalk PcctWalk;
+
 /** @brief The PIT-CS Composite Table (PCCT). */
 typedef struct Pcct
 {
@@ -18,6 +20,7 @@ typedef struct Pcct
   Pit pit;
   Cs cs;
 
+  PcctWalk* walk; ///< in-progress walk
   uint32_t nKeyHtBuckets;
 } Pcct;
 
@@ -53,6 +56,13 @@ Pcct_Clear(Pcct* pcct);
 __attribute__((nonnull)) PccEntry*
 Pcct_Insert(Pcct* pcct, PccSearch* search, bool* isNew);
 
+/**
+ * @brief Find an entry.
+ * @return the entry, or NULL if it does not exist.
+ */
+__attribute__((nonnull)) PccEntry*
+Pcct_Find(Pcct* pcct, PccSearch* search);
+
 /**
  * @brief Erase an entry.
  * @sa PcctEraseBatch
@@ -112,7 +112,7 @@ PitEntry_Timeout_(MinTmr* tmr, void* pit0)
     Pit_InvokeSgTimerCb_(pit, entry);
   } else {
     ZF_LOGD("%p Timeout() reason=expiry", entry);
-    Pit_Erase(pit, entry);
+    Pit_Expire(pit, entry);
   }
 }
 
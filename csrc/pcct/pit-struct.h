@@ -3,7 +3,7 @@
 
 /** @file */
 
-#include "common.h"
+#include "dead-nonce.h"
 
 typedef struct Pit Pit;
 
@@ -31,6 +31,8 @@ struct Pit
   uint64_t nNackHit;  ///< how many find-by-Nack found PIT entry
   uint64_t nNackMiss; ///< how many find-by-Nack did not find PIT entry
 
+  DeadNonceList* dnl; ///< Dead Nonce List, NULL if disabled
+
   MinSched* timeoutSched;
   Pit_SgTimerCb sgTimerCb;
   void* sgTimerCbArg;
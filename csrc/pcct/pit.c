@@ -2,6 +2,7 @@
 
 #include "../core/logger.h"
 #include "cs.h"
+#include "pit-iterator.h"
 
 INIT_ZF_LOG(Pit);
 
@@ -111,6 +112,36 @@ Pit_Insert(Pit* pit, Packet* npkt, const FibEntry* fibEntry)
   return PitResult_New_(pccEntry, resKind);
 }
 
+__attribute__((nonnull)) static void
+Pit_AddDeadNonces_(Pit* pit, PitEntry* entry)
+{
+  if (pit->dnl == NULL || unlikely(entry->npkt == NULL)) {
+    return;
+  }
+
+  PInterest* interest = Packet_GetInterestHdr(entry->npkt);
+  uint64_t nameHash = PName_ComputeHash(&interest->name);
+  TscTime now = rte_get_tsc_cycles();
+
+  PitDnIt dnIt;
+  for (PitDnIt_Init(&dnIt, entry); PitDnIt_Valid(&dnIt); PitDnIt_Next(&dnIt)) {
+    if (dnIt.dn->face == 0) {
+      break;
+    }
+    DeadNonceList_Insert(pit->dnl, nameHash, dnIt.dn->nonce, now);
+  }
+
+  PitUpIt upIt;
+  for (PitUpIt_Init(&upIt, entry); PitUpIt_Valid(&upIt); PitUpIt_Next(&upIt)) {
+    if (upIt.up->face == 0) {
+      break;
+    }
+    if (upIt.up->nTx > 0) {
+      DeadNonceList_Insert(pit->dnl, nameHash, upIt.up->nonce, now);
+    }
+  }
+}
+
 void
 Pit_Erase(Pit* pit, PitEntry* entry)
 {
@@ -134,6 +165,13 @@ Pit_Erase(Pit* pit, PitEntry* entry)
   }
 }
 
+void
+Pit_Expire(Pit* pit, PitEntry* entry)
+{
+  Pit_AddDeadNonces_(pit, entry);
+  Pit_Erase(pit, entry);
+}
+
 void
 Pit_RawErase01_(Pit* pit, PccEntry* pccEntry)
 {
@@ -193,6 +231,23 @@ Pit_FindByData(Pit* pit, Packet* npkt)
   return PitResult_New_(pccEntry, flags);
 }
 
+PitEntry*
+Pit_FindByInterest(Pit* pit, Packet* npkt)
+{
+  PInterest* interest = Packet_GetInterestHdr(npkt);
+  PccSearch search;
+  PccSearch_FromNames(&search, &interest->name, interest);
+
+  PccEntry* pccEntry = Pcct_Find(Pcct_FromPit(pit), &search);
+  if (pccEntry == NULL) {
+    return NULL;
+  }
+  if (interest->mustBeFresh) {
+    return pccEntry->hasPitEntry1 ? PccEntry_GetPitEntry1(pccEntry) : NULL;
+  }
+  return pccEntry->hasPitEntry0 ? PccEntry_GetPitEntry0(pccEntry) : NULL;
+}
+
 PitEntry*
 Pit_FindByNack(Pit* pit, Packet* npkt)
 {
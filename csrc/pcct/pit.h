@@ -7,7 +7,7 @@
 #include "pit-result.h"
 
 /** @brief Maximum PIT entry lifetime (millis). */
-#define PIT_MAX_LIFETIME 120000
+#define PIT_MAX_LIFETIME MaxInterestLifetime
 
 /** @brief Constructor. */
 void
@@ -30,6 +30,24 @@ Pit_InvokeSgTimerCb_(Pit* pit, PitEntry* entry)
   (*pit->sgTimerCb)(pit, entry, pit->sgTimerCbArg);
 }
 
+/**
+ * @brief Determine whether an Interest carries a nonce in the Dead Nonce List.
+ * @param npkt Interest packet.
+ *
+ * The Dead Nonce List contains DN and UP nonces of recently erased PIT entries.
+ * A match indicates the Interest is looping.
+ */
+__attribute__((nonnull)) static inline bool
+Pit_HasDeadNonce(Pit* pit, Packet* npkt)
+{
+  if (pit->dnl == NULL) {
+    return false;
+  }
+  PInterest* interest = Packet_GetInterestHdr(npkt);
+  return DeadNonceList_Has(pit->dnl, PName_ComputeHash(&interest->name), interest->nonce,
+                           rte_get_tsc_cycles());
+}
+
 /**
  * @brief Insert or find a PIT entry for the given Interest.
  * @param npkt Interest packet.
@@ -47,6 +65,16 @@ Pit_InvokeSgTimerCb_(Pit* pit, PitEntry* entry)
 __attribute__((nonnull)) PitInsertResult
 Pit_Insert(Pit* pit, Packet* npkt, const FibEntry* fibEntry);
 
+/**
+ * @brief Find an existing PIT entry that would aggregate an Interest.
+ * @param npkt Interest packet.
+ * @return PIT entry, or NULL if it does not exist.
+ *
+ * Unlike Pit_Insert, this does not create any entry or check the CS.
+ */
+__attribute__((nonnull)) PitEntry*
+Pit_FindByInterest(Pit* pit, Packet* npkt);
+
 /**
  * @brief Erase a PIT entry.
  * @post @p entry is no longer valid.
@@ -54,6 +82,15 @@ Pit_Insert(Pit* pit, Packet* npkt, const FibEntry* fibEntry);
 __attribute__((nonnull)) void
 Pit_Erase(Pit* pit, PitEntry* entry);
 
+/**
+ * @brief Erase a PIT entry that expired without being satisfied.
+ * @post @p entry is no longer valid.
+ *
+ * Nonces of the entry are inserted into the Dead Nonce List.
+ */
+__attribute__((nonnull)) void
+Pit_Expire(Pit* pit, PitEntry* entry);
+
 /** @brief Erase both PIT entries on a PccEntry but retain the PccEntry. */
 __attribute__((nonnull)) void
 Pit_RawErase01_(Pit* pit, PccEntry* pccEntry);
@@ -0,0 +1,203 @@
+#include "walk.h"
+
+#include "../core/logger.h"
+#include "cs.h"
+#include "pit-iterator.h"
+
+INIT_ZF_LOG(PcctWalk);
+
+__attribute__((nonnull(1, 3))) static uint16_t
+PcctWalk_CopyField_(uint8_t* dst, uint16_t length, const uint8_t* firstV, uint16_t firstCapacity,
+                    const PccKeyExt* ext)
+{
+  rte_memcpy(dst, firstV, RTE_MIN(length, firstCapacity));
+  for (uint16_t offset = firstCapacity; offset < length; offset += PccKeyExtCapacity) {
+    NDNDPDK_ASSERT(ext != NULL);
+    rte_memcpy(RTE_PTR_ADD(dst, offset), ext->value, RTE_MIN(length - offset, PccKeyExtCapacity));
+    ext = ext->next;
+  }
+  return length;
+}
+
+/**
+ * @brief Copy PCC key into next record, and determine whether it matches the prefix.
+ * @return the record, or NULL if it does not match.
+ */
+__attribute__((nonnull)) static PcctWalkRecord*
+PcctWalk_MatchKey_(PcctWalk* walk, PccEntry* entry)
+{
+  const PccKey* key = &entry->key;
+  if (key->nameL < walk->prefixL) {
+    return NULL;
+  }
+
+  PcctWalkRecord* rec = &walk->records[walk->nRecords];
+  rec->nameL =
+    PcctWalk_CopyField_(rec->nameV, key->nameL, key->nameV, PccKeyNameCapacity, key->nameExt);
+  if (memcmp(rec->nameV, walk->prefixV, walk->prefixL) != 0) {
+    return NULL;
+  }
+  rec->fhL = PcctWalk_CopyField_(rec->fhV, key->fhL, key->fhV, PccKeyFhCapacity, key->fhExt);
+  return rec;
+}
+
+/** @brief Commit the next record unless it should be skipped. */
+__attribute__((nonnull)) static inline void
+PcctWalk_Commit_(PcctWalk* walk)
+{
+  if (walk->skip > 0) {
+    --walk->skip;
+    return;
+  }
+  ++walk->nRecords;
+}
+
+__attribute__((nonnull)) static void
+PcctWalk_FillPit_(PcctWalkPitRecord* r, PitEntry* entry)
+{
+  r->expiry = entry->expiry;
+  r->mustBeFresh = entry->mustBeFresh;
+  r->hasSgTimer = entry->hasSgTimer;
+
+  r->nDns = 0;
+  PitDnIt dnIt;
+  for (PitDnIt_Init(&dnIt, entry); PitDnIt_Valid(&dnIt); PitDnIt_Next(&dnIt)) {
+    if (dnIt.dn->face == 0) {
+      break;
+    }
+    if (r->nDns < PcctWalkMaxDns) {
+      r->dns[r->nDns] = *dnIt.dn;
+    }
+    ++r->nDns;
+  }
+
+  r->nUps = 0;
+  PitUpIt upIt;
+  for (PitUpIt_Init(&upIt, entry); PitUpIt_Valid(&upIt); PitUpIt_Next(&upIt)) {
+    if (upIt.up->face == 0) {
+      break;
+    }
+    if (r->nUps < PcctWalkMaxUps) {
+      r->ups[r->nUps] = *upIt.up;
+    }
+    ++r->nUps;
+  }
+}
+
+__attribute__((nonnull)) static void
+PcctWalk_VisitPit_(PcctWalk* walk, PccEntry* entry)
+{
+  if (!entry->hasPitEntries) {
+    return;
+  }
+  PcctWalkRecord* first = PcctWalk_MatchKey_(walk, entry);
+  if (first == NULL) {
+    return;
+  }
+
+  PitEntry* pitEntries[2] = {
+    entry->hasPitEntry0 ? PccEntry_GetPitEntry0(entry) : NULL,
+    entry->hasPitEntry1 ? PccEntry_GetPitEntry1(entry) : NULL,
+  };
+  for (int i = 0; i < 2; ++i) {
+    if (pitEntries[i] == NULL || walk->nRecords >= walk->limit) {
+      continue;
+    }
+    PcctWalkRecord* rec = &walk->records[walk->nRecords];
+    if (rec != first) { // second record on same PCC entry
+      rec->nameL = first->nameL;
+      rte_memcpy(rec->nameV, first->nameV, first->nameL);
+      rec->fhL = first->fhL;
+      rte_memcpy(rec->fhV, first->fhV, first->fhL);
+    }
+    rec->pit.token = entry->hasToken ? entry->token : 0;
+    PcctWalk_FillPit_(&rec->pit, pitEntries[i]);
+    PcctWalk_Commit_(walk);
+  }
+}
+
+__attribute__((nonnull)) static void
+PcctWalk_VisitCs_(PcctWalk* walk, PccEntry* entry)
+{
+  if (!entry->hasCsEntry) {
+    return;
+  }
+  CsEntry* csEntry = PccEntry_GetCsEntry(entry);
+  CsEntry* direct = CsEntry_GetDirect(csEntry);
+  if (direct->arcList != CSL_ARC_T1 && direct->arcList != CSL_ARC_T2) {
+    return; // ghost entry without Data
+  }
+
+  PcctWalkRecord* rec = PcctWalk_MatchKey_(walk, entry);
+  if (rec == NULL) {
+    return;
+  }
+  rec->cs = (const PcctWalkCsRecord){
+    .freshUntil = direct->freshUntil,
+    .dataLen = Packet_ToMbuf(direct->data)->pkt_len,
+    .nIndirects = csEntry->nIndirects,
+    .arcList = direct->arcList,
+  };
+  PcctWalk_Commit_(walk);
+}
+
+__attribute__((nonnull)) static void
+PcctWalk_VisitCsErase_(Pcct* pcct, PcctWalk* walk, PccEntry* entry)
+{
+  if (!entry->hasCsEntry) {
+    return;
+  }
+  CsEntry* csEntry = PccEntry_GetCsEntry(entry);
+  uint32_t nErased = 1;
+  if (CsEntry_IsDirect(csEntry)) {
+    if (csEntry->arcList != CSL_ARC_T1 && csEntry->arcList != CSL_ARC_T2) {
+      return; // ghost entry without Data
+    }
+    nErased += csEntry->nIndirects;
+  }
+
+  if (PcctWalk_MatchKey_(walk, entry) == NULL) {
+    return;
+  }
+  Cs_Erase(&pcct->cs, csEntry);
+  walk->nErased += nErased;
+}
+
+bool
+PcctWalk_Run(Pcct* pcct, PcctWalk* walk, uint32_t budget)
+{
+  if (!walk->started) {
+    ZF_LOGD("%p Run(%p) start kind=%d skip=%" PRIu32 " limit=%" PRIu32, pcct, walk, walk->kind,
+            walk->skip, walk->limit);
+    walk->started = true;
+    walk->now = rte_get_tsc_cycles();
+    walk->cursor = pcct->keyHt;
+    walk->nRecords = 0;
+    walk->nErased = 0;
+    pcct->walk = walk;
+  }
+
+  for (uint32_t i = 0; i < budget; ++i) {
+    PccEntry* entry = walk->cursor;
+    if (entry == NULL || walk->nRecords >= walk->limit) {
+      ZF_LOGD("%p Run(%p) finish nRecords=%" PRIu32 " nErased=%" PRIu32, pcct, walk,
+              walk->nRecords, walk->nErased);
+      pcct->walk = NULL;
+      return true;
+    }
+    walk->cursor = entry->hh.next;
+
+    switch (walk->kind) {
+      case PcctWalkPit:
+        PcctWalk_VisitPit_(walk, entry);
+        break;
+      case PcctWalkCs:
+        PcctWalk_VisitCs_(walk, entry);
+        break;
+      case PcctWalkCsErase:
+        PcctWalk_VisitCsErase_(pcct, walk, entry);
+        break;
+    }
+  }
+  return false;
+}
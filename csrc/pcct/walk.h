@@ -0,0 +1,99 @@
+#ifndef NDNDPDK_PCCT_WALK_H
+#define NDNDPDK_PCCT_WALK_H
+
+/** @file */
+
+#include "pcct.h"
+
+/** @brief Which entries should be collected by PcctWalk. */
+typedef enum PcctWalkKind
+{
+  PcctWalkPit = 1,     ///< collect PIT entries
+  PcctWalkCs = 2,      ///< collect CS entries that contain Data
+  PcctWalkCsErase = 3, ///< erase CS entries that contain Data, and indirect CS entries
+} PcctWalkKind;
+
+enum
+{
+  PcctWalkMaxDns = PitMaxDns + PitMaxExtDns,
+  PcctWalkMaxUps = PitMaxUps + PitMaxExtUps,
+};
+
+/** @brief Snapshot of a PIT entry. */
+typedef struct PcctWalkPitRecord
+{
+  PitDn dns[PcctWalkMaxDns];
+  PitUp ups[PcctWalkMaxUps];
+  uint64_t token;
+  TscTime expiry;
+  uint16_t nDns; ///< number of DN records, may exceed PcctWalkMaxDns
+  uint16_t nUps; ///< number of UP records, may exceed PcctWalkMaxUps
+  bool mustBeFresh;
+  bool hasSgTimer;
+} PcctWalkPitRecord;
+
+/** @brief Snapshot of a CS entry. */
+typedef struct PcctWalkCsRecord
+{
+  TscTime freshUntil;
+  uint32_t dataLen;   ///< Data packet length
+  int8_t nIndirects;  ///< number of indirect entries, or -1 if this entry is indirect
+  uint8_t arcList;    ///< CsArcListId of the direct entry
+} PcctWalkCsRecord;
+
+/** @brief Snapshot of a PIT or CS entry. */
+typedef struct PcctWalkRecord
+{
+  union
+  {
+    PcctWalkPitRecord pit;
+    PcctWalkCsRecord cs;
+  };
+  uint16_t nameL;
+  uint16_t fhL;
+  uint8_t nameV[NameMaxLength];
+  uint8_t fhV[NameMaxLength];
+} PcctWalkRecord;
+
+/**
+ * @brief Request to collect a page of PIT or CS entries under a name prefix.
+ *
+ * The walk visits PCC entries in insertion order. It is performed incrementally by the thread
+ * that owns the PCCT, visiting a bounded number of PCC entries in each @c PcctWalk_Run call.
+ *
+ * A @c PcctWalkCsErase walk does not collect records; it needs one record as scratch space.
+ */
+typedef struct PcctWalk
+{
+  PccEntry* cursor; ///< (pvt) next PCC entry to visit
+  TscTime now;      ///< when the walk started
+  PcctWalkKind kind;
+  uint32_t skip;     ///< number of matching entries to skip
+  uint32_t limit;    ///< maximum number of records
+  uint32_t nRecords; ///< number of collected records
+  uint32_t nErased;  ///< number of erased CS entries
+  bool started;      ///< (pvt) whether the walk has started
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+  PcctWalkRecord records[];
+} PcctWalk;
+
+/** @brief Access i-th record. */
+__attribute__((nonnull, returns_nonnull)) static inline PcctWalkRecord*
+PcctWalk_GetRecord(PcctWalk* walk, uint32_t i)
+{
+  return &walk->records[i];
+}
+
+/**
+ * @brief Continue a walk.
+ * @param budget maximum number of PCC entries to visit.
+ * @retval true the walk has completed.
+ * @retval false the walk should be continued in another call.
+ * @pre Calling thread owns @p pcct .
+ * @pre At most one walk is in progress on @p pcct .
+ */
+__attribute__((nonnull)) bool
+PcctWalk_Run(Pcct* pcct, PcctWalk* walk, uint32_t budget);
+
+#endif // NDNDPDK_PCCT_WALK_H
@@ -42,6 +42,7 @@ PingClientRx_ProcessData(PingClientRx* cr, Packet* npkt)
   PingTime recvTime = PingTime_FromTsc(Packet_ToMbuf(npkt)->timestamp);
   PingTime sendTime = PingToken_GetTimestamp(token);
   RunningStat_Push(&pattern->rtt, recvTime - sendTime);
+  QuantileSketch_Push(&pattern->rttQuantiles, recvTime - sendTime);
 }
 
 __attribute__((nonnull)) static void
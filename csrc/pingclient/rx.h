@@ -5,6 +5,7 @@
 
 #include "common.h"
 
+#include "../core/quantile-sketch.h"
 #include "../core/running-stat.h"
 #include "../dpdk/thread.h"
 #include "../iface/pktqueue.h"
@@ -15,6 +16,7 @@ typedef struct PingClientRxPattern
   uint64_t nData;
   uint64_t nNacks;
   RunningStat rtt;
+  QuantileSketch rttQuantiles;
   uint16_t prefixLen;
 } PingClientRxPattern;
 
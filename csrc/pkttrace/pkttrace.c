@@ -0,0 +1,61 @@
+#include "pkttrace.h"
+
+#include <rte_lcore.h>
+#include <rte_memcpy.h>
+#include <rte_random.h>
+
+PktTrace thePktTrace;
+
+PktTraceFilter*
+PktTrace_SetFilter(PktTraceFilter* filter)
+{
+  return rcu_xchg_pointer(&thePktTrace.filter, filter);
+}
+
+uint32_t
+PktTrace_Rx_(PktTraceFilter* filter, Packet* npkt, const PName* name)
+{
+  if (LName_IsPrefix(LName_Init(filter->prefixL, filter->prefixV), PName_ToLName(name)) < 0 ||
+      rte_rand() > filter->sampleThreshold) {
+    return 0;
+  }
+
+  uint32_t id = __atomic_add_fetch(&thePktTrace.lastId, 1, __ATOMIC_RELAXED);
+  if (unlikely(id == 0)) { // skip zero after wraparound
+    id = __atomic_add_fetch(&thePktTrace.lastId, 1, __ATOMIC_RELAXED);
+  }
+  PktTrace_Post_(id, PktTraceStageRx, PktType_ToFull(Packet_GetType(npkt)),
+                 Packet_ToMbuf(npkt)->port, 0, name);
+  return id;
+}
+
+void
+PktTrace_Post_(uint32_t id, PktTraceStage stage, PktType pktType, FaceID face, uint64_t value,
+               const PName* name)
+{
+  PktTraceRecord* rec = NULL;
+  if (unlikely(thePktTrace.mp == NULL || rte_mempool_get(thePktTrace.mp, (void**)&rec) != 0)) {
+    __atomic_fetch_add(&thePktTrace.nDropped, 1, __ATOMIC_RELAXED);
+    return;
+  }
+
+  rec->time = rte_get_tsc_cycles();
+  rec->value = value;
+  rec->id = id;
+  rec->face = face;
+  rec->stage = stage;
+  rec->pktType = pktType;
+  rec->lcore = rte_lcore_id();
+  rec->nameL = 0;
+  if (name != NULL) {
+    rec->nameL = RTE_MIN(name->length, (uint16_t)PktTraceNameMax);
+    rte_memcpy(rec->name, name->value, rec->nameL);
+  }
+
+  if (unlikely(rte_ring_enqueue(thePktTrace.ring, rec) != 0)) {
+    rte_mempool_put(thePktTrace.mp, rec);
+    __atomic_fetch_add(&thePktTrace.nDropped, 1, __ATOMIC_RELAXED);
+    return;
+  }
+  __atomic_fetch_add(&thePktTrace.nRecords, 1, __ATOMIC_RELAXED);
+}
@@ -0,0 +1,103 @@
+#ifndef NDNDPDK_PKTTRACE_PKTTRACE_H
+#define NDNDPDK_PKTTRACE_PKTTRACE_H
+
+/** @file */
+
+#include "../core/urcu.h"
+#include "../dpdk/tsc.h"
+#include "../iface/faceid.h"
+#include "../ndni/packet.h"
+#include "enum.h"
+
+#include <rte_mempool.h>
+#include <rte_ring.h>
+
+/** @brief Packet trace filter. */
+typedef struct PktTraceFilter
+{
+  uint64_t sampleThreshold; ///< trace a matching packet if rte_rand() <= sampleThreshold
+  uint16_t prefixL;
+  uint8_t prefixV[NameMaxLength];
+} PktTraceFilter;
+
+/** @brief Packet trace record. */
+typedef struct PktTraceRecord
+{
+  TscTime time;
+  uint64_t value; ///< stage specific value
+  uint32_t id;    ///< trace identifier
+  FaceID face;
+  uint8_t stage;   ///< PktTraceStage
+  uint8_t pktType; ///< PktType
+  uint8_t lcore;
+  uint8_t nameL;                 ///< name length, at most PktTraceNameMax
+  uint8_t name[PktTraceNameMax]; ///< name TLV-VALUE, possibly truncated
+} PktTraceRecord;
+static_assert(sizeof(PktTraceRecord) == 128, "");
+
+/** @brief Packet tracer. */
+typedef struct PktTrace
+{
+  struct rte_ring* ring;  ///< queue of PktTraceRecord* toward the collector
+  struct rte_mempool* mp; ///< mempool of PktTraceRecord
+  PktTraceFilter* filter; ///< RCU protected; NULL disables tracing
+  uint32_t lastId;        ///< last assigned trace identifier
+  uint64_t nRecords;      ///< records posted
+  uint64_t nDropped;      ///< records lost due to full ring or empty mempool
+} PktTrace;
+
+extern PktTrace thePktTrace;
+
+/**
+ * @brief Replace packet trace filter.
+ * @param filter new filter, or NULL to disable tracing.
+ * @return old filter, which may be freed after an RCU grace period.
+ */
+PktTraceFilter*
+PktTrace_SetFilter(PktTraceFilter* filter);
+
+__attribute__((nonnull)) uint32_t
+PktTrace_Rx_(PktTraceFilter* filter, Packet* npkt, const PName* name);
+
+void
+PktTrace_Post_(uint32_t id, PktTraceStage stage, PktType pktType, FaceID face, uint64_t value,
+               const PName* name);
+
+/**
+ * @brief Decide whether to trace an incoming packet.
+ * @param name packet name; Nack uses the Interest name.
+ * @pre Calling thread holds rcu_read_lock.
+ * @post If the packet is selected, its LpL3.traceId is set and a PktTraceStageRx record is posted.
+ */
+__attribute__((nonnull)) static __rte_always_inline void
+PktTrace_Rx(Packet* npkt, const PName* name)
+{
+  PktTraceFilter* filter = rcu_dereference(thePktTrace.filter);
+  if (likely(filter == NULL)) {
+    return;
+  }
+  Packet_GetLpL3Hdr(npkt)->traceId = PktTrace_Rx_(filter, npkt, name);
+}
+
+/**
+ * @brief Post a trace record.
+ * @param id trace identifier; zero means the packet is not traced, and nothing is posted.
+ */
+static __rte_always_inline void
+PktTrace_Post(uint32_t id, PktTraceStage stage, PktType pktType, FaceID face, uint64_t value)
+{
+  if (likely(id == 0)) {
+    return;
+  }
+  PktTrace_Post_(id, stage, pktType, face, value, NULL);
+}
+
+/** @brief Post a trace record if the packet is being traced. */
+__attribute__((nonnull)) static __rte_always_inline void
+PktTrace_PostPacket(Packet* npkt, PktTraceStage stage, FaceID face, uint64_t value)
+{
+  PktTrace_Post(Packet_GetLpL3Hdr(npkt)->traceId, stage, PktType_ToFull(Packet_GetType(npkt)),
+                face, value);
+}
+
+#endif // NDNDPDK_PKTTRACE_PKTTRACE_H
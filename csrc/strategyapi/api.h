@@ -100,6 +100,7 @@ typedef enum SgForwardInterestResult
   SGFWDI_NONONCE,    ///< upstream has rejected all nonces
   SGFWDI_SUPPRESSED, ///< forwarding is suppressed
   SGFWDI_HOPZERO,    ///< HopLimit has become zero
+  SGFWDI_DENIED,     ///< denied by outbound face ACL or scope control
 } SgForwardInterestResult;
 
 /**
@@ -0,0 +1,145 @@
+#include "test-ctx.h"
+
+void
+SgTestCtx_Init(SgTestCtx* ctx)
+{
+  ctx->global.tscHz = rte_get_tsc_hz();
+  ctx->inner.global = &ctx->global;
+  ctx->inner.pkt = &ctx->pkt;
+  ctx->inner.fibEntry = &ctx->fibEntry;
+  ctx->inner.fibEntryDyn = &ctx->fibEntryDyn;
+  ctx->inner.pitEntry = &ctx->pitEntry;
+  ctx->setTimerResult = true;
+}
+
+uint64_t
+SgTestCtx_Invoke(SgTestCtx* ctx, StrategyCode* sc)
+{
+  ctx->nCalls = 0;
+  return StrategyCode_Execute(sc, &ctx->inner, sizeof(SgCtx));
+}
+
+static SgTestCall*
+SgTestCtx_AddCall(SgTestCtx* ctx, SgTestCallKind kind)
+{
+  if (unlikely(ctx->nCalls >= SGTEST_MAX_CALLS)) {
+    return NULL;
+  }
+  SgTestCall* call = &ctx->calls[ctx->nCalls++];
+  *call = (const SgTestCall){ .kind = kind };
+  return call;
+}
+
+static bool
+SgTestCtx_SetTimer(SgCtx* ctx0, TscDuration after)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, inner);
+  SgTestCall* call = SgTestCtx_AddCall(ctx, SGTEST_CALL_SET_TIMER);
+  if (call != NULL) {
+    call->after = after;
+    call->result = ctx->setTimerResult;
+  }
+  return ctx->setTimerResult;
+}
+
+static SgForwardInterestResult
+SgTestCtx_ForwardInterest(SgCtx* ctx0, FaceID nh)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, inner);
+  SgForwardInterestResult res = SGFWDI_OK;
+  for (uint32_t i = 0; i < ctx->nFwdiResults; ++i) {
+    if (ctx->fwdiResults[i].nh == nh) {
+      res = ctx->fwdiResults[i].result;
+      break;
+    }
+  }
+
+  SgTestCall* call = SgTestCtx_AddCall(ctx, SGTEST_CALL_FORWARD_INTEREST);
+  if (call != NULL) {
+    call->nh = nh;
+    call->result = res;
+  }
+  return res;
+}
+
+static void
+SgTestCtx_ReturnNacks(SgCtx* ctx0, SgNackReason reason)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, inner);
+  SgTestCall* call = SgTestCtx_AddCall(ctx, SGTEST_CALL_RETURN_NACKS);
+  if (call != NULL) {
+    call->reason = reason;
+  }
+}
+
+const struct rte_bpf_xsym*
+SgTestCtx_GetXsyms(int* nXsyms)
+{
+  static const struct rte_bpf_xsym xsyms[] = {
+    {
+      .name = "SgSetTimer",
+      .type = RTE_BPF_XTYPE_FUNC,
+      .func =
+        {
+          .val = (void*)SgTestCtx_SetTimer,
+          .nb_args = 2,
+          .args =
+            {
+              [0] =
+                {
+                  .type = RTE_BPF_ARG_PTR,
+                  .size = sizeof(SgCtx),
+                },
+              [1] =
+                {
+                  .type = RTE_BPF_ARG_RAW,
+                },
+            },
+        },
+    },
+    {
+      .name = "SgForwardInterest",
+      .type = RTE_BPF_XTYPE_FUNC,
+      .func =
+        {
+          .val = (void*)SgTestCtx_ForwardInterest,
+          .nb_args = 2,
+          .args =
+            {
+              [0] =
+                {
+                  .type = RTE_BPF_ARG_PTR,
+                  .size = sizeof(SgCtx),
+                },
+              [1] =
+                {
+                  .type = RTE_BPF_ARG_RAW,
+                },
+            },
+        },
+    },
+    {
+      .name = "SgReturnNacks",
+      .type = RTE_BPF_XTYPE_FUNC,
+      .func =
+        {
+          .val = (void*)SgTestCtx_ReturnNacks,
+          .nb_args = 2,
+          .args =
+            {
+              [0] =
+                {
+                  .type = RTE_BPF_ARG_PTR,
+                  .size = sizeof(SgCtx),
+                },
+              [1] =
+                {
+                  .type = RTE_BPF_ARG_RAW,
+                },
+            },
+        },
+    },
+  };
+  *nXsyms = RTE_DIM(xsyms);
+  return xsyms;
+}
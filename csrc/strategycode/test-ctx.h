@@ -0,0 +1,75 @@
+#ifndef NDNDPDK_STRATEGYCODE_TEST_CTX_H
+#define NDNDPDK_STRATEGYCODE_TEST_CTX_H
+
+/** @file */
+
+#include "../strategyapi/api.h"
+#include "strategy-code.h"
+
+#define SGTEST_MAX_CALLS 64
+#define SGTEST_MAX_FWDI_RESULTS 16
+
+/** @brief Kind of strategy API call recorded by SgTestCtx. */
+typedef enum SgTestCallKind
+{
+  SGTEST_CALL_NONE,
+  SGTEST_CALL_SET_TIMER,        ///< SgSetTimer
+  SGTEST_CALL_FORWARD_INTEREST, ///< SgForwardInterest
+  SGTEST_CALL_RETURN_NACKS,     ///< SgReturnNacks
+} SgTestCallKind;
+
+/** @brief Recorded strategy API call. */
+typedef struct SgTestCall
+{
+  TscDuration after; ///< SgSetTimer duration
+  uint8_t kind;      ///< SgTestCallKind
+  uint8_t reason;    ///< SgReturnNacks reason
+  FaceID nh;         ///< SgForwardInterest nexthop
+  uint8_t result;    ///< SgForwardInterest result, or SgSetTimer success
+} SgTestCall;
+
+/** @brief Predetermined SgForwardInterest result toward a nexthop. */
+typedef struct SgTestFwdiResult
+{
+  FaceID nh;
+  uint8_t result; ///< SgForwardInterestResult
+} SgTestFwdiResult;
+
+/**
+ * @brief Synthetic strategy invocation context.
+ *
+ * This struct embeds SgCtx as its first field, so that mock strategy API functions can recover
+ * it from the SgCtx* passed by the strategy program.
+ */
+typedef struct SgTestCtx
+{
+  SgCtx inner;
+
+  SgGlobal global;
+  SgPacket pkt;
+  SgFibEntry fibEntry;
+  SgFibEntryDyn fibEntryDyn;
+  SgPitEntry pitEntry;
+  SgPitEntryExt pitEntryExt;
+
+  SgTestFwdiResult fwdiResults[SGTEST_MAX_FWDI_RESULTS];
+  uint32_t nFwdiResults;
+  bool setTimerResult;
+
+  uint32_t nCalls;
+  SgTestCall calls[SGTEST_MAX_CALLS];
+} SgTestCtx;
+
+/** @brief Initialize SgTestCtx pointers and default values. */
+__attribute__((nonnull)) void
+SgTestCtx_Init(SgTestCtx* ctx);
+
+/** @brief Execute strategy program, after clearing recorded calls. */
+__attribute__((nonnull)) uint64_t
+SgTestCtx_Invoke(SgTestCtx* ctx, StrategyCode* sc);
+
+/** @brief Obtain mock external symbols that record calls into SgTestCtx. */
+const struct rte_bpf_xsym*
+SgTestCtx_GetXsyms(int* nXsyms);
+
+#endif // NDNDPDK_STRATEGYCODE_TEST_CTX_H
@@ -0,0 +1,61 @@
+package ealthread
+
+/*
+#include "../../csrc/dpdk/thread.h"
+*/
+import "C"
+import (
+	"strconv"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+// LoadStat contains thread load statistics.
+type LoadStat struct {
+	// EmptyPolls is the number of polls that processed zero items.
+	EmptyPolls uint64 `json:"emptyPolls"`
+
+	// ValidPolls is the number of polls that processed at least one item.
+	ValidPolls uint64 `json:"validPolls"`
+
+	// Items is the number of processed items.
+	Items uint64 `json:"items"`
+}
+
+// ItemsPerPoll returns average number of processed items per valid poll.
+func (s LoadStat) ItemsPerPoll() float64 {
+	return float64(s.Items) / float64(s.ValidPolls)
+}
+
+// Sub computes the difference.
+func (s LoadStat) Sub(prev LoadStat) (diff LoadStat) {
+	diff.EmptyPolls = s.EmptyPolls - prev.EmptyPolls
+	diff.ValidPolls = s.ValidPolls - prev.ValidPolls
+	diff.Items = s.Items - prev.Items
+	return diff
+}
+
+// LoadStatFromPtr reads LoadStat from *C.ThreadLoadStat pointer.
+func LoadStatFromPtr(ptr unsafe.Pointer) (s LoadStat) {
+	c := (*C.ThreadLoadStat)(ptr)
+	s.EmptyPolls = uint64(c.nPolls[0])
+	s.ValidPolls = uint64(c.nPolls[1])
+	s.Items = uint64(c.nItems)
+	return s
+}
+
+// ThreadWithLoadStat is a thread that reports load statistics.
+type ThreadWithLoadStat interface {
+	Thread
+	ThreadLoadStat() LoadStat
+}
+
+// CollectMetrics adds thread load metrics.
+func CollectMetrics(w *metrics.Writer, th ThreadWithLoadStat, role string) {
+	labels := []string{"lcore", strconv.Itoa(th.LCore().ID()), "role", role}
+	s := th.ThreadLoadStat()
+	w.Counter("thread_polls_total", "Thread polls.", s.EmptyPolls, append(labels, "result", "empty")...)
+	w.Counter("thread_polls_total", "Thread polls.", s.ValidPolls, append(labels, "result", "valid")...)
+	w.Counter("thread_items_total", "Items processed by thread.", s.Items, labels...)
+}
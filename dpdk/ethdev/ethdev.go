@@ -87,6 +87,13 @@ func (port EthDev) DevInfo() (info DevInfo) {
 	return info
 }
 
+// HasTxChecksumOffload determines whether the hardware device supports IPv4 and UDP checksum offloads.
+// If supported, these offloads are enabled by Start with default configuration.
+func (info DevInfo) HasTxChecksumOffload() bool {
+	const offloads = C.DEV_TX_OFFLOAD_IPV4_CKSUM | C.DEV_TX_OFFLOAD_UDP_CKSUM
+	return info.Tx_offload_capa&offloads == offloads
+}
+
 // MacAddr retrieves MAC address of this EthDev.
 // If the underlying EthDev returns an invalid MAC address, a random MAC address is returned instead.
 func (port EthDev) MacAddr() (a net.HardwareAddr) {
@@ -133,7 +140,10 @@ func (port EthDev) Start(cfg Config) error {
 		conf = new(C.struct_rte_eth_conf)
 		conf.rxmode.max_rx_pkt_len = C.uint32_t(port.Mtu())
 		if info.Tx_offload_capa&C.DEV_TX_OFFLOAD_MULTI_SEGS != 0 {
-			conf.txmode.offloads = C.DEV_TX_OFFLOAD_MULTI_SEGS
+			conf.txmode.offloads |= C.DEV_TX_OFFLOAD_MULTI_SEGS
+		}
+		if info.HasTxChecksumOffload() {
+			conf.txmode.offloads |= C.DEV_TX_OFFLOAD_IPV4_CKSUM | C.DEV_TX_OFFLOAD_UDP_CKSUM
 		}
 	}
 
@@ -0,0 +1,166 @@
+package iface
+
+/*
+#include "../csrc/iface/face.h"
+*/
+import "C"
+import (
+	"errors"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Errors returned by SetAclRules.
+var (
+	ErrAclDir     = errors.New("invalid ACL direction")
+	ErrAclRules   = errors.New("too many ACL rules")
+	ErrAclPktType = errors.New("invalid packet type in ACL rule")
+	ErrAclPrefix  = errors.New("ACL rule prefix too long")
+)
+
+// AclRule is a face ACL rule.
+type AclRule struct {
+	Prefix ndn.Name `json:"prefix"`
+
+	// PktTypes lists packet types matched by this rule.
+	// Each element should be ndni.PktInterest, ndni.PktData, or ndni.PktNack.
+	// If empty, the rule matches all packet types.
+	PktTypes []ndni.PktType `json:"pktTypes,omitempty"`
+
+	// Allow determines whether matched packets are allowed or denied.
+	Allow bool `json:"allow"`
+}
+
+func (rule AclRule) copyToC(r *C.FaceAclRule) error {
+	pktTypes := rule.PktTypes
+	if len(pktTypes) == 0 {
+		pktTypes = []ndni.PktType{ndni.PktInterest, ndni.PktData, ndni.PktNack}
+	}
+	for _, t := range pktTypes {
+		switch t {
+		case ndni.PktInterest, ndni.PktData, ndni.PktNack:
+			r.pktTypes |= 1 << t
+		default:
+			return ErrAclPktType
+		}
+	}
+	r.allow = C.bool(rule.Allow)
+
+	prefixV, e := rule.Prefix.MarshalBinary()
+	if e != nil {
+		return e
+	}
+	if len(prefixV) > ndni.NameMaxLength {
+		return ErrAclPrefix
+	}
+	for i, b := range prefixV {
+		r.prefixV[i] = C.uint8_t(b)
+	}
+	r.prefixL = C.uint16_t(len(prefixV))
+	r.nComps = C.uint16_t(len(rule.Prefix))
+
+	pname := ndni.NewPName(rule.Prefix)
+	defer pname.Free()
+	r.hash = C.uint64_t(pname.ComputeHash())
+	return nil
+}
+
+// AclRuleInfo contains an ACL rule and its counters.
+type AclRuleInfo struct {
+	AclRule
+	NMatches uint64 `json:"nMatches"`
+}
+
+// AclRules returns ACL rules in a direction and their counters.
+func (f *face) AclRules(dir AclDir) (list []AclRuleInfo) {
+	f.aclMutex.Lock()
+	defer f.aclMutex.Unlock()
+
+	c := f.ptr()
+	if f.aclClosed || c.impl == nil || dir < AclInbound || dir > AclOutbound {
+		return nil
+	}
+	acl := c.impl.acl[dir]
+	if acl == nil {
+		return nil
+	}
+
+	for i := range acl.rules[:acl.nRules] {
+		r := &acl.rules[i]
+		var info AclRuleInfo
+		info.Prefix.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&r.prefixV[0]), C.int(r.prefixL)))
+		for _, t := range []ndni.PktType{ndni.PktInterest, ndni.PktData, ndni.PktNack} {
+			if r.pktTypes&(1<<t) != 0 {
+				info.PktTypes = append(info.PktTypes, t)
+			}
+		}
+		info.Allow = bool(r.allow)
+		info.NMatches = atomic.LoadUint64((*uint64)(unsafe.Pointer(&r.nMatches)))
+		list = append(list, info)
+	}
+	return list
+}
+
+// SetAclRules replaces ACL rules in a direction.
+// Rules are evaluated in order; a packet is allowed if no rule matches.
+// This function waits for an RCU grace period, and should not be called from an RCU read-side thread.
+func (f *face) SetAclRules(dir AclDir, rules []AclRule) error {
+	if dir < AclInbound || dir > AclOutbound {
+		return ErrAclDir
+	}
+	if len(rules) > MaxAclRules {
+		return ErrAclRules
+	}
+
+	var acl *C.FaceAcl
+	if len(rules) > 0 {
+		acl = (*C.FaceAcl)(eal.Zmalloc("FaceAcl", C.sizeof_FaceAcl, f.socket))
+		for i, rule := range rules {
+			if e := rule.copyToC(&acl.rules[i]); e != nil {
+				eal.Free(acl)
+				return e
+			}
+		}
+		acl.nRules = C.uint32_t(len(rules))
+	}
+
+	f.aclMutex.Lock()
+	defer f.aclMutex.Unlock()
+	if f.aclClosed {
+		if acl != nil {
+			eal.Free(acl)
+		}
+		return nil
+	}
+	f.replaceAcl(dir, acl)
+	return nil
+}
+
+// replaceAcl publishes an ACL and releases the old one.
+// Caller must hold aclMutex.
+func (f *face) replaceAcl(dir AclDir, acl *C.FaceAcl) {
+	if old := C.Face_SetAcl(C.FaceID(f.id), C.FaceAclDir(dir), acl); old != nil {
+		urcu.Synchronize()
+		eal.Free(old)
+	}
+}
+
+// closeAcls releases ACLs.
+func (f *face) closeAcls() {
+	f.aclMutex.Lock()
+	defer f.aclMutex.Unlock()
+	f.aclClosed = true
+
+	impl := f.ptr().impl
+	for dir := range impl.acl {
+		if impl.acl[dir] != nil {
+			eal.Free(impl.acl[dir])
+			impl.acl[dir] = nil
+		}
+	}
+}
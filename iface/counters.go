@@ -22,6 +22,9 @@ type Counters struct {
 	ReassPackets uint64 // RX packets that were reassembled
 	ReassDrops   uint64 // RX frames that were dropped by reassembler
 
+	ReassRedirects  uint64 // RX fragments handed off to reassembler thread
+	ReassQueueDrops uint64 // RX fragments dropped due to full handoff queue
+
 	RxInterests uint64 // RX Interest packets
 	RxData      uint64 // RX Data packets
 	RxNacks     uint64 // RX Nack packets
@@ -40,11 +43,13 @@ type Counters struct {
 	TxDropped   uint64 // L2 frames dropped due to full queue
 	TxFrames    uint64 // sent total frames
 	TxOctets    uint64 // sent total bytes
+
+	TxClasses [NTxClasses]TxClassCounters // per traffic class counters
 }
 
 func (cnt Counters) String() string {
-	return fmt.Sprintf("RX %dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop) TX %dfrm %db %dI %dD %dN frag=(%dgood %dbad) alloc=%derr %ddropped",
-		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.DecodeErrs, cnt.ReassPackets, cnt.ReassDrops,
+	return fmt.Sprintf("RX %dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop %dredirect %dqdrop) TX %dfrm %db %dI %dD %dN frag=(%dgood %dbad) alloc=%derr %ddropped",
+		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.DecodeErrs, cnt.ReassPackets, cnt.ReassDrops, cnt.ReassRedirects, cnt.ReassQueueDrops,
 		cnt.TxFrames, cnt.TxOctets, cnt.TxInterests, cnt.TxData, cnt.TxNacks, cnt.FragGood, cnt.FragBad, cnt.TxAllocErrs, cnt.TxDropped)
 }
 
@@ -56,17 +61,22 @@ func (f *face) ReadCounters() (cnt Counters) {
 	}
 
 	rxC := &c.impl.rx
-	for i := 0; i < C.RXPROC_MAX_THREADS; i++ {
-		rxtC := &rxC.threads[i]
+	for i := -1; i < C.RXPROC_MAX_THREADS; i++ {
+		rxtC := &rxC.reassThread
+		if i >= 0 {
+			rxtC = &rxC.threads[i]
+		}
 		cnt.RxOctets += uint64(rxtC.nFrames[0])
 		cnt.DecodeErrs += uint64(rxtC.nDecodeErr)
 		cnt.RxInterests += uint64(rxtC.nFrames[ndni.PktInterest])
 		cnt.RxData += uint64(rxtC.nFrames[ndni.PktData])
 		cnt.RxNacks += uint64(rxtC.nFrames[ndni.PktNack])
+		cnt.ReassRedirects += uint64(rxtC.nReassRedirect)
+		cnt.ReassQueueDrops += uint64(rxtC.nReassQueueDrop)
 	}
 	cnt.ReassPackets = uint64(rxC.reass.nDeliverPackets)
 	cnt.ReassDrops = uint64(rxC.reass.nDropFragments)
-	cnt.RxFrames = cnt.RxInterests + cnt.RxData + cnt.RxNacks + uint64(rxC.reass.nDeliverFragments) - cnt.ReassPackets + cnt.ReassDrops
+	cnt.RxFrames = cnt.RxInterests + cnt.RxData + cnt.RxNacks + uint64(rxC.reass.nDeliverFragments) - cnt.ReassPackets + cnt.ReassDrops + cnt.ReassQueueDrops
 
 	txC := &c.impl.tx
 	readLatencyStat := func(c *C.RunningStat) runningstat.Snapshot {
@@ -85,6 +95,7 @@ func (f *face) ReadCounters() (cnt Counters) {
 	cnt.TxDropped = uint64(txC.nDroppedFrames)
 	cnt.TxFrames = uint64(txC.nFrames - txC.nDroppedFrames)
 	cnt.TxOctets = uint64(txC.nOctets - txC.nDroppedOctets)
+	cnt.TxClasses = f.readTxClassCounters()
 
 	return cnt
 }
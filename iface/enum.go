@@ -19,12 +19,34 @@ const (
 	// DefaultReassemblerCapacity is the default partial message store capacity in the reassembler.
 	DefaultReassemblerCapacity = 64
 
+	// MinReassemblerQueueSize is the minimum capacity of the queue that hands off fragments to the reassembler thread.
+	MinReassemblerQueueSize = 64
+
+	// DefaultReassemblerQueueSize is the default capacity of the queue that hands off fragments to the reassembler thread.
+	DefaultReassemblerQueueSize = 1024
+
 	// MinOutputQueueSize is the minimum packet queue capacity before the output thread.
 	MinOutputQueueSize = 256
 
 	// DefaultOutputQueueSize is the default packet queue capacity before the output thread.
 	DefaultOutputQueueSize = 1024
 
+	// NTxClasses is the number of traffic classes on the send path.
+	NTxClasses = 4
+
+	// TxClassPriority is the strict priority traffic class.
+	// Other traffic classes are scheduled with deficit round robin.
+	TxClassPriority = NTxClasses - 1
+
+	// MaxTxClassRules is the maximum number of traffic classification rules on a face.
+	MaxTxClassRules = 16
+
+	// DefaultTxClassQuantum is the default deficit round robin quantum in octets.
+	DefaultTxClassQuantum = 8192
+
+	// MaxAclRules is the maximum number of ACL rules in each direction on a face.
+	MaxAclRules = 32
+
 	// MinMtu is the minimum value of Maximum Transmission Unit (MTU).
 	MinMtu = 1280
 
@@ -34,6 +56,27 @@ const (
 	_ = "enumgen"
 )
 
+// AclDir indicates the direction of a face ACL.
+type AclDir int
+
+// AclDir values.
+const (
+	AclInbound  AclDir = iota // packets received on the face
+	AclOutbound               // packets to be transmitted on the face
+
+	_ = "enumgen:FaceAclDir:Face"
+)
+
+func (dir AclDir) String() string {
+	switch dir {
+	case AclInbound:
+		return "inbound"
+	case AclOutbound:
+		return "outbound"
+	}
+	return strconv.Itoa(int(dir))
+}
+
 // State indicates face state.
 type State uint8
 
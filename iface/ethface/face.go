@@ -7,6 +7,7 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/usnistgov/ndn-dpdk/core/cptr"
 	"github.com/usnistgov/ndn-dpdk/core/macaddr"
@@ -21,8 +22,28 @@ func New(port *Port, loc Locator) (iface.Face, error) {
 	loc.Port = port.dev.Name()
 	loc.PortConfig = nil
 	loc.RxQueueIDs = nil
+	loc.applyUDPDefaults()
+	if e := loc.Validate(); e != nil {
+		return nil, e
+	}
 
+	cfg := port.cfg.Config
+	txHeadroom := int(C.sizeof_EthFaceEtherHdr)
 	switch {
+	case loc.isUDP():
+		if face := port.findUDPFace(loc); face != nil {
+			return nil, fmt.Errorf("port has another face %d with same UDP endpoints and VXLAN VNI", face.ID())
+		}
+		ipHdrLen := int(C.sizeof_struct_rte_ipv6_hdr)
+		if loc.ipVersion() == 4 {
+			ipHdrLen = int(C.sizeof_struct_rte_ipv4_hdr)
+		}
+		udpHdrLen := int(C.sizeof_struct_rte_udp_hdr)
+		if loc.VXLAN != nil {
+			udpHdrLen += int(C.EthFaceVxlanHdrLen)
+		}
+		cfg.MTU -= ipHdrLen + udpHdrLen
+		txHeadroom += int(C.sizeof_struct_rte_ipv6_hdr) + udpHdrLen
 	case macaddr.IsMulticast(loc.Remote):
 		if face := port.FindFace(nil); face != nil {
 			return nil, fmt.Errorf("port has another face %d with a group address", face.ID())
@@ -40,10 +61,10 @@ func New(port *Port, loc Locator) (iface.Face, error) {
 		loc:  loc,
 	}
 	return iface.New(iface.NewParams{
-		Config:     port.cfg.Config,
+		Config:     cfg,
 		Socket:     port.dev.NumaSocket(),
 		SizeofPriv: uintptr(C.sizeof_EthFacePriv),
-		TxHeadroom: int(C.sizeof_EthFaceEtherHdr),
+		TxHeadroom: txHeadroom,
 		Init: func(f iface.Face) error {
 			face.Face = f
 			c := face.ptr()
@@ -55,10 +76,25 @@ func New(port *Port, loc Locator) (iface.Face, error) {
 				faceID: C.FaceID(f.ID()),
 			}
 
-			var local, remote C.struct_rte_ether_addr
-			copy(cptr.AsByteSlice(&local.addr_bytes), []byte(face.loc.Local))
-			copy(cptr.AsByteSlice(&remote.addr_bytes), []byte(face.loc.Remote))
-			priv.txHdrLen = C.EthFaceEtherHdr_Init(&priv.txHdr, &local, &remote, C.uint16_t(face.loc.VLAN))
+			addr := &priv.addr
+			copy(cptr.AsByteSlice(&addr.local.addr_bytes), []byte(face.loc.Local))
+			copy(cptr.AsByteSlice(&addr.remote.addr_bytes), []byte(face.loc.Remote))
+			addr.vlan = C.uint16_t(face.loc.VLAN)
+			if ipVersion := face.loc.ipVersion(); ipVersion != 0 {
+				addr.ipVersion = C.uint8_t(ipVersion)
+				copyIP(addr.localIP[:], face.loc.LocalIP)
+				copyIP(addr.remoteIP[:], face.loc.RemoteIP)
+				addr.localUDP = C.uint16_t(face.loc.LocalUDP)
+				addr.remoteUDP = C.uint16_t(face.loc.RemoteUDP)
+				priv.learnRemote = C.bool(macaddr.Equal(face.loc.Remote, AddressLearnRemote))
+			}
+			if vxlan := face.loc.VXLAN; vxlan != nil {
+				addr.vxlan = true
+				addr.vni = C.uint32_t(vxlan.VNI)
+				copy(cptr.AsByteSlice(&addr.innerLocal.addr_bytes), []byte(vxlan.InnerLocal.HardwareAddr))
+				copy(cptr.AsByteSlice(&addr.innerRemote.addr_bytes), []byte(vxlan.InnerRemote.HardwareAddr))
+			}
+			C.EthFaceTxHdr_Init(&priv.txHdr, addr, C.bool(port.dev.DevInfo().HasTxChecksumOffload()))
 
 			face.priv = priv
 			return nil
@@ -92,3 +128,13 @@ type ethFace struct {
 func (face *ethFace) ptr() *C.Face {
 	return (*C.Face)(face.Ptr())
 }
+
+// Copy IP address into C array; IPv4 address occupies first 4 octets.
+func copyIP(dst []C.uint8_t, ip net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for i, b := range ip {
+		dst[i] = C.uint8_t(b)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/iface/ethface"
 	"github.com/usnistgov/ndn-dpdk/iface/ifacetestenv"
 	"github.com/usnistgov/ndn-dpdk/ndn/packettransport"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
 )
 
@@ -129,3 +130,108 @@ func TestFragmentation(t *testing.T) {
 	cntB := faceB.ReadCounters()
 	assert.Greater(cntB.ReassDrops, uint64(0))
 }
+
+func testEthFaceUDP(t *testing.T, ipA, ipB string, portA, portB int, learnRemote bool) {
+	assert, require := makeAR(t)
+	fixture := ifacetestenv.NewFixture(t)
+
+	var vnetCfg ethdev.VNetConfig
+	vnetCfg.RxPool = ndnitestenv.Packet.Pool()
+	vnetCfg.NNodes = 2
+	vnet := ethdev.NewVNet(vnetCfg)
+	defer func() {
+		fixture.Close()
+		vnet.Close()
+	}()
+
+	locA := ethface.NewLocator(vnet.Ports[0])
+	locA.Remote = vnet.Ports[1].MacAddr()
+	locA.LocalIP, locA.RemoteIP = net.ParseIP(ipA), net.ParseIP(ipB)
+	locA.LocalUDP, locA.RemoteUDP = portA, portB
+	faceA, e := locA.CreateFace()
+	require.NoError(e)
+
+	locB := ethface.NewLocator(vnet.Ports[1])
+	locB.Remote = vnet.Ports[0].MacAddr()
+	if learnRemote {
+		locB.Remote = ethface.AddressLearnRemote
+	}
+	locB.LocalIP, locB.RemoteIP = locA.RemoteIP, locA.LocalIP
+	locB.LocalUDP, locB.RemoteUDP = portB, portA
+	faceB, e := locB.CreateFace()
+	require.NoError(e)
+
+	_, e = locB.CreateFace()
+	assert.Error(e, "duplicate UDP endpoints")
+
+	locA = faceA.Locator().(ethface.Locator)
+	assert.Equal("udpe", locA.Scheme())
+	assert.NotZero(locA.LocalUDP)
+	assert.NotZero(locA.RemoteUDP)
+
+	ealthread.Launch(vnet)
+	time.Sleep(time.Second)
+
+	if learnRemote {
+		iface.TxBurst(faceB.ID(), []*ndni.Packet{ndnitestenv.MakeInterest("/B")})
+		time.Sleep(100 * time.Millisecond)
+		assert.EqualValues(1, faceB.ReadCounters().TxDropped, "remote MAC address not yet learned")
+	}
+
+	fixture.RunTest(faceA, faceB)
+	fixture.CheckCounters()
+}
+
+func TestEthFaceUDP4(t *testing.T) {
+	testEthFaceUDP(t, "192.168.2.1", "192.168.2.2", 0, 0, false)
+}
+
+func TestEthFaceUDP6(t *testing.T) {
+	testEthFaceUDP(t, "fd00::1", "fd00::2", 16363, 26363, false)
+}
+
+func TestEthFaceUDPLearnRemote(t *testing.T) {
+	testEthFaceUDP(t, "192.168.2.1", "192.168.2.2", 6363, 16363, true)
+}
+
+func TestEthFaceVXLAN(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := ifacetestenv.NewFixture(t)
+
+	var vnetCfg ethdev.VNetConfig
+	vnetCfg.RxPool = ndnitestenv.Packet.Pool()
+	vnetCfg.NNodes = 2
+	vnet := ethdev.NewVNet(vnetCfg)
+	defer func() {
+		fixture.Close()
+		vnet.Close()
+	}()
+
+	makeFace := func(i int, vni int) iface.Face {
+		loc := ethface.NewLocator(vnet.Ports[i])
+		loc.Remote = vnet.Ports[1-i].MacAddr()
+		loc.LocalIP = net.IPv4(192, 168, 2, byte(1+i))
+		loc.RemoteIP = net.IPv4(192, 168, 2, byte(2-i))
+		loc.VXLAN = &ethface.VXLANLocator{VNI: vni}
+		face, e := loc.CreateFace()
+		require.NoError(e, "%d %d", i, vni)
+		return face
+	}
+
+	faceA1, faceB1 := makeFace(0, 1), makeFace(1, 1)
+	makeFace(0, 0x1002)
+	faceB2 := makeFace(1, 0x1002)
+
+	locA1 := faceA1.Locator().(ethface.Locator)
+	assert.Equal("vxlan", locA1.Scheme())
+	assert.Equal(ethface.VXLANPort, locA1.LocalUDP)
+	assert.Equal(ethface.VXLANPort, locA1.RemoteUDP)
+	assert.Equal(packettransport.MulticastAddressNDN, locA1.VXLAN.InnerRemote.HardwareAddr)
+
+	ealthread.Launch(vnet)
+	time.Sleep(time.Second)
+
+	fixture.RunTest(faceA1, faceB1)
+	fixture.CheckCounters()
+	assert.Zero(faceB2.ReadCounters().RxInterests)
+}
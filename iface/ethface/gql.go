@@ -0,0 +1,121 @@
+package ethface
+
+import (
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ethdev"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+// GraphQL types.
+var (
+	GqlEthDevNodeType *gqlserver.NodeType
+	GqlEthDevType     *graphql.Object
+)
+
+func init() {
+	GqlEthDevNodeType = gqlserver.NewNodeTypeNamed("EthDev", ethdev.EthDev{})
+	GqlEthDevNodeType.Retrieve = func(id string) (interface{}, error) {
+		dev := ethdev.Find(id)
+		if !dev.Valid() {
+			return nil, nil
+		}
+		return dev, nil
+	}
+
+	GqlEthDevType = graphql.NewObject(GqlEthDevNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "EthDev",
+		Description: "Ethernet adapter.",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Description: "Port name.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ethdev.EthDev).Name(), nil
+				},
+			},
+			"numaSocket": eal.GqlWithNumaSocket,
+			"macAddr": &graphql.Field{
+				Description: "MAC address.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ethdev.EthDev).MacAddr().String(), nil
+				},
+			},
+			"isDown": &graphql.Field{
+				Description: "Whether the link is down.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ethdev.EthDev).IsDown(), nil
+				},
+			},
+			"implName": &graphql.Field{
+				Description: "Face implementation name. null indicates no face has been created on the port.",
+				Type:        graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					port := FindPort(p.Source.(ethdev.EthDev))
+					if port == nil {
+						return nil, nil
+					}
+					return port.ImplName(), nil
+				},
+			},
+			"faces": &graphql.Field{
+				Description: "Faces on the port.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(iface.GqlFaceType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					list := []iface.Face{}
+					if port := FindPort(p.Source.(ethdev.EthDev)); port != nil {
+						list = append(list, port.Faces()...)
+					}
+					return list, nil
+				},
+			},
+			"stats": &graphql.Field{
+				Description: "Hardware statistics.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(ethdev.EthDev).Stats(), nil
+				},
+			},
+		},
+	}))
+	GqlEthDevNodeType.Register(GqlEthDevType)
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "ethDevs",
+		Description: "Ethernet adapters.",
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlEthDevType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return append([]ethdev.EthDev{}, ethdev.List()...), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "resetEthDevStats",
+		Description: "Clear hardware statistics of an Ethernet adapter. Returns statistics before clearing.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Description: "EthDev ID.",
+				Type:        gqlserver.NonNullID,
+			},
+		},
+		Type: gqlserver.NonNullJSON,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			node, e := gqlserver.RetrieveNodeOfType(GqlEthDevNodeType, p.Args["id"])
+			if e != nil {
+				return nil, e
+			}
+			if node == nil {
+				return nil, errors.New("EthDev not found")
+			}
+			dev := node.(ethdev.EthDev)
+			stats := dev.Stats()
+			dev.ResetStats()
+			return stats, nil
+		},
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 
 	"github.com/VojtechVitek/mergemaps"
 	"github.com/usnistgov/ndn-dpdk/core/macaddr"
@@ -17,8 +19,26 @@ import (
 const (
 	locatorSchemeEther = "ether"
 	locatorSchemeMemif = "memif"
+	locatorSchemeUDP   = "udpe"
+	locatorSchemeVXLAN = "vxlan"
 )
 
+// UDP port numbers.
+const (
+	// DefaultUDPPort is the default UDP port number for NDN over UDP.
+	DefaultUDPPort = 6363
+
+	// VXLANPort is the default UDP port number for VXLAN.
+	VXLANPort = 4789
+)
+
+// MaxVNI is the maximum VXLAN Network Identifier.
+const MaxVNI = 0xFFFFFF
+
+// AddressLearnRemote is a remote MAC address that causes an NDN over UDP face to learn the remote MAC
+// address from incoming frames.
+var AddressLearnRemote = net.HardwareAddr{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
 // LocatorFields contains additional Locator fields.
 type LocatorFields struct {
 	// Memif specifies shared memory packet interface (memif) settings.
@@ -34,6 +54,37 @@ type LocatorFields struct {
 	// - loc.PortConfig.NoSetMTU is overridden to true.
 	Memif *memiftransport.Locator `json:"memif,omitempty"`
 
+	// LocalIP and RemoteIP specify NDN over UDP endpoints.
+	// Both must be either IPv4 or IPv6 unicast addresses.
+	//
+	// If these are specified:
+	// - loc.Scheme() becomes "udpe".
+	// - Every outgoing packet is encapsulated in Ethernet, VLAN (if loc.VLAN is non-zero), IP, and UDP headers.
+	// - An incoming frame is accepted only if its IP addresses and UDP ports match this face.
+	// - loc.Remote must be a unicast MAC address of the next hop, which is usually obtained out-of-band
+	//   because the face does not perform ARP or Neighbor Discovery.
+	//   Alternatively, if loc.Remote is AddressLearnRemote, the face learns the remote MAC address from
+	//   incoming frames, and drops outgoing frames until then.
+	LocalIP  net.IP `json:"localIP,omitempty"`
+	RemoteIP net.IP `json:"remoteIP,omitempty"`
+
+	// LocalUDP and RemoteUDP are NDN over UDP port numbers.
+	// If zero, they default to DefaultUDPPort, or VXLANPort if VXLAN is specified.
+	// With VXLAN, outgoing frames use a source port derived from a hash of the inner headers instead of LocalUDP.
+	LocalUDP  int `json:"localUDP,omitempty"`
+	RemoteUDP int `json:"remoteUDP,omitempty"`
+
+	// VXLAN specifies VXLAN tunnel settings.
+	//
+	// If this is specified:
+	// - loc.Scheme() becomes "vxlan".
+	// - LocalIP and RemoteIP are required, and they become the outer IP addresses.
+	// - Every outgoing packet is encapsulated in an inner Ethernet header, and then a VXLAN header,
+	//   before adding outer headers as in NDN over UDP.
+	// - An incoming frame is accepted only if its outer IP addresses, outer destination UDP port, VNI,
+	//   and inner MAC addresses match this face. Outer source UDP port is not checked.
+	VXLAN *VXLANLocator `json:"vxlan,omitempty"`
+
 	// Port is the port name.
 	//
 	// During face creation, this field is optional.
@@ -58,6 +109,21 @@ type LocatorFields struct {
 	RxQueueIDs []int `json:"rxQueueIDs,omitempty"`
 }
 
+// VXLANLocator contains VXLAN tunnel settings.
+type VXLANLocator struct {
+	// VNI is the VXLAN Network Identifier.
+	// This must be between 0 and MaxVNI.
+	VNI int `json:"vni"`
+
+	// InnerLocal is the inner local MAC address.
+	// If omitted, it defaults to loc.Local.
+	InnerLocal macaddr.Flag `json:"innerLocal"`
+
+	// InnerRemote is the inner remote MAC address.
+	// If omitted, it defaults to packettransport.MulticastAddressNDN.
+	InnerRemote macaddr.Flag `json:"innerRemote"`
+}
+
 // Locator describes port, addresses, and VLAN of an Ethernet face.
 type Locator struct {
 	packettransport.Locator
@@ -71,14 +137,99 @@ func NewLocator(dev ethdev.EthDev) (loc Locator) {
 	return loc
 }
 
-// Scheme returns either "ether" or "memif".
+// Scheme returns "ether", "memif", "udpe", or "vxlan".
 func (loc Locator) Scheme() string {
-	if loc.Memif != nil {
+	switch {
+	case loc.Memif != nil:
 		return locatorSchemeMemif
+	case loc.VXLAN != nil:
+		return locatorSchemeVXLAN
+	case loc.isUDP():
+		return locatorSchemeUDP
 	}
 	return locatorSchemeEther
 }
 
+func (loc Locator) isUDP() bool {
+	return loc.LocalIP != nil || loc.RemoteIP != nil || loc.VXLAN != nil
+}
+
+// ipVersion returns 4 or 6 for NDN over UDP, or 0 for NDN over Ethernet.
+func (loc Locator) ipVersion() int {
+	switch {
+	case !loc.isUDP():
+		return 0
+	case loc.LocalIP.To4() != nil:
+		return 4
+	}
+	return 6
+}
+
+func (loc *Locator) applyUDPDefaults() {
+	if !loc.isUDP() {
+		return
+	}
+	defaultPort := DefaultUDPPort
+	if loc.VXLAN != nil {
+		defaultPort = VXLANPort
+		vxlan := *loc.VXLAN
+		if vxlan.InnerLocal.HardwareAddr == nil {
+			vxlan.InnerLocal.HardwareAddr = loc.Local
+		}
+		if vxlan.InnerRemote.HardwareAddr == nil {
+			vxlan.InnerRemote.HardwareAddr = packettransport.MulticastAddressNDN
+		}
+		loc.VXLAN = &vxlan
+	}
+	if loc.LocalUDP == 0 {
+		loc.LocalUDP = defaultPort
+	}
+	if loc.RemoteUDP == 0 {
+		loc.RemoteUDP = defaultPort
+	}
+}
+
+// Validate checks Locator fields.
+func (loc Locator) Validate() error {
+	if e := loc.Locator.Validate(); e != nil {
+		return e
+	}
+	if !loc.isUDP() {
+		return nil
+	}
+
+	if loc.Memif != nil {
+		return errors.New("NDN over UDP cannot be used with memif")
+	}
+	local4, remote4 := loc.LocalIP.To4() != nil, loc.RemoteIP.To4() != nil
+	switch {
+	case len(loc.LocalIP) == 0 || !loc.LocalIP.IsGlobalUnicast() && !loc.LocalIP.IsLinkLocalUnicast():
+		return errors.New("invalid LocalIP")
+	case len(loc.RemoteIP) == 0 || !loc.RemoteIP.IsGlobalUnicast() && !loc.RemoteIP.IsLinkLocalUnicast():
+		return errors.New("invalid RemoteIP")
+	case local4 != remote4:
+		return errors.New("LocalIP and RemoteIP must have the same address family")
+	case loc.LocalUDP < 0 || loc.LocalUDP > math.MaxUint16:
+		return errors.New("invalid LocalUDP")
+	case loc.RemoteUDP < 0 || loc.RemoteUDP > math.MaxUint16:
+		return errors.New("invalid RemoteUDP")
+	case macaddr.IsMulticast(loc.Remote) && !macaddr.Equal(loc.Remote, AddressLearnRemote):
+		return errors.New("NDN over UDP requires unicast Remote or AddressLearnRemote")
+	}
+
+	if vxlan := loc.VXLAN; vxlan != nil {
+		switch {
+		case vxlan.VNI < 0 || vxlan.VNI > MaxVNI:
+			return errors.New("invalid VXLAN.VNI")
+		case vxlan.InnerLocal.HardwareAddr != nil && !macaddr.IsUnicast(vxlan.InnerLocal.HardwareAddr):
+			return errors.New("invalid VXLAN.InnerLocal")
+		case vxlan.InnerRemote.HardwareAddr != nil && !macaddr.IsValid(vxlan.InnerRemote.HardwareAddr):
+			return errors.New("invalid VXLAN.InnerRemote")
+		}
+	}
+	return nil
+}
+
 // CreateFace creates a face from this Locator.
 func (loc Locator) CreateFace() (face iface.Face, e error) {
 	if e = loc.Validate(); e != nil {
@@ -199,5 +350,5 @@ func (loc *Locator) UnmarshalJSON(data []byte) error {
 }
 
 func init() {
-	iface.RegisterLocatorType(Locator{}, locatorSchemeEther, locatorSchemeMemif)
+	iface.RegisterLocatorType(Locator{}, locatorSchemeEther, locatorSchemeMemif, locatorSchemeUDP, locatorSchemeVXLAN)
 }
@@ -139,17 +139,32 @@ func (port *Port) filterFace(filter func(face *ethFace) bool) iface.Face {
 	return nil
 }
 
-// FindFace returns a face that matches the query, or nil if it does not exist.
+// FindFace returns an NDN over Ethernet face that matches the query, or nil if it does not exist.
 // FindFace(nil) returns a face with multicast address.
 // FindFace(unicastAddr) returns a face with matching address.
 func (port *Port) FindFace(query net.HardwareAddr) iface.Face {
 	if query == nil {
 		return port.filterFace(func(face *ethFace) bool {
-			return macaddr.IsMulticast(face.loc.Remote)
+			return !face.loc.isUDP() && macaddr.IsMulticast(face.loc.Remote)
 		})
 	}
 	return port.filterFace(func(face *ethFace) bool {
-		return macaddr.Equal(face.loc.Remote, query)
+		return !face.loc.isUDP() && macaddr.Equal(face.loc.Remote, query)
+	})
+}
+
+// findUDPFace returns an NDN over UDP or VXLAN face with same IP addresses, UDP ports, and VNI,
+// or nil if it does not exist.
+func (port *Port) findUDPFace(loc Locator) iface.Face {
+	return port.filterFace(func(face *ethFace) bool {
+		if !face.loc.isUDP() || !face.loc.LocalIP.Equal(loc.LocalIP) || !face.loc.RemoteIP.Equal(loc.RemoteIP) ||
+			face.loc.LocalUDP != loc.LocalUDP || (face.loc.VXLAN == nil) != (loc.VXLAN == nil) {
+			return false
+		}
+		if loc.VXLAN != nil {
+			return face.loc.VXLAN.VNI == loc.VXLAN.VNI
+		}
+		return face.loc.RemoteUDP == loc.RemoteUDP
 	})
 }
 
@@ -2,6 +2,7 @@ package ethface
 
 /*
 #include "../../csrc/ethface/rxtable.h"
+#include "../../csrc/ethface/eth-face.h"
 */
 import "C"
 import (
@@ -48,6 +49,13 @@ func (impl *rxTableImpl) setFace(slot *C.FaceID, faceID iface.ID) error {
 
 func (impl *rxTableImpl) Start(face *ethFace) error {
 	rxtC := impl.rxt.ptr()
+	if vxlan := face.loc.VXLAN; vxlan != nil {
+		return impl.setFace(&rxtC.vxlan[uint8(vxlan.VNI)], face.ID())
+	}
+	if face.loc.isUDP() {
+		slot := C.EthRxTable_UdpSlot(C.EthFaceAddr_RemoteIPLastOctet(&face.priv.addr), C.uint16_t(face.loc.RemoteUDP))
+		return impl.setFace(&rxtC.udp[slot], face.ID())
+	}
 	if macaddr.IsMulticast(face.loc.Remote) {
 		return impl.setFace(&rxtC.multicast, face.ID())
 	}
@@ -8,11 +8,15 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/pkg/math"
 	"github.com/usnistgov/ndn-dpdk/core/cptr"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/core/runningstat"
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
@@ -41,6 +45,21 @@ type Face interface {
 
 	// SetDown changes face UP/DOWN state.
 	SetDown(isDown bool)
+
+	// Persistency returns face persistency level.
+	Persistency() Persistency
+
+	// SetPersistency changes face persistency level.
+	SetPersistency(p Persistency) error
+
+	// IsLocal determines whether the face is local, for /localhost and /localhop scope control.
+	IsLocal() bool
+
+	// AclRules returns ACL rules in a direction and their counters.
+	AclRules(dir AclDir) []AclRuleInfo
+
+	// SetAclRules replaces ACL rules in a direction.
+	SetAclRules(dir AclDir, rules []AclRule) error
 }
 
 // Config contains face configuration.
@@ -51,18 +70,51 @@ type Config struct {
 	// Otherwise, it is clamped between MinReassemblerCapacity and MaxReassemblerCapacity.
 	ReassemblerCapacity int `json:"reassemblerCapacity,omitempty"`
 
-	// OutputQueueSize is the packet queue capacity before the output thread.
+	// ReassemblerQueueSize is the capacity of the queue that hands off fragments to the reassembler,
+	// when they are received on other RX threads or while the reassembler is busy.
+	//
+	// The minimum is MinReassemblerQueueSize.
+	// If this value is less than the minimum, it defaults to DefaultReassemblerQueueSize.
+	// Otherwise, it is adjusted up to the next power of 2.
+	ReassemblerQueueSize int `json:"reassemblerQueueSize,omitempty"`
+
+	// OutputQueueSize is the packet queue capacity before the output thread, for each traffic class.
 	//
 	// The minimum is MinOutputQueueSize.
 	// If this value is less than the minimum, it defaults to DefaultOutputQueueSize.
 	// Otherwise, it is adjusted up to the next power of 2.
 	OutputQueueSize int `json:"outputQueueSize,omitempty"`
 
+	// TxClasses configures traffic classes on the send path.
+	TxClasses TxClassConfig `json:"txClasses,omitempty"`
+
+	// CongMarkThreshold enables ECN-like congestion marking on the send path.
+	//
+	// If this value is positive, outgoing Data and Nack packets are marked with NDNLPv2 CongestionMark
+	// when the packet queue before the output thread of their traffic class still contains at least
+	// this many packets, so that consumers can reduce their sending rate before the queue overflows.
+	// If this value is zero, congestion marking on the send path is disabled.
+	CongMarkThreshold int `json:"congMarkThreshold,omitempty"`
+
 	// MTU is the maximum size of outgoing NDNLP packets.
 	//
 	// If this value is zero, it disables fragmentation.
 	// Otherwise, it is clamped between (1) MinMtu (2) the lesser of MaxMtu and the MTU reported by the transport.
 	MTU int `json:"mtu,omitempty"`
+
+	// Scope determines whether the face is local or non-local.
+	//
+	// If this value is ScopeDefault, the face is local if its locator scheme is in LocalSchemes.
+	// Packets under /localhost can only be received from and sent to local faces.
+	// Interests under /localhop received from a non-local face can only be forwarded to local faces.
+	Scope Scope `json:"scope,omitempty"`
+
+	// IdleTimeout is the duration after which an on-demand face is destroyed if it has not received
+	// any frame.
+	//
+	// If this value is zero, it defaults to DefaultIdleTimeout.
+	// It is raised to MinIdleTimeout if it is smaller.
+	IdleTimeout nnduration.Milliseconds `json:"idleTimeout,omitempty"`
 }
 
 // ApplyDefaults applies defaults.
@@ -72,11 +124,19 @@ func (c *Config) ApplyDefaults() {
 	}
 	c.ReassemblerCapacity = math.MinInt(math.MaxInt(MinReassemblerCapacity, c.ReassemblerCapacity), MaxReassemblerCapacity)
 
+	c.ReassemblerQueueSize = ringbuffer.AlignCapacity(c.ReassemblerQueueSize, MinReassemblerQueueSize, DefaultReassemblerQueueSize)
 	c.OutputQueueSize = ringbuffer.AlignCapacity(c.OutputQueueSize, MinOutputQueueSize, DefaultOutputQueueSize)
 
 	if c.MTU != 0 {
 		c.MTU = math.MinInt(math.MaxInt(MinMtu, c.MTU), MaxMtu)
 	}
+
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = nnduration.Milliseconds(DefaultIdleTimeout / time.Millisecond)
+	}
+	if min := nnduration.Milliseconds(MinIdleTimeout / time.Millisecond); c.IdleTimeout < min {
+		c.IdleTimeout = min
+	}
 }
 
 // NewParams contains parameters to New().
@@ -142,6 +202,7 @@ func newFace(p NewParams) (Face, error) {
 		stopCallback:           p.Stop,
 		closeCallback:          p.Close,
 		readExCountersCallback: p.ReadExCounters,
+		idleTimeout:            p.IdleTimeout.Duration(),
 	}
 
 	c := f.ptr()
@@ -154,11 +215,9 @@ func newFace(p NewParams) (Face, error) {
 		return f.clear(), e
 	}
 
-	outputQueue, e := ringbuffer.New(p.OutputQueueSize, p.Socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
-	if e != nil {
+	if e := f.initTxClasses(p); e != nil {
 		return f.clear(), e
 	}
-	c.outputQueue = (*C.struct_rte_ring)(outputQueue.Ptr())
 
 	for l3type := 0; l3type < 4; l3type++ {
 		latencyStat := runningstat.FromPtr(unsafe.Pointer(&c.impl.tx.latency[l3type]))
@@ -174,6 +233,12 @@ func newFace(p NewParams) (Face, error) {
 	if ok := bool(C.Reassembler_Init(&c.impl.rx.reass, reassID, C.uint32_t(p.ReassemblerCapacity), C.unsigned(p.Socket.ID()))); !ok {
 		return f.clear(), fmt.Errorf("Reassembler_Init error %w", eal.GetErrno())
 	}
+	reassQueue, e := ringbuffer.New(p.ReassemblerQueueSize, p.Socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
+	if e != nil {
+		return f.clear(), e
+	}
+	c.impl.rx.reassQueue = (*C.struct_rte_ring)(reassQueue.Ptr())
+	C.cds_hlist_add_head_rcu(&c.impl.rx.reassNode, &C.theRxProcReassList_)
 
 	C.TxProc_Init(&c.impl.tx, C.uint16_t(p.MTU), C.uint16_t(p.TxHeadroom),
 		(*C.struct_rte_mempool)(indirectMp.Ptr()), (*C.struct_rte_mempool)(headerMp.Ptr()))
@@ -182,6 +247,7 @@ func newFace(p NewParams) (Face, error) {
 	if e != nil {
 		return f.clear(), e
 	}
+	c.isLocal = C.bool(p.Scope.resolve(p.Locator(f2)))
 
 	gFaces[f.id] = f2
 	emitter.EmitSync(evtFaceNew, f.id)
@@ -196,6 +262,16 @@ type face struct {
 	stopCallback           func(f Face) error
 	closeCallback          func(f Face) error
 	readExCountersCallback func(f Face) interface{}
+
+	// These fields are accessed on main thread only, except that persistency may be read from any
+	// thread, and thus is accessed via atomic operations.
+	persistency int32 // Persistency
+	idleTimeout time.Duration
+	idleStop    chan struct{}
+	closed      bool
+
+	aclMutex  sync.Mutex // serializes ACL readers, writers, and release
+	aclClosed bool       // ACLs have been released, protected by aclMutex
 }
 
 func (f *face) ptr() *C.Face {
@@ -224,6 +300,10 @@ func (f *face) Close() (e error) {
 }
 
 func (f *face) close() error {
+	if f.closed {
+		return nil
+	}
+	f.stopIdleMonitor()
 	f.ptr().state = StateDown
 	emitter.EmitSync(evtFaceClosing, f.id)
 	DeactivateTxFace(f)
@@ -243,19 +323,33 @@ func (f *face) close() error {
 
 func (f *face) clear() Face {
 	id, c := f.id, f.ptr()
+	f.closed = true
 	c.state = StateRemoved
 	if c.impl != nil {
+		if c.impl.rx.reassQueue != nil {
+			C.cds_hlist_del_rcu(&c.impl.rx.reassNode)
+			urcu.Synchronize()
+			reassQueue := ringbuffer.FromPtr(unsafe.Pointer(c.impl.rx.reassQueue))
+			vec := make(pktmbuf.Vector, reassQueue.CountInUse())
+			vec = vec[:reassQueue.Dequeue(vec)]
+			vec.Close()
+			reassQueue.Close()
+		}
 		C.Reassembler_Close(&c.impl.rx.reass)
+		f.closeTxClasses()
+		f.closeAcls()
 		eal.Free(c.impl)
 	}
-	if c.outputQueue != nil {
-		ringbuffer.FromPtr(unsafe.Pointer(c.outputQueue)).Close()
-	}
 	c.id = 0
+	c.isLocal = false
 	gFaces[id] = nil
 	return nil
 }
 
+func (f *face) IsLocal() bool {
+	return bool(f.ptr().isLocal)
+}
+
 func (f *face) ReadExCounters() interface{} {
 	if f.readExCountersCallback != nil {
 		return f.readExCountersCallback(f)
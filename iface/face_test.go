@@ -1,11 +1,14 @@
 package iface_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
 )
@@ -37,6 +40,97 @@ func TestTxBurst(t *testing.T) {
 	assert.NotNil(collect.Get(0).Data)
 }
 
+func TestTxClasses(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var cfg socketface.Config
+	cfg.TxClasses.Rules = []iface.TxClassRule{
+		{Prefix: ndn.ParseName("/P"), Class: iface.TxClassPriority},
+		{Prefix: ndn.ParseName("/B"), Class: 1},
+		{Prefix: ndn.ParseName("/B/0"), Class: 2},
+	}
+	face := intface.Must(intface.New(cfg))
+	defer face.D.Close()
+	collect := intface.Collect(face)
+
+	pkts := []*ndni.Packet{
+		ndnitestenv.MakeInterest("/P/0"),
+		ndnitestenv.MakeInterest("/B/0/0"),
+		ndnitestenv.MakeInterest("/B/1"),
+		ndnitestenv.MakeData("/B/1"),
+		ndnitestenv.MakeData("/A"),
+	}
+	iface.TxBurst(face.ID, pkts)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(len(pkts), collect.Count())
+
+	cnt := face.D.ReadCounters()
+	assert.EqualValues(1, cnt.TxClasses[0].NPkts)
+	assert.EqualValues(2, cnt.TxClasses[1].NPkts)
+	assert.EqualValues(1, cnt.TxClasses[2].NPkts)
+	assert.EqualValues(1, cnt.TxClasses[iface.TxClassPriority].NPkts)
+
+	cfg.TxClasses.Rules = append(cfg.TxClasses.Rules, iface.TxClassRule{Prefix: ndn.ParseName("/X"), Class: iface.NTxClasses})
+	_, e := intface.New(cfg)
+	require.Error(e)
+}
+
+func TestTxDequeueOrder(t *testing.T) {
+	assert, require := makeAR(t)
+
+	const nPriority, nDrr = 8, 96
+	var cfg socketface.Config
+	cfg.TxQueueSize = 2 * (nPriority + 2*nDrr)
+	cfg.TxClasses.Rules = []iface.TxClassRule{
+		{Prefix: ndn.ParseName("/P"), Class: iface.TxClassPriority},
+		{Prefix: ndn.ParseName("/A"), Class: 1},
+		{Prefix: ndn.ParseName("/B"), Class: 2},
+	}
+	cfg.TxClasses.Quantum = []int{0, 100, 200}
+	face := intface.Must(intface.New(cfg))
+	defer face.D.Close()
+	collect := intface.Collect(face)
+
+	// stop draining the before-Tx queues, so that all classes are backlogged
+	iface.DeactivateTxFace(face.D)
+	var pkts []*ndni.Packet
+	for i := 0; i < nDrr; i++ {
+		pkts = append(pkts, ndnitestenv.MakeInterest(fmt.Sprintf("/A/%03d", i)), ndnitestenv.MakeInterest(fmt.Sprintf("/B/%03d", i)))
+	}
+	for i := 0; i < nPriority; i++ {
+		pkts = append(pkts, ndnitestenv.MakeInterest(fmt.Sprintf("/P/%03d", i)))
+	}
+	iface.TxBurst(face.ID, pkts)
+	iface.ActivateTxFace(face.D)
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(len(pkts), collect.Count())
+
+	var classes []string
+	collect.Peek(func(received []*ndn.Packet) {
+		for _, packet := range received {
+			classes = append(classes, string(packet.Interest.Name.Get(0).Value))
+		}
+	})
+	for i := 0; i < nPriority; i++ {
+		assert.Equal("P", classes[i], i)
+	}
+
+	// window in which both DRR classes remain backlogged
+	nA, nB := 0, 0
+	for _, class := range classes[nPriority : nPriority+nDrr*5/4] {
+		switch class {
+		case "A":
+			nA++
+		case "B":
+			nB++
+		}
+	}
+	if assert.NotZero(nA) {
+		assert.InDelta(2.0, float64(nB)/float64(nA), 0.5)
+	}
+}
+
 func TestEvents(t *testing.T) {
 	assert, _ := makeAR(t)
 
@@ -94,3 +188,52 @@ func TestEvents(t *testing.T) {
 	}
 	assert.True(iface.IsDown(id1))
 }
+
+func TestPersistency(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var cfg socketface.Config
+	cfg.IdleTimeout = 1000
+	faceP := intface.Must(intface.New(cfg))
+	defer faceP.D.Close()
+	faceA := intface.Must(intface.New(cfg))
+	defer faceA.D.Close()
+	faceI := intface.Must(intface.New(cfg))
+	defer faceI.D.Close()
+
+	assert.Equal(iface.PersistencyPersistent, faceP.D.Persistency())
+	assert.Error(faceP.D.SetPersistency(iface.Persistency(-1)))
+	require.NoError(faceA.D.SetPersistency(iface.PersistencyOnDemand))
+	require.NoError(faceI.D.SetPersistency(iface.PersistencyOnDemand))
+	assert.Equal(iface.PersistencyOnDemand, faceI.D.Persistency())
+
+	for i := 0; i < 10; i++ {
+		faceA.Tx <- ndn.MakeInterest("/A")
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	assert.NotNil(iface.Get(faceP.ID))
+	assert.NotNil(iface.Get(faceA.ID))
+	assert.Nil(iface.Get(faceI.ID))
+
+	require.NoError(faceA.D.SetPersistency(iface.PersistencyPermanent))
+	time.Sleep(1500 * time.Millisecond)
+	assert.NotNil(iface.Get(faceA.ID))
+	assert.Equal(iface.PersistencyPermanent, faceA.D.Persistency())
+}
+
+func TestConfigReassemblerQueueSize(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	var cfg iface.Config
+	cfg.ApplyDefaults()
+	assert.Equal(iface.DefaultReassemblerQueueSize, cfg.ReassemblerQueueSize)
+
+	cfg.ReassemblerQueueSize = iface.MinReassemblerQueueSize - 1
+	cfg.ApplyDefaults()
+	assert.Equal(iface.DefaultReassemblerQueueSize, cfg.ReassemblerQueueSize)
+
+	cfg.ReassemblerQueueSize = 100
+	cfg.ApplyDefaults()
+	assert.Equal(128, cfg.ReassemblerQueueSize)
+}
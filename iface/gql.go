@@ -1,20 +1,136 @@
 package iface
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
 // GraghQL types.
 var (
-	GqlFaceNodeType *gqlserver.NodeType
-	GqlFaceType     *graphql.Object
+	GqlPersistencyType  *graphql.Enum
+	GqlAclDirType       *graphql.Enum
+	GqlAclPktTypeType   *graphql.Enum
+	GqlAclRuleType      *graphql.Object
+	GqlAclRuleInputType *graphql.InputObject
+	GqlFaceNodeType     *gqlserver.NodeType
+	GqlFaceType         *graphql.Object
+	GqlFaceEventType    *graphql.Object
 )
 
+type gqlFaceEvent struct {
+	Event string
+	ID    ID
+}
+
+var gqlFacePublisher = gqlserver.NewPublisher()
+
+func parseGqlAclRules(arg interface{}) (rules []AclRule) {
+	for _, item := range arg.([]interface{}) {
+		m := item.(map[string]interface{})
+		rule := AclRule{
+			Allow: m["allow"].(bool),
+		}
+		rule.Prefix, _ = m["prefix"].(ndn.Name)
+		if pktTypes, ok := m["pktTypes"].([]interface{}); ok {
+			for _, t := range pktTypes {
+				rule.PktTypes = append(rule.PktTypes, t.(ndni.PktType))
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
 func init() {
+	GqlPersistencyType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "FacePersistency",
+		Description: "Face persistency level.",
+		Values: graphql.EnumValueConfigMap{
+			"PERSISTENT": &graphql.EnumValueConfig{Value: PersistencyPersistent},
+			"ON_DEMAND":  &graphql.EnumValueConfig{Value: PersistencyOnDemand},
+			"PERMANENT":  &graphql.EnumValueConfig{Value: PersistencyPermanent},
+		},
+	})
+
+	GqlAclDirType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "FaceAclDir",
+		Description: "Face ACL direction.",
+		Values: graphql.EnumValueConfigMap{
+			"INBOUND":  &graphql.EnumValueConfig{Value: AclInbound},
+			"OUTBOUND": &graphql.EnumValueConfig{Value: AclOutbound},
+		},
+	})
+
+	GqlAclPktTypeType = graphql.NewEnum(graphql.EnumConfig{
+		Name:        "FaceAclPktType",
+		Description: "Packet type matched by a face ACL rule.",
+		Values: graphql.EnumValueConfigMap{
+			"INTEREST": &graphql.EnumValueConfig{Value: ndni.PktInterest},
+			"DATA":     &graphql.EnumValueConfig{Value: ndni.PktData},
+			"NACK":     &graphql.EnumValueConfig{Value: ndni.PktNack},
+		},
+	})
+
+	GqlAclRuleType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FaceAclRule",
+		Description: "Face ACL rule.",
+		Fields: graphql.Fields{
+			"prefix": &graphql.Field{
+				Description: "Name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(AclRuleInfo).Prefix, nil
+				},
+			},
+			"pktTypes": &graphql.Field{
+				Description: "Matched packet types.",
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlAclPktTypeType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(AclRuleInfo).PktTypes, nil
+				},
+			},
+			"allow": &graphql.Field{
+				Description: "Whether matched packets are allowed.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(AclRuleInfo).Allow, nil
+				},
+			},
+			"nMatches": &graphql.Field{
+				Description: "Number of matched packets.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(AclRuleInfo).NMatches, nil
+				},
+			},
+		},
+	})
+
+	GqlAclRuleInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FaceAclRuleInput",
+		Description: "Face ACL rule.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"prefix": &graphql.InputObjectFieldConfig{
+				Description: "Name prefix. Nack is matched by its Interest name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"pktTypes": &graphql.InputObjectFieldConfig{
+				Description: "Matched packet types. Omit to match all packet types.",
+				Type:        graphql.NewList(graphql.NewNonNull(GqlAclPktTypeType)),
+			},
+			"allow": &graphql.InputObjectFieldConfig{
+				Description: "Whether matched packets are allowed.",
+				Type:        gqlserver.NonNullBoolean,
+			},
+		},
+	})
+
 	GqlFaceNodeType = gqlserver.NewNodeType((*Face)(nil))
 	GqlFaceNodeType.Retrieve = func(id string) (interface{}, error) {
 		nid, e := strconv.Atoi(id)
@@ -48,6 +164,30 @@ func init() {
 				},
 			},
 			"numaSocket": eal.GqlWithNumaSocket,
+			"isDown": &graphql.Field{
+				Type:        gqlserver.NonNullBoolean,
+				Description: "Whether the face is DOWN.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					face := p.Source.(Face)
+					return IsDown(face.ID()), nil
+				},
+			},
+			"isLocal": &graphql.Field{
+				Type:        gqlserver.NonNullBoolean,
+				Description: "Whether the face is local, for /localhost and /localhop scope control.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					face := p.Source.(Face)
+					return face.IsLocal(), nil
+				},
+			},
+			"persistency": &graphql.Field{
+				Type:        graphql.NewNonNull(GqlPersistencyType),
+				Description: "Persistency level.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					face := p.Source.(Face)
+					return face.Persistency(), nil
+				},
+			},
 			"counters": &graphql.Field{
 				Type:        gqlserver.JSON,
 				Description: "Face counters.",
@@ -56,6 +196,19 @@ func init() {
 					return face.ReadCounters(), nil
 				},
 			},
+			"acl": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlAclRuleType))),
+				Description: "ACL rules and their counters.",
+				Args: graphql.FieldConfigArgument{
+					"dir": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(GqlAclDirType),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					face := p.Source.(Face)
+					return face.AclRules(p.Args["dir"].(AclDir)), nil
+				},
+			},
 		},
 	}))
 	GqlFaceNodeType.Register(GqlFaceType)
@@ -76,6 +229,11 @@ func init() {
 			"locator": &graphql.ArgumentConfig{
 				Type: gqlserver.NonNullJSON,
 			},
+			"persistency": &graphql.ArgumentConfig{
+				Description:  "Persistency level.",
+				Type:         GqlPersistencyType,
+				DefaultValue: PersistencyPersistent,
+			},
 		},
 		Type: GqlFaceType,
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -83,7 +241,118 @@ func init() {
 			if e := gqlserver.DecodeJSON(p.Args["locator"], &locw); e != nil {
 				return nil, e
 			}
-			return locw.Locator.CreateFace()
+			face, e := locw.Locator.CreateFace()
+			if e != nil {
+				return nil, e
+			}
+			if e := face.SetPersistency(p.Args["persistency"].(Persistency)); e != nil {
+				face.Close()
+				return nil, e
+			}
+			return face, nil
 		},
 	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setFacePersistency",
+		Description: "Change face persistency level.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: gqlserver.NonNullID,
+			},
+			"persistency": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(GqlPersistencyType),
+			},
+		},
+		Type: graphql.NewNonNull(GqlFaceType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			face, e := gqlserver.RetrieveNodeOfType(GqlFaceNodeType, p.Args["id"])
+			if face == nil || e != nil {
+				return nil, fmt.Errorf("face not found: %w", e)
+			}
+			if e := face.(Face).SetPersistency(p.Args["persistency"].(Persistency)); e != nil {
+				return nil, e
+			}
+			return face, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setFaceAcl",
+		Description: "Replace face ACL rules in one direction.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type: gqlserver.NonNullID,
+			},
+			"dir": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(GqlAclDirType),
+			},
+			"rules": &graphql.ArgumentConfig{
+				Description: fmt.Sprintf("ACL rules, up to %d. The first matching rule allows or denies a packet; a packet is allowed if no rule matches.", MaxAclRules),
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlAclRuleInputType))),
+			},
+		},
+		Type: graphql.NewNonNull(GqlFaceType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			face, e := gqlserver.RetrieveNodeOfType(GqlFaceNodeType, p.Args["id"])
+			if face == nil || e != nil {
+				return nil, fmt.Errorf("face not found: %w", e)
+			}
+			if e := face.(Face).SetAclRules(p.Args["dir"].(AclDir), parseGqlAclRules(p.Args["rules"])); e != nil {
+				return nil, e
+			}
+			return face, nil
+		},
+	})
+
+	GqlFaceEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "FaceEvent",
+		Fields: graphql.Fields{
+			"event": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewEnum(graphql.EnumConfig{
+					Name:        "FaceEventKind",
+					Description: "Face event kind.",
+					Values: graphql.EnumValueConfigMap{
+						"CREATED": &graphql.EnumValueConfig{Value: evtFaceNew},
+						"UP":      &graphql.EnumValueConfig{Value: evtFaceUp},
+						"DOWN":    &graphql.EnumValueConfig{Value: evtFaceDown},
+						"CLOSED":  &graphql.EnumValueConfig{Value: evtFaceClosed},
+					},
+				})),
+				Description: "Event kind.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(gqlFaceEvent).Event, nil
+				},
+			},
+			"nid": &graphql.Field{
+				Type:        gqlserver.NonNullInt,
+				Description: "Numeric face identifier.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(gqlFaceEvent).ID), nil
+				},
+			},
+			"face": &graphql.Field{
+				Type:        GqlFaceType,
+				Description: "Face object, if it still exists.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if face := Get(p.Source.(gqlFaceEvent).ID); face != nil {
+						return face, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	for _, evt := range []string{evtFaceNew, evtFaceUp, evtFaceDown, evtFaceClosed} {
+		evt := evt
+		emitter.On(evt, func(id ID) { gqlFacePublisher.Publish(gqlFaceEvent{evt, id}) })
+	}
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "faceEvents",
+		Description: "Face creation, state change, and destruction events.",
+		Type:        graphql.NewNonNull(GqlFaceEventType),
+	}, func(p graphql.ResolveParams) (<-chan interface{}, error) {
+		return gqlFacePublisher.Subscribe(p.Context), nil
+	})
 }
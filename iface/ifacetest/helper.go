@@ -0,0 +1,26 @@
+// Package ifacetest contains C tests for iface package.
+package ifacetest
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+var (
+	makeAR = testenv.MakeAR
+
+	rxl iface.RxLoop
+	txl iface.TxLoop
+)
+
+func initLoops() {
+	rxl = iface.NewRxLoop(eal.NumaSocket{})
+	rxl.InterestDemux().InitDrop()
+	rxl.DataDemux().InitDrop()
+	rxl.NackDemux().InitDrop()
+	ealthread.Launch(rxl)
+	txl = iface.NewTxLoop(eal.NumaSocket{})
+	ealthread.Launch(txl)
+}
@@ -0,0 +1,97 @@
+package ifacetest
+
+/*
+#include "../../csrc/iface/face.h"
+*/
+import "C"
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf/mbuftestenv"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// makeFragments creates nData Data packets, each split into 4 fragments, received on a face.
+func makeFragments(face *intface.IntFace, nData int) (frames []*C.struct_rte_mbuf) {
+	fragmenter := ndn.NewLpFragmenter(1000)
+	for i := 0; i < nData; i++ {
+		data := ndn.MakeData("/D", bytes.Repeat([]byte{0xCC}, 3000))
+		frags, e := fragmenter.Fragment(data.ToPacket())
+		if e != nil || len(frags) != 4 {
+			panic(e)
+		}
+		for _, frag := range frags {
+			wire, _ := tlv.Encode(frag)
+			m := mbuftestenv.MakePacket(wire)
+			m.SetPort(uint16(face.ID))
+			m.SetTimestamp(eal.TscNow())
+			frames = append(frames, (*C.struct_rte_mbuf)(m.Ptr()))
+		}
+	}
+	return frames
+}
+
+func ctestRxProcHandoff(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+	rx := &(*C.Face)(face.D.Ptr()).impl.rx
+
+	// thread 0 reassembles directly
+	var nDirect int
+	for _, frame := range makeFragments(face, 1) {
+		if npkt := C.RxProc_Input(rx, 0, frame); npkt != nil {
+			nDirect++
+			C.rte_pktmbuf_free(C.Packet_ToMbuf(npkt))
+		}
+	}
+	assert.Equal(1, nDirect)
+
+	// thread 1 hands off; fragments are drained by the RxLoop, which never receives this face on thread 0
+	for _, frame := range makeFragments(face, 2) {
+		assert.Nil(C.RxProc_Input(rx, 1, frame))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	cnt := face.D.ReadCounters()
+	assert.EqualValues(8, cnt.ReassRedirects)
+	assert.EqualValues(0, cnt.ReassQueueDrops)
+	assert.EqualValues(3, cnt.ReassPackets)
+	assert.EqualValues(3, cnt.RxData)
+}
+
+func ctestRxProcQueueFull(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	var cfg socketface.Config
+	cfg.ReassemblerQueueSize = 64
+	face := intface.Must(intface.New(cfg))
+	defer face.D.Close()
+	rx := &(*C.Face)(face.D.Ptr()).impl.rx
+
+	// while the reassembler is busy, thread 0 also hands off, and the queue overflows
+	C.rte_spinlock_lock(&rx.reassLock)
+	frames := makeFragments(face, 18)
+	for _, frame := range frames[:4] {
+		assert.Nil(C.RxProc_Input(rx, 0, frame))
+	}
+	for _, frame := range frames[4:] {
+		assert.Nil(C.RxProc_Input(rx, 1, frame))
+	}
+	C.rte_spinlock_unlock(&rx.reassLock)
+	time.Sleep(100 * time.Millisecond)
+
+	// ring of capacity 64 holds 63 fragments: 15 complete packets and 3 fragments of the 16th
+	cnt := face.D.ReadCounters()
+	assert.EqualValues(63, cnt.ReassRedirects)
+	assert.EqualValues(72-63, cnt.ReassQueueDrops)
+	assert.EqualValues(15, cnt.ReassPackets)
+	assert.EqualValues(15, cnt.RxData)
+}
@@ -0,0 +1,16 @@
+package ifacetest
+
+//go:generate bash ../../mk/cgotest.sh
+
+import (
+	"os"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealtestenv"
+)
+
+func TestMain(m *testing.M) {
+	ealtestenv.Init()
+	initLoops()
+	os.Exit(m.Run())
+}
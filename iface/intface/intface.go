@@ -34,7 +34,10 @@ type IntFace struct {
 func New(cfg socketface.Config) (*IntFace, error) {
 	var f IntFace
 
-	trA, trD, e := sockettransport.Pipe(sockettransport.Config{})
+	var trCfg sockettransport.Config
+	trCfg.RxQueueSize = cfg.RxQueueSize
+	trCfg.TxQueueSize = cfg.TxQueueSize
+	trA, trD, e := sockettransport.Pipe(trCfg)
 	if e != nil {
 		return nil, e
 	}
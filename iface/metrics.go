@@ -0,0 +1,59 @@
+package iface
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+)
+
+func collectMetrics(w *metrics.Writer) {
+	for _, face := range List() {
+		labels := []string{"face", strconv.Itoa(int(face.ID()))}
+		if loc := face.Locator(); loc != nil {
+			labels = append(labels, "scheme", loc.Scheme())
+		}
+		cnt := face.ReadCounters()
+
+		w.Counter("face_rx_frames_total", "Frames received on face.", cnt.RxFrames, labels...)
+		w.Counter("face_rx_octets_total", "Octets received on face.", cnt.RxOctets, labels...)
+		w.Counter("face_rx_packets_total", "L3 packets received on face.", cnt.RxInterests, append(labels, "type", "interest")...)
+		w.Counter("face_rx_packets_total", "L3 packets received on face.", cnt.RxData, append(labels, "type", "data")...)
+		w.Counter("face_rx_packets_total", "L3 packets received on face.", cnt.RxNacks, append(labels, "type", "nack")...)
+		w.Counter("face_rx_decode_errors_total", "Frames dropped on face due to decode errors.", cnt.DecodeErrs, labels...)
+		w.Counter("face_rx_reass_drops_total", "Fragments dropped by reassembler.", cnt.ReassDrops+cnt.ReassQueueDrops, labels...)
+
+		w.Counter("face_tx_frames_total", "Frames sent on face.", cnt.TxFrames, labels...)
+		w.Counter("face_tx_octets_total", "Octets sent on face.", cnt.TxOctets, labels...)
+		w.Counter("face_tx_packets_total", "L3 packets sent on face.", cnt.TxInterests, append(labels, "type", "interest")...)
+		w.Counter("face_tx_packets_total", "L3 packets sent on face.", cnt.TxData, append(labels, "type", "data")...)
+		w.Counter("face_tx_packets_total", "L3 packets sent on face.", cnt.TxNacks, append(labels, "type", "nack")...)
+		w.Counter("face_tx_dropped_total", "Frames dropped on face due to full queue.", cnt.TxDropped, labels...)
+		w.Counter("face_tx_alloc_errors_total", "Allocation errors on face send path.", cnt.TxAllocErrs, labels...)
+		for class, tc := range cnt.TxClasses {
+			classLabels := append(labels, "class", strconv.Itoa(class))
+			w.Counter("face_tx_class_drops_total", "Packets dropped due to full before-Tx queue.", tc.NDrops, classLabels...)
+			w.Counter("face_tx_class_cong_marks_total", "Congestion marks inserted on send path.", tc.NCongMarks, classLabels...)
+		}
+
+		const latencyHelp = "Latency from packet arrival or creation to transmission on face."
+		w.Summary("face_tx_latency_nanoseconds", latencyHelp, cnt.InterestLatency, append(labels, "type", "interest")...)
+		w.Summary("face_tx_latency_nanoseconds", latencyHelp, cnt.DataLatency, append(labels, "type", "data")...)
+		w.Summary("face_tx_latency_nanoseconds", latencyHelp, cnt.NackLatency, append(labels, "type", "nack")...)
+	}
+
+	for _, rxl := range ListRxLoops() {
+		if rxl.LCore().Valid() {
+			ealthread.CollectMetrics(w, rxl, rxl.ThreadRole())
+		}
+	}
+	for _, txl := range ListTxLoops() {
+		if txl.LCore().Valid() {
+			ealthread.CollectMetrics(w, txl, txl.ThreadRole())
+		}
+	}
+}
+
+func init() {
+	metrics.AddCollector("iface", collectMetrics)
+}
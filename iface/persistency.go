@@ -0,0 +1,141 @@
+package iface
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+// Persistency indicates face persistency level.
+// Numeric values are same as NFD FacePersistency.
+type Persistency int
+
+// Persistency values.
+const (
+	// PersistencyPersistent indicates the face is kept until it is explicitly destroyed.
+	// When the underlying transport fails, the face reports DOWN state while the transport is
+	// being restored, if the transport supports that.
+	PersistencyPersistent Persistency = iota
+
+	// PersistencyOnDemand indicates the face is destroyed automatically when no frame has been
+	// received for Config.IdleTimeout, or when the underlying transport fails.
+	PersistencyOnDemand
+
+	// PersistencyPermanent indicates the face is kept until it is explicitly destroyed.
+	// When the underlying transport fails, the face remains in UP state while the transport is
+	// being restored, so that routes through this face are not disrupted.
+	PersistencyPermanent
+)
+
+const (
+	// MinIdleTimeout is the minimum idle timeout of on-demand faces.
+	MinIdleTimeout = time.Second
+
+	// DefaultIdleTimeout is the default idle timeout of on-demand faces.
+	DefaultIdleTimeout = 5 * time.Minute
+)
+
+// ErrPersistency indicates an invalid Persistency value.
+var ErrPersistency = errors.New("invalid face persistency")
+
+var persistencyStrings = map[Persistency]string{
+	PersistencyPersistent: "persistent",
+	PersistencyOnDemand:   "on-demand",
+	PersistencyPermanent:  "permanent",
+}
+
+func (p Persistency) String() string {
+	if s, ok := persistencyStrings[p]; ok {
+		return s
+	}
+	return strconv.Itoa(int(p))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Persistency) MarshalText() (text []byte, e error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Persistency) UnmarshalText(text []byte) error {
+	s := strings.ToLower(string(text))
+	for value, str := range persistencyStrings {
+		if s == str {
+			*p = value
+			return nil
+		}
+	}
+	return ErrPersistency
+}
+
+func (f *face) Persistency() Persistency {
+	return Persistency(atomic.LoadInt32(&f.persistency))
+}
+
+func (f *face) SetPersistency(p Persistency) (e error) {
+	if _, ok := persistencyStrings[p]; !ok {
+		return ErrPersistency
+	}
+
+	eal.CallMain(func() {
+		if f.closed {
+			e = errors.New("face is closed")
+			return
+		}
+		if f.Persistency() == p {
+			return
+		}
+		atomic.StoreInt32(&f.persistency, int32(p))
+		f.stopIdleMonitor()
+		if p == PersistencyOnDemand {
+			f.idleStop = make(chan struct{})
+			go f.idleMonitor(f.idleStop)
+		}
+	})
+	return e
+}
+
+// stopIdleMonitor stops idle monitor goroutine.
+// This must be called on main thread.
+func (f *face) stopIdleMonitor() {
+	if f.idleStop != nil {
+		close(f.idleStop)
+		f.idleStop = nil
+	}
+}
+
+// idleMonitor closes the face if no frame has been received for f.idleTimeout.
+func (f *face) idleMonitor(stop <-chan struct{}) {
+	ticker := time.NewTicker(f.idleTimeout / 4)
+	defer ticker.Stop()
+
+	lastRx, lastActive := f.ReadCounters().RxFrames, time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if rx := f.ReadCounters().RxFrames; rx != lastRx {
+				lastRx, lastActive = rx, now
+				continue
+			}
+			if now.Sub(lastActive) < f.idleTimeout {
+				continue
+			}
+		}
+
+		eal.CallMain(func() {
+			select {
+			case <-stop: // persistency changed or face closed in the meantime
+			default:
+				log.WithField("face", f.id).Info("closing idle on-demand face")
+				f.close()
+			}
+		})
+		return
+	}
+}
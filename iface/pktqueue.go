@@ -85,10 +85,14 @@ func (q *PktQueue) Init(cfg PktQueueConfig, socket eal.NumaSocket) error {
 	return nil
 }
 
+// Ring returns the underlying ring.
+func (q *PktQueue) Ring() *ringbuffer.Ring {
+	return ringbuffer.FromPtr(unsafe.Pointer(q.ptr().ring))
+}
+
 // Close deallocates the PktQueue.
 func (q *PktQueue) Close() error {
-	ring := ringbuffer.FromPtr(unsafe.Pointer(q.ptr().ring))
-	return ring.Close()
+	return q.Ring().Close()
 }
 
 // Push enqueues a slice of packets.
@@ -33,6 +33,8 @@ type RxLoop interface {
 	eal.WithNumaSocket
 	io.Closer
 
+	ThreadLoadStat() ealthread.LoadStat
+
 	InterestDemux() *InputDemux
 	DataDemux() *InputDemux
 	NackDemux() *InputDemux
@@ -71,6 +73,10 @@ func (rxl *rxLoop) NumaSocket() eal.NumaSocket {
 	return rxl.socket
 }
 
+func (rxl *rxLoop) ThreadLoadStat() ealthread.LoadStat {
+	return ealthread.LoadStatFromPtr(unsafe.Pointer(&rxl.c.loadStat))
+}
+
 func (rxl *rxLoop) Close() error {
 	rxl.Stop()
 	delete(rxLoopThreads, rxl)
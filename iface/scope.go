@@ -0,0 +1,75 @@
+package iface
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Scope indicates whether a face is local or non-local.
+// It is used in /localhost and /localhop scope control.
+type Scope int
+
+// Scope values.
+const (
+	// ScopeDefault indicates the scope is determined from the locator scheme.
+	// A face is local if its scheme is listed in LocalSchemes, otherwise it is non-local.
+	ScopeDefault Scope = iota
+
+	// ScopeLocal indicates the face connects to a local application.
+	ScopeLocal
+
+	// ScopeNonLocal indicates the face connects to a remote forwarder or application.
+	ScopeNonLocal
+)
+
+// ErrScope indicates an invalid Scope value.
+var ErrScope = errors.New("invalid face scope")
+
+// LocalSchemes lists locator schemes that are local by default.
+var LocalSchemes = map[string]bool{
+	"unix":  true, // Unix socket
+	"memif": true, // shared memory packet interface
+	"pipe":  true, // internal face, see package intface
+}
+
+var scopeStrings = map[Scope]string{
+	ScopeDefault:  "",
+	ScopeLocal:    "local",
+	ScopeNonLocal: "non-local",
+}
+
+func (s Scope) String() string {
+	if str, ok := scopeStrings[s]; ok {
+		return str
+	}
+	return strconv.Itoa(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Scope) MarshalText() (text []byte, e error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Scope) UnmarshalText(text []byte) error {
+	str := strings.ToLower(string(text))
+	for value, vstr := range scopeStrings {
+		if str == vstr {
+			*s = value
+			return nil
+		}
+	}
+	return ErrScope
+}
+
+// resolve determines whether a face with this scope and a locator is local.
+func (s Scope) resolve(loc Locator) bool {
+	switch s {
+	case ScopeLocal:
+		return true
+	case ScopeNonLocal:
+		return false
+	}
+	return loc != nil && LocalSchemes[loc.Scheme()]
+}
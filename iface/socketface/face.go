@@ -85,9 +85,14 @@ func Wrap(transport sockettransport.Transport, cfg Config) (iface.Face, error) {
 		},
 		Start: func(f iface.Face) (iface.Face, error) {
 			face.transport.OnStateChange(func(st l3.TransportState) {
-				if st == l3.TransportUp {
+				switch {
+				case st == l3.TransportUp:
 					f.SetDown(false)
-				} else {
+				case st == l3.TransportDown && f.Persistency() == iface.PersistencyOnDemand:
+					go f.Close()
+				case st == l3.TransportDown && f.Persistency() == iface.PersistencyPermanent:
+					// remain UP while redialing
+				default:
 					f.SetDown(true)
 				}
 			})
@@ -0,0 +1,167 @@
+package iface
+
+/*
+#include "../csrc/iface/face.h"
+*/
+import "C"
+import (
+	"errors"
+	"sort"
+	"unsafe"
+
+	"github.com/pkg/math"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Errors returned by TxClassConfig validation.
+var (
+	ErrTxClassRules     = errors.New("too many traffic classification rules")
+	ErrTxClass          = errors.New("traffic class out of range")
+	ErrTxClassPrefix    = errors.New("traffic classification rule prefix too long")
+	ErrTxClassDupPrefix = errors.New("duplicate prefix in traffic classification rules")
+)
+
+// TxClassRule assigns packets under a name prefix to a traffic class.
+type TxClassRule struct {
+	Prefix ndn.Name `json:"prefix"`
+	Class  int      `json:"class"`
+}
+
+// TxClassConfig contains traffic class configuration on the send path.
+//
+// There are NTxClasses traffic classes.
+// TxClassPriority is served with strict priority.
+// Other classes share the remaining capacity with deficit round robin.
+// Packets are assigned to class 0, unless classified otherwise by LpL3.trafficClass field or rules.
+type TxClassConfig struct {
+	// Rules assigns packets to traffic classes by name prefix.
+	// A packet is classified under the rule with longest matching prefix.
+	Rules []TxClassRule `json:"rules,omitempty"`
+
+	// Quantum is the deficit round robin quantum in octets, for each class except TxClassPriority.
+	// Zero or missing values default to DefaultTxClassQuantum.
+	Quantum []int `json:"quantum,omitempty"`
+}
+
+func (cfg TxClassConfig) validate() error {
+	if len(cfg.Rules) > MaxTxClassRules {
+		return ErrTxClassRules
+	}
+	for i, rule := range cfg.Rules {
+		if rule.Class < 0 || rule.Class >= NTxClasses {
+			return ErrTxClass
+		}
+		if rule.Prefix.Length() > ndni.NameMaxLength {
+			return ErrTxClassPrefix
+		}
+		for _, prev := range cfg.Rules[:i] {
+			if prev.Prefix.Equal(rule.Prefix) {
+				return ErrTxClassDupPrefix
+			}
+		}
+	}
+	if len(cfg.Quantum) > TxClassPriority {
+		return ErrTxClass
+	}
+	return nil
+}
+
+func (cfg TxClassConfig) quantum(class int) int {
+	if class < len(cfg.Quantum) && cfg.Quantum[class] > 0 {
+		return cfg.Quantum[class]
+	}
+	return DefaultTxClassQuantum
+}
+
+// TxClassCounters contains counters of a traffic class.
+type TxClassCounters struct {
+	NPkts      uint64 `json:"nPkts"`      // dequeued packets
+	NOctets    uint64 `json:"nOctets"`    // dequeued octets
+	NDrops     uint64 `json:"nDrops"`     // packets dropped due to full queue
+	NCongMarks uint64 `json:"nCongMarks"` // inserted congestion marks
+	QueueSize  int    `json:"queueSize"`  // current queue length
+}
+
+// initTxClasses creates before-Tx queues and classifier.
+func (f *face) initTxClasses(p NewParams) error {
+	if e := p.TxClasses.validate(); e != nil {
+		return e
+	}
+
+	impl := f.ptr().impl
+	for class := range impl.txClass {
+		tc := &impl.txClass[class]
+		queue, e := ringbuffer.New(p.OutputQueueSize, p.Socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
+		if e != nil {
+			return e
+		}
+		tc.queue = (*C.struct_rte_ring)(queue.Ptr())
+		tc.congMarkThreshold = C.uint32_t(math.MaxInt(0, p.CongMarkThreshold))
+		if class != TxClassPriority {
+			tc.quantum = C.int32_t(p.TxClasses.quantum(class))
+		}
+	}
+
+	if len(p.TxClasses.Rules) == 0 {
+		return nil
+	}
+	rules := append([]TxClassRule{}, p.TxClasses.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].Prefix) > len(rules[j].Prefix) })
+
+	classifier := (*C.FaceTxClassifier)(eal.Zmalloc("FaceTxClassifier", C.sizeof_FaceTxClassifier, p.Socket))
+	for i, rule := range rules {
+		r := &classifier.rules[i]
+		r.txClass = C.uint8_t(rule.Class)
+		prefixV, _ := rule.Prefix.MarshalBinary()
+		for j, b := range prefixV {
+			r.prefixV[j] = C.uint8_t(b)
+		}
+		r.prefixL = C.uint16_t(len(prefixV))
+	}
+	classifier.nRules = C.uint32_t(len(rules))
+	impl.txClassifier = classifier
+	return nil
+}
+
+// closeTxClasses releases before-Tx queues and classifier.
+func (f *face) closeTxClasses() {
+	impl := f.ptr().impl
+	for class := range impl.txClass {
+		tc := &impl.txClass[class]
+		if tc.queue == nil {
+			continue
+		}
+		queue := ringbuffer.FromPtr(unsafe.Pointer(tc.queue))
+		vec := make(pktmbuf.Vector, queue.CountInUse())
+		vec = vec[:queue.Dequeue(vec)]
+		vec.Close()
+		queue.Close()
+		tc.queue = nil
+	}
+
+	if impl.txClassifier != nil {
+		eal.Free(impl.txClassifier)
+		impl.txClassifier = nil
+	}
+}
+
+// readTxClassCounters retrieves traffic class counters.
+func (f *face) readTxClassCounters() (list [NTxClasses]TxClassCounters) {
+	impl := f.ptr().impl
+	for class := range impl.txClass {
+		tc := &impl.txClass[class]
+		cnt := &list[class]
+		cnt.NPkts = uint64(tc.nPkts)
+		cnt.NOctets = uint64(tc.nOctets)
+		cnt.NDrops = uint64(tc.nDrops)
+		cnt.NCongMarks = uint64(tc.nCongMarks)
+		if tc.queue != nil {
+			cnt.QueueSize = ringbuffer.FromPtr(unsafe.Pointer(tc.queue)).CountInUse()
+		}
+	}
+	return list
+}
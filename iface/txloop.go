@@ -22,6 +22,8 @@ type TxLoop interface {
 	eal.WithNumaSocket
 	io.Closer
 
+	ThreadLoadStat() ealthread.LoadStat
+
 	CountFaces() int
 	add(face Face)
 	remove(face Face)
@@ -56,6 +58,10 @@ func (txl *txLoop) NumaSocket() eal.NumaSocket {
 	return txl.socket
 }
 
+func (txl *txLoop) ThreadLoadStat() ealthread.LoadStat {
+	return ealthread.LoadStatFromPtr(unsafe.Pointer(&txl.c.loadStat))
+}
+
 func (txl *txLoop) Close() error {
 	txl.Stop()
 	delete(txLoopThreads, txl)
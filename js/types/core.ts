@@ -87,6 +87,34 @@ export interface RunningStatSnapshot {
   m2: number;
 }
 
+/**
+ * Snapshot from runningstat quantile sketch.
+ */
+export interface QuantileSnapshot {
+  /**
+   * Number of inputs.
+   * @TJS-type integer
+   * @minimum 0
+   */
+  count: number;
+
+  /**
+   * Multiplier from bucket values to reported quantiles.
+   */
+  scale: number;
+
+  /**
+   * Nonzero buckets, each is [bucket index, count].
+   */
+  buckets: Array<[number, number]>;
+
+  /**
+   * Selected quantiles, keyed by quantile such as "0.99".
+   * This is omitted if count is zero.
+   */
+  quantiles?: Record<string, number>;
+}
+
 /**
  * Name represented as canonical URI.
  */
@@ -1,4 +1,4 @@
-import type { Counter, RunningStatSnapshot } from "./core";
+import type { Counter, QuantileSnapshot, RunningStatSnapshot } from "./core";
 import type { LCore } from "./dpdk";
 
 export interface FwdpInputInfo {
@@ -12,11 +12,15 @@ export interface FwdpFwdInfo {
   InputData: FwdpFwdInfo.InputCounter;
   InputNack: FwdpFwdInfo.InputCounter;
   InputLatency: RunningStatSnapshot;
+  InputLatencyQuantiles: QuantileSnapshot;
 
   NNoFibMatch: Counter;
   NDupNonce: Counter;
   NSgNoFwd: Counter;
   NNackMismatch: Counter;
+  NAclDrops: Counter;
+  NLocalhostViolations: Counter;
+  NLocalhopViolations: Counter;
 
   HeaderMpUsage: Counter;
   IndirectMpUsage: Counter;
@@ -25,12 +25,48 @@ export interface FaceConfig {
    */
   outputQueueSize?: number;
 
+  txClasses?: TxClassConfig;
+
+  /**
+   * @TJS-type integer
+   * @minimum 0
+   * @default 0
+   */
+  congMarkThreshold?: number;
+
   /**
    * @TJS-type integer
    * @minimum 1280
    * @maximum 65000
    */
   mtu?: number;
+
+  /**
+   * Whether the face is local or non-local.
+   * Default is determined from locator scheme.
+   */
+  scope?: "local"|"non-local";
+}
+
+export interface TxClassConfig {
+  rules?: TxClassRule[];
+
+  /**
+   * @TJS-type integer
+   * @minimum 1
+   */
+  quantum?: number[];
+}
+
+export interface TxClassRule {
+  prefix: string;
+
+  /**
+   * @TJS-type integer
+   * @minimum 0
+   * @maximum 3
+   */
+  class: number;
 }
 
 export interface EthFaceLocator {
@@ -144,6 +180,16 @@ export interface FaceCounters {
   TxDropped: Counter;
   TxFrames: Counter;
   TxOctets: Counter;
+
+  TxClasses: TxClassCounters[];
+}
+
+export interface TxClassCounters {
+  nPkts: Counter;
+  nOctets: Counter;
+  nDrops: Counter;
+  nCongMarks: Counter;
+  queueSize: number;
 }
 
 export interface CreateFaceConfig {
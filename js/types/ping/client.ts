@@ -1,4 +1,4 @@
-import type { Counter, Name, NNMilliseconds, NNNanoseconds, RunningStatSnapshot } from "../core";
+import type { Counter, Name, NNMilliseconds, NNNanoseconds, QuantileSnapshot, RunningStatSnapshot } from "../core";
 import type { PktQueueConfig } from "../pktqueue";
 
 export interface PingClientConfig {
@@ -48,7 +48,7 @@ export interface PingClientPattern {
 
 export interface PingClientCounters extends PingClientCounters.PacketCounters {
   NAllocError: Counter;
-  Rtt: RunningStatSnapshot;
+  Rtt: PingClientCounters.RttCounters;
   PerPattern: PingClientCounters.PatternCounters[];
 }
 
@@ -59,7 +59,11 @@ export namespace PingClientCounters {
     NNacks: Counter;
   }
 
+  export interface RttCounters extends RunningStatSnapshot {
+    quantiles: QuantileSnapshot;
+  }
+
   export interface PatternCounters extends PacketCounters {
-    Rtt: RunningStatSnapshot;
+    Rtt: RttCounters;
   }
 }
@@ -1,4 +1,4 @@
-import type { Counter, NNNanoseconds } from "../core";
+import type { Counter, NNNanoseconds, QuantileSnapshot } from "../core";
 import type { PktQueueConfig } from "../pktqueue";
 
 export interface FetchConfig {
@@ -31,6 +31,7 @@ export interface FetchCounters {
   LastRtt: NNNanoseconds;
   SRtt: NNNanoseconds;
   Rto: NNNanoseconds;
+  Rtt: QuantileSnapshot;
   Cwnd: Counter;
   NInFlight: Counter;
   NTxRetx: Counter;
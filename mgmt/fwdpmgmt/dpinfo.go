@@ -4,7 +4,6 @@ import (
 	"errors"
 
 	"github.com/usnistgov/ndn-dpdk/app/fwdp"
-	"github.com/usnistgov/ndn-dpdk/container/cs"
 	"github.com/usnistgov/ndn-dpdk/container/pit"
 )
 
@@ -44,24 +43,12 @@ func (mg DpInfoMgmt) Pit(arg IndexArg, reply *pit.Counters) error {
 	return nil
 }
 
-func readCslCnt(cs *cs.Cs, list cs.ListID) (cnt CsListCounters) {
-	cnt.Count = cs.CountEntries(list)
-	cnt.Capacity = cs.Capacity(list)
-	return cnt
-}
-
-func (mg DpInfoMgmt) Cs(arg IndexArg, reply *CsCounters) error {
-	thePit, theCs := mg.Dp.GetFwdPit(arg.Index), mg.Dp.GetFwdCs(arg.Index)
-	if thePit == nil || theCs == nil {
+func (mg DpInfoMgmt) Cs(arg IndexArg, reply *fwdp.CsCounters) error {
+	reply1 := mg.Dp.ReadCsCounters(arg.Index)
+	if reply1 == nil {
 		return errors.New("index out of range")
 	}
-	pitCnt := thePit.ReadCounters()
-
-	reply.MD = readCslCnt(theCs, cs.ListMd)
-	reply.MI = readCslCnt(theCs, cs.ListMi)
-	reply.NHits = pitCnt.NCsMatch
-	reply.NMisses = pitCnt.NInsert + pitCnt.NFound
-
+	*reply = *reply1
 	return nil
 }
 
@@ -73,16 +60,3 @@ type FwdpInfo struct {
 	NInputs int
 	NFwds   int
 }
-
-type CsListCounters struct {
-	Count    int
-	Capacity int
-}
-
-type CsCounters struct {
-	MD CsListCounters // in-memory direct entries
-	MI CsListCounters // in-memory indirect entries
-
-	NHits   uint64
-	NMisses uint64
-}
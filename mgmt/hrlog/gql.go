@@ -0,0 +1,79 @@
+package hrlog
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+)
+
+// GraphQL types.
+var (
+	GqlJobNodeType *gqlserver.NodeType
+	GqlJobType     *graphql.Object
+)
+
+func init() {
+	GqlJobNodeType = gqlserver.NewNodeTypeNamed("HrlogJob", (*collectJob)(nil))
+	GqlJobNodeType.GetID = func(source interface{}) string {
+		return source.(*collectJob).Filename
+	}
+	GqlJobNodeType.Retrieve = func(id string) (interface{}, error) {
+		return findJob(id), nil
+	}
+	GqlJobNodeType.Delete = func(source interface{}) error {
+		return stopJob(source.(*collectJob).Filename)
+	}
+
+	GqlJobType = graphql.NewObject(GqlJobNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "HrlogJob",
+		Description: "High resolution log collection job. Deleting the job stops the collection and closes the file.",
+		Fields: graphql.Fields{
+			"filename": &graphql.Field{
+				Description: "Output filename.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*collectJob).Filename, nil
+				},
+			},
+			"count": &graphql.Field{
+				Description: "Maximum number of log entries.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*collectJob).Count, nil
+				},
+			},
+		},
+	}))
+	GqlJobNodeType.Register(GqlJobType)
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "hrlogJobs",
+		Description: "Running high resolution log collection jobs.",
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlJobType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return listJobs(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "collectHrlog",
+		Description: "Start collecting high resolution log entries to a file.",
+		Args: graphql.FieldConfigArgument{
+			"filename": &graphql.ArgumentConfig{
+				Description: "Output filename.",
+				Type:        gqlserver.NonNullString,
+			},
+			"count": &graphql.ArgumentConfig{
+				Description:  "Maximum number of log entries. 0 means 2^28 entries.",
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+		},
+		Type: graphql.NewNonNull(GqlJobType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var args StartArgs
+			args.Filename = p.Args["filename"].(string)
+			args.Count = p.Args["count"].(int)
+			return startJob(args)
+		},
+	})
+}
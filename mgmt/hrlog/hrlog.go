@@ -7,6 +7,7 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"unsafe"
 
@@ -32,13 +33,22 @@ func Init() error {
 type HrlogMgmt struct{}
 
 func (HrlogMgmt) Start(args StartArgs, reply *struct{}) error {
+	_, e := startJob(args)
+	return e
+}
+
+func (HrlogMgmt) Stop(args FilenameArg, reply *struct{}) error {
+	return stopJob(args.Filename)
+}
+
+func startJob(args StartArgs) (job *collectJob, e error) {
 	collectJobsLock.Lock()
 	defer collectJobsLock.Unlock()
 	if _, ok := collectJobs[args.Filename]; ok {
-		return errors.New("duplicate collect job")
+		return nil, errors.New("duplicate collect job")
 	}
 
-	job := new(collectJob)
+	job = new(collectJob)
 	job.StartArgs = args
 	if job.Count == 0 {
 		job.Count = 1 << 28 // 268 million samples, 2GB file
@@ -48,15 +58,11 @@ func (HrlogMgmt) Start(args StartArgs, reply *struct{}) error {
 
 	collectJobs[args.Filename] = job
 	collectStart <- job
-	return nil
+	return job, nil
 }
 
-func (HrlogMgmt) Stop(args FilenameArg, reply *struct{}) error {
-	job := func() *collectJob {
-		collectJobsLock.Lock()
-		defer collectJobsLock.Unlock()
-		return collectJobs[args.Filename]
-	}()
+func stopJob(filename string) error {
+	job := findJob(filename)
 	if job == nil {
 		return errors.New("job not found")
 	}
@@ -68,10 +74,27 @@ func (HrlogMgmt) Stop(args FilenameArg, reply *struct{}) error {
 
 	collectJobsLock.Lock()
 	defer collectJobsLock.Unlock()
-	delete(collectJobs, args.Filename)
+	delete(collectJobs, filename)
 	return e
 }
 
+func findJob(filename string) *collectJob {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	return collectJobs[filename]
+}
+
+func listJobs() (list []*collectJob) {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	list = []*collectJob{}
+	for _, job := range collectJobs {
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Filename < list[j].Filename })
+	return list
+}
+
 type FilenameArg struct {
 	Filename string
 }
@@ -99,7 +122,7 @@ func collectLoop() {
 		filenameC := C.CString(job.Filename)
 		defer C.free(unsafe.Pointer(filenameC))
 
-		res := C.Hrlog_RunWriter(filenameC, ringCapacity, C.int(job.Count), &job.stop)
+		res := C.Hrlog_RunWriter(filenameC, 0, C.int(job.Count), &job.stop)
 		if res != 0 {
 			job.finish <- fmt.Errorf("Hrlog_RunWriter error %d", res)
 		} else {
@@ -0,0 +1,136 @@
+package hrlogreader
+
+import (
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Key identifies a histogram.
+type Key struct {
+	Act   Action
+	LCore int
+}
+
+// Histogram counts durations of one action on one lcore.
+type Histogram struct {
+	Key
+
+	// Counts[i] is the number of entries whose duration is in [i*resolution, (i+1)*resolution).
+	Counts []int
+	// N is the total number of entries.
+	N int `json:"-"`
+
+	resolution time.Duration
+}
+
+// NewHistogram creates a Histogram.
+// resolution must be positive.
+func NewHistogram(key Key, resolution time.Duration) (*Histogram, error) {
+	if resolution <= 0 {
+		return nil, ErrResolution
+	}
+	return &Histogram{
+		Key:        key,
+		Counts:     make([]int, 4096),
+		resolution: resolution,
+	}, nil
+}
+
+// Resolution returns the bin width.
+func (h *Histogram) Resolution() time.Duration {
+	return h.resolution
+}
+
+// Add adds a duration.
+func (h *Histogram) Add(d time.Duration) {
+	bin := 0
+	if d > 0 {
+		bin = int(d / h.resolution)
+	}
+	if bin >= len(h.Counts) {
+		nBins := len(h.Counts) * 2
+		for bin >= nBins {
+			nBins *= 2
+		}
+		counts := make([]int, nBins)
+		copy(counts, h.Counts)
+		h.Counts = counts
+	}
+	h.Counts[bin]++
+	h.N++
+}
+
+// Trim removes empty bins at the end of Counts.
+func (h *Histogram) Trim() {
+	right := len(h.Counts) - 1
+	for right > 0 && h.Counts[right] == 0 {
+		right--
+	}
+	h.Counts = h.Counts[:right+1]
+}
+
+// Quantile returns the q-quantile, 0 <= q <= 1.
+// The result is the upper edge of the bin containing the quantile.
+// It returns zero if the histogram is empty.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.N == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q * float64(h.N)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	sum := 0
+	for bin, count := range h.Counts {
+		if sum += count; sum >= rank {
+			return time.Duration(bin+1) * h.resolution
+		}
+	}
+	return time.Duration(len(h.Counts)) * h.resolution
+}
+
+// Histograms contains histograms keyed by action and lcore.
+type Histograms map[Key]*Histogram
+
+// ReadHistograms reads all entries and computes histograms.
+func ReadHistograms(reader *Reader, resolution time.Duration) (hists Histograms, e error) {
+	if resolution <= 0 {
+		return nil, ErrResolution
+	}
+
+	hists = make(Histograms)
+	for {
+		entry, e := reader.Read()
+		if e == io.EOF {
+			return hists, nil
+		} else if e != nil {
+			return hists, e
+		}
+
+		key := Key{Act: entry.Action, LCore: entry.LCore}
+		h := hists[key]
+		if h == nil {
+			h, _ = NewHistogram(key, resolution)
+			hists[key] = h
+		}
+		h.Add(reader.Duration(entry))
+	}
+}
+
+// Sorted returns histograms sorted by action and lcore.
+func (hists Histograms) Sorted() (list []*Histogram) {
+	list = make([]*Histogram, 0, len(hists))
+	for _, h := range hists {
+		list = append(list, h)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Act != list[j].Act {
+			return list[i].Act < list[j].Act
+		}
+		return list[i].LCore < list[j].LCore
+	})
+	return list
+}
@@ -0,0 +1,120 @@
+package hrlogreader_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/mgmt/hrlog/hrlogreader"
+)
+
+var makeAR = testenv.MakeAR
+
+func makeFile(order binary.ByteOrder, version uint32, entries ...uint64) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, order, uint32(hrlogreader.Magic))
+	binary.Write(&b, order, version)
+	binary.Write(&b, order, uint64(1000000)) // 1 TSC = 1us
+	for _, entry := range entries {
+		binary.Write(&b, order, entry)
+	}
+	return b.Bytes()
+}
+
+func makeEntry(act hrlogreader.Action, lcore uint8, value uint64) uint64 {
+	return value<<16 | uint64(lcore)<<8 | uint64(act)
+}
+
+func TestReader(t *testing.T) {
+	assert, require := makeAR(t)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		file := makeFile(order, hrlogreader.Version,
+			makeEntry(hrlogreader.ActOI, 3, 25),
+			makeEntry(hrlogreader.ActSG, 7, 1<<40),
+		)
+		file = append(file, 0xFF, 0xFF) // partial entry
+
+		r, e := hrlogreader.NewReader(bytes.NewReader(file))
+		require.NoError(e)
+		assert.Equal(order, r.ByteOrder)
+		assert.EqualValues(1000000, r.TscHz)
+
+		entry, e := r.Read()
+		require.NoError(e)
+		assert.Equal(hrlogreader.ActOI, entry.Action)
+		assert.Equal(3, entry.LCore)
+		assert.EqualValues(25, entry.Value)
+		assert.Equal(25*time.Microsecond, r.Duration(entry))
+
+		entry, e = r.Read()
+		require.NoError(e)
+		assert.Equal(hrlogreader.ActSG, entry.Action)
+		assert.Equal("SG", entry.Action.String())
+		assert.Equal(7, entry.LCore)
+		assert.EqualValues(uint64(1<<40), entry.Value)
+
+		_, e = r.Read()
+		assert.Equal(io.EOF, e)
+	}
+
+	_, e := hrlogreader.NewReader(bytes.NewReader(makeFile(binary.LittleEndian, 1)))
+	assert.True(errors.Is(e, hrlogreader.ErrVersion))
+
+	file := makeFile(binary.LittleEndian, hrlogreader.Version)
+	file[0] ^= 0xFF
+	_, e = hrlogreader.NewReader(bytes.NewReader(file))
+	assert.True(errors.Is(e, hrlogreader.ErrMagic))
+}
+
+func TestHistograms(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var entries []uint64
+	for i := uint64(1); i <= 100; i++ {
+		entries = append(entries, makeEntry(hrlogreader.ActPI, 2, i))
+	}
+	entries = append(entries, makeEntry(hrlogreader.ActCS, 2, 9000), makeEntry(hrlogreader.ActPI, 1, 0))
+
+	r, e := hrlogreader.NewReader(bytes.NewReader(makeFile(binary.LittleEndian, hrlogreader.Version, entries...)))
+	require.NoError(e)
+	hists, e := hrlogreader.ReadHistograms(r, time.Microsecond)
+	require.NoError(e)
+	require.Len(hists, 3)
+
+	list := hists.Sorted()
+	assert.Equal(hrlogreader.Key{Act: hrlogreader.ActPI, LCore: 1}, list[0].Key)
+	assert.Equal(hrlogreader.Key{Act: hrlogreader.ActPI, LCore: 2}, list[1].Key)
+	assert.Equal(hrlogreader.Key{Act: hrlogreader.ActCS, LCore: 2}, list[2].Key)
+
+	pi := list[1]
+	assert.Equal(100, pi.N)
+	assert.Equal(51*time.Microsecond, pi.Quantile(0.5))
+	assert.Equal(100*time.Microsecond, pi.Quantile(0.99))
+	assert.Equal(101*time.Microsecond, pi.Quantile(1))
+	pi.Trim()
+	assert.Len(pi.Counts, 101)
+
+	cs := list[2]
+	assert.GreaterOrEqual(len(cs.Counts), 9001)
+	assert.Equal(9001*time.Microsecond, cs.Quantile(0.5))
+}
+
+func TestHistogramResolution(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	for _, resolution := range []time.Duration{0, -time.Microsecond} {
+		_, e := hrlogreader.NewHistogram(hrlogreader.Key{Act: hrlogreader.ActPI}, resolution)
+		assert.True(errors.Is(e, hrlogreader.ErrResolution))
+
+		r, e := hrlogreader.NewReader(bytes.NewReader(makeFile(binary.LittleEndian, hrlogreader.Version,
+			makeEntry(hrlogreader.ActPI, 1, 5))))
+		assert.NoError(e)
+		_, e = hrlogreader.ReadHistograms(r, resolution)
+		assert.True(errors.Is(e, hrlogreader.ErrResolution))
+	}
+}
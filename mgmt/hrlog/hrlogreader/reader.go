@@ -0,0 +1,147 @@
+// Package hrlogreader reads high resolution log files written by mgmt/hrlog.
+package hrlogreader
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Magic is the magic number in file header.
+const Magic = 0x35f0498a
+
+// Version is the supported file version.
+// This must match HRLOG_HEADER_VERSION in csrc/hrlog/entry.h.
+const Version = 2
+
+// Errors.
+var (
+	ErrMagic      = errors.New("invalid magic number")
+	ErrVersion    = errors.New("unsupported file version")
+	ErrResolution = errors.New("histogram resolution must be positive")
+)
+
+// Action identifies the action type of a log entry.
+// These must match HrlogAction in csrc/hrlog/entry.h.
+type Action uint8
+
+// Action types.
+const (
+	ActOI Action = 1   // Interest TX since RX
+	ActOD Action = 2   // retrieved Data TX since RX
+	ActOC Action = 4   // cached Data TX since Interest RX
+	ActPI Action = 8   // PIT-CS lookup that inserts or finds a PIT entry
+	ActSG Action = 16  // strategy execution
+	ActCS Action = 32  // PIT-CS lookup that finds a CS entry
+	ActCD Action = 64  // Data digest computation in crypto helper
+	ActTQ Action = 128 // sojourn time in face before-Tx queue
+)
+
+var actionStrings = map[Action]string{
+	ActOI: "OI",
+	ActOD: "OD",
+	ActOC: "OC",
+	ActPI: "PI",
+	ActSG: "SG",
+	ActCS: "CS",
+	ActCD: "CD",
+	ActTQ: "TQ",
+}
+
+func (act Action) String() string {
+	if s, ok := actionStrings[act]; ok {
+		return s
+	}
+	return fmt.Sprintf("%d", uint8(act))
+}
+
+// ParseAction parses an action type from its string representation.
+func ParseAction(s string) (act Action, e error) {
+	for act, str := range actionStrings {
+		if str == s {
+			return act, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action %s", s)
+}
+
+// Header contains information from the file header.
+type Header struct {
+	// ByteOrder is the byte order of the machine that wrote the file.
+	ByteOrder binary.ByteOrder
+
+	// Version is the file version.
+	Version uint32
+
+	// TscHz is the TSC frequency, i.e. one second represented in TSC duration unit.
+	TscHz uint64
+}
+
+// ToDuration converts a value in TSC duration unit to time.Duration.
+func (hdr Header) ToDuration(value uint64) time.Duration {
+	return time.Duration(float64(value) / float64(hdr.TscHz) * float64(time.Second))
+}
+
+// Entry is a log entry.
+type Entry struct {
+	Action Action
+	LCore  int
+	Value  uint64 // duration in TSC unit
+}
+
+// Reader reads log entries from a file.
+type Reader struct {
+	Header
+	r   *bufio.Reader
+	buf [16]byte
+}
+
+// NewReader reads the file header and constructs a Reader.
+func NewReader(r io.Reader) (reader *Reader, e error) {
+	reader = &Reader{
+		r: bufio.NewReader(r),
+	}
+	if _, e = io.ReadFull(reader.r, reader.buf[:16]); e != nil {
+		return nil, e
+	}
+
+	if binary.LittleEndian.Uint32(reader.buf[0:]) == Magic {
+		reader.ByteOrder = binary.LittleEndian
+	} else if binary.BigEndian.Uint32(reader.buf[0:]) == Magic {
+		reader.ByteOrder = binary.BigEndian
+	} else {
+		return nil, ErrMagic
+	}
+
+	if reader.Version = reader.ByteOrder.Uint32(reader.buf[4:]); reader.Version != Version {
+		return nil, fmt.Errorf("%w %d", ErrVersion, reader.Version)
+	}
+	reader.TscHz = reader.ByteOrder.Uint64(reader.buf[8:])
+	return reader, nil
+}
+
+// Read reads the next entry.
+// It returns io.EOF at the end of file.
+// A partial entry at the end of file is ignored.
+func (reader *Reader) Read() (entry Entry, e error) {
+	if _, e = io.ReadFull(reader.r, reader.buf[:8]); e != nil {
+		if e == io.ErrUnexpectedEOF {
+			e = io.EOF
+		}
+		return entry, e
+	}
+
+	v := reader.ByteOrder.Uint64(reader.buf[:8])
+	entry.Action = Action(v)
+	entry.LCore = int(uint8(v >> 8))
+	entry.Value = v >> 16
+	return entry, nil
+}
+
+// Duration returns the entry value as time.Duration.
+func (reader *Reader) Duration(entry Entry) time.Duration {
+	return reader.ToDuration(entry.Value)
+}
@@ -0,0 +1,108 @@
+package loglevel
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+)
+
+// GqlModuleType is the GraphQL type for Module.
+var GqlModuleType *graphql.Object
+
+func levelString(lvl rune) interface{} {
+	return gqlserver.Optional(string(lvl), lvl != 0)
+}
+
+func init() {
+	GqlModuleType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LogModule",
+		Description: "Log module.",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Module name.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Module).Name, nil
+				},
+			},
+			"goLevel": &graphql.Field{
+				Description: "Log level of Go loggers. null if the module has no Go logger.",
+				Type:        graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return levelString(p.Source.(Module).GoLevel), nil
+				},
+			},
+			"cLevel": &graphql.Field{
+				Description: "Log level of C code. null if the module has no C code.",
+				Type:        graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return levelString(p.Source.(Module).CLevel), nil
+				},
+			},
+			"cEffectiveLevel": &graphql.Field{
+				Description: "Effective log level of C code, considering the compile-time minimum level. " +
+					"In RELEASE builds, DEBUG and VERBOSE logging statements are compiled out, so that effective level is at least I. " +
+					"null if the module has no C code.",
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return levelString(p.Source.(Module).CEffectiveLevel), nil
+				},
+			},
+			"revertAt": &graphql.Field{
+				Description: "When log levels revert to their original values. null if not scheduled.",
+				Type:        graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					t := p.Source.(Module).RevertAt
+					return gqlserver.Optional(t, !t.IsZero()), nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "logModules",
+		Description: "List log modules.",
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlModuleType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return List(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setLogLevel",
+		Description: "Change log level of a module or all modules.",
+		Args: graphql.FieldConfigArgument{
+			"module": &graphql.ArgumentConfig{
+				Description: "Module name. Omit to change all modules.",
+				Type:        graphql.String,
+			},
+			"level": &graphql.ArgumentConfig{
+				Description: "Log level letter: V, D, I, W, E, F, or N.",
+				Type:        gqlserver.NonNullString,
+			},
+			"revertAfter": &graphql.ArgumentConfig{
+				Description:  "Revert to original log level after this duration in milliseconds. 0 means permanent.",
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+		},
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlModuleType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			module, _ := p.Args["module"].(string)
+			level := []rune(p.Args["level"].(string))
+			if len(level) != 1 {
+				return nil, ErrLevel
+			}
+			revertAfter := nnduration.Milliseconds(p.Args["revertAfter"].(int))
+			if e := Set(module, level[0], revertAfter.Duration()); e != nil {
+				return nil, e
+			}
+
+			if module == "" {
+				return List(), nil
+			}
+			m, _ := Get(module)
+			return []Module{m}, nil
+		},
+	})
+}
@@ -0,0 +1,205 @@
+// Package loglevel implements runtime log level control.
+package loglevel
+
+/*
+#include "../../csrc/core/logger.h"
+*/
+import "C"
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/logger"
+)
+
+// Levels contains acceptable log level letters.
+const Levels = "VDIWEFN"
+
+// Errors.
+var (
+	ErrLevel  = errors.New("level must be one of " + Levels)
+	ErrModule = errors.New("log module not found")
+)
+
+// Module describes a log module.
+type Module struct {
+	Name string
+	// GoLevel is the log level of Go loggers, or zero if the module has no Go logger.
+	// Go loggers have no VERBOSE level: setting 'V' is the same as 'D', which is reported here.
+	GoLevel rune
+	// CLevel is the log level of C code, or zero if the module has no C code.
+	CLevel rune
+	// CEffectiveLevel is the effective log level of C code, or zero if the module has no C code.
+	// This differs from CLevel if CLevel is below CompiledCLevel.
+	CEffectiveLevel rune
+	// RevertAt is when the levels revert to their original values, or zero if not scheduled.
+	RevertAt time.Time
+}
+
+type revert struct {
+	goLevel rune
+	cLevel  rune
+	at      time.Time
+	timer   *time.Timer
+}
+
+var (
+	lock    sync.Mutex
+	reverts = make(map[string]*revert)
+)
+
+// CompiledCLevel returns the compile-time minimum log level of C code.
+// Logging statements below this level are removed at compile time, so that setting a lower CLevel
+// has no effect. This is 'I' in RELEASE builds, and 'V' otherwise.
+func CompiledCLevel() rune {
+	return rune(C.Logger_CompiledLevelLetter())
+}
+
+// effectiveCLevel returns the more restrictive of lvl and CompiledCLevel.
+func effectiveCLevel(lvl rune) rune {
+	compiled := CompiledCLevel()
+	if strings.IndexRune(Levels, lvl) < strings.IndexRune(Levels, compiled) {
+		return compiled
+	}
+	return lvl
+}
+
+func cRegistry() []C.LoggerRegistryEntry {
+	return (*[C.LoggerRegistryCapacity]C.LoggerRegistryEntry)(unsafe.Pointer(&C.gLoggerRegistry))[:C.gLoggerRegistryCount]
+}
+
+func cLevels() map[string]rune {
+	m := make(map[string]rune)
+	for _, entry := range cRegistry() {
+		name := C.GoString(entry.module)
+		if _, ok := m[name]; !ok {
+			m[name] = rune(C.Logger_LevelLetter(*entry.lvl))
+		}
+	}
+	return m
+}
+
+func getCLevel(name string) rune {
+	return cLevels()[name]
+}
+
+func setCLevel(name string, lvl rune) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return C.Logger_SetLevel(cName, C.char(lvl)) > 0
+}
+
+// List returns log modules in alphabetical order.
+func List() (list []Module) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	byName := make(map[string]*Module)
+	get := func(name string) *Module {
+		m := byName[name]
+		if m == nil {
+			m = &Module{Name: name}
+			if r := reverts[name]; r != nil {
+				m.RevertAt = r.at
+			}
+			byName[name] = m
+		}
+		return m
+	}
+	for _, name := range logger.ListModules() {
+		get(name).GoLevel = logger.GetModuleLevel(name)
+	}
+	for name, lvl := range cLevels() {
+		m := get(name)
+		m.CLevel = lvl
+		m.CEffectiveLevel = effectiveCLevel(lvl)
+	}
+
+	for _, m := range byName {
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get returns information about a log module.
+func Get(name string) (m Module, ok bool) {
+	for _, m := range List() {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Module{}, false
+}
+
+// Set changes log level of a module, or all modules if name is empty.
+// If revertAfter is positive, the levels revert to their values before the first Set after this duration.
+// A Go logger set to 'V' before the first Set reverts to 'D', which is equivalent.
+// Otherwise, the change is permanent and cancels any scheduled revert.
+func Set(name string, lvl rune, revertAfter time.Duration) error {
+	if !strings.ContainsRune(Levels, lvl) {
+		return ErrLevel
+	}
+
+	var names []string
+	if name == "" {
+		for _, m := range List() {
+			names = append(names, m.Name)
+		}
+	} else if _, ok := Get(name); ok {
+		names = append(names, name)
+	} else {
+		return ErrModule
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, name := range names {
+		setLocked(name, lvl, revertAfter)
+	}
+	return nil
+}
+
+func setLocked(name string, lvl rune, revertAfter time.Duration) {
+	goLevel, cLevel := logger.GetModuleLevel(name), getCLevel(name)
+	if old := reverts[name]; old != nil {
+		old.timer.Stop()
+		delete(reverts, name)
+		goLevel, cLevel = old.goLevel, old.cLevel
+	}
+
+	if revertAfter > 0 {
+		// A new revert object is created every time, so that a stale timer that has fired but not
+		// yet acquired the lock would not match reverts[name] in doRevert.
+		r := &revert{
+			goLevel: goLevel,
+			cLevel:  cLevel,
+			at:      time.Now().Add(revertAfter),
+		}
+		r.timer = time.AfterFunc(revertAfter, func() { doRevert(name, r) })
+		reverts[name] = r
+	}
+
+	logger.SetModuleLevel(name, lvl)
+	setCLevel(name, lvl)
+}
+
+func doRevert(name string, r *revert) {
+	lock.Lock()
+	defer lock.Unlock()
+	if reverts[name] != r {
+		return
+	}
+	delete(reverts, name)
+
+	if r.goLevel != 0 {
+		logger.SetModuleLevel(name, r.goLevel)
+	}
+	if r.cLevel != 0 {
+		setCLevel(name, r.cLevel)
+	}
+}
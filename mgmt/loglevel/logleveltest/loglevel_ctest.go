@@ -0,0 +1,60 @@
+package logleveltest
+
+import "C"
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/logger"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/mgmt/loglevel"
+)
+
+const moduleName = "LogLevelTest"
+
+var _ = logger.New(moduleName)
+
+func ctestRevert(t *testing.T) {
+	assert, require := testenv.MakeAR(t)
+	defer loglevel.Set(moduleName, 'I', 0)
+
+	getLevels := func() (goLevel, cLevel rune, revertAt time.Time) {
+		m, ok := loglevel.Get(moduleName)
+		require.True(ok)
+		return m.GoLevel, m.CLevel, m.RevertAt
+	}
+
+	require.NoError(loglevel.Set(moduleName, 'I', 0))
+	goLevel, cLevel, revertAt := getLevels()
+	assert.Equal('I', goLevel)
+	assert.Equal('I', cLevel)
+	assert.True(revertAt.IsZero())
+
+	require.NoError(loglevel.Set(moduleName, 'W', 400*time.Millisecond))
+	goLevel, cLevel, revertAt = getLevels()
+	assert.Equal('W', goLevel)
+	assert.Equal('W', cLevel)
+	assert.False(revertAt.IsZero())
+
+	// re-set before expiry: original level is kept, revert is rescheduled
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(loglevel.Set(moduleName, 'E', 400*time.Millisecond))
+	time.Sleep(300 * time.Millisecond)
+	goLevel, cLevel, _ = getLevels()
+	assert.Equal('E', goLevel)
+	assert.Equal('E', cLevel)
+
+	time.Sleep(300 * time.Millisecond)
+	goLevel, cLevel, revertAt = getLevels()
+	assert.Equal('I', goLevel)
+	assert.Equal('I', cLevel)
+	assert.True(revertAt.IsZero())
+
+	// Go loggers have no VERBOSE level, so that 'V' reads back and reverts as 'D' on the Go side
+	require.NoError(loglevel.Set(moduleName, 'V', 0))
+	require.NoError(loglevel.Set(moduleName, 'W', 200*time.Millisecond))
+	time.Sleep(400 * time.Millisecond)
+	goLevel, cLevel, _ = getLevels()
+	assert.Equal('D', goLevel)
+	assert.Equal('V', cLevel)
+}
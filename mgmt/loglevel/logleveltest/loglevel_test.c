@@ -0,0 +1,4 @@
+#include "../../../csrc/core/logger.h"
+
+// Register a C log module with the same name as the Go logger in loglevel_ctest.go.
+INIT_ZF_LOG(LogLevelTest);
@@ -0,0 +1,159 @@
+package pkttrace
+
+/*
+#include "../../csrc/pkttrace/pkttrace.h"
+*/
+import "C"
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+func collectLoop() {
+	var cvt converter
+	ptrs := make([]*C.PktTraceRecord, collectBurst)
+	for {
+		n := ring.Dequeue(ptrs)
+		if n == 0 {
+			time.Sleep(collectInterval)
+			continue
+		}
+
+		now, nowTsc := time.Now(), eal.TscNow()
+		records := make([]Record, n)
+		for i, c := range ptrs[:n] {
+			records[i] = cvt.convert(c, now, nowTsc)
+		}
+		mp.Free(ptrs[:n])
+
+		for _, rec := range records {
+			publisher.Publish(rec)
+		}
+		writeJobs(records)
+	}
+}
+
+// CollectArgs contains arguments of a file collection job.
+type CollectArgs struct {
+	// Filename is the output filename.
+	// Records are written as JSON lines.
+	Filename string
+
+	// Count is the maximum number of records.
+	// The job stops after writing this many records.
+	// Zero means 2^24 records.
+	Count int
+}
+
+type collectJob struct {
+	CollectArgs
+	nWritten int
+	file     *os.File
+	w        *bufio.Writer
+	enc      *json.Encoder
+}
+
+func (job *collectJob) close() error {
+	e := job.w.Flush()
+	if e1 := job.file.Close(); e == nil {
+		e = e1
+	}
+	return e
+}
+
+var (
+	collectJobsLock sync.Mutex
+	collectJobs     = make(map[string]*collectJob)
+)
+
+func startJob(args CollectArgs) (job *collectJob, e error) {
+	if ring == nil {
+		return nil, ErrNotInitialized
+	}
+	if args.Count <= 0 {
+		args.Count = 1 << 24
+	}
+
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	if _, ok := collectJobs[args.Filename]; ok {
+		return nil, errors.New("duplicate collect job")
+	}
+
+	job = &collectJob{CollectArgs: args}
+	if job.file, e = os.Create(args.Filename); e != nil {
+		return nil, e
+	}
+	job.w = bufio.NewWriter(job.file)
+	job.enc = json.NewEncoder(job.w)
+	collectJobs[args.Filename] = job
+	return job, nil
+}
+
+func stopJob(filename string) error {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	job := collectJobs[filename]
+	if job == nil {
+		return errors.New("job not found")
+	}
+	delete(collectJobs, filename)
+	return job.close()
+}
+
+func findJob(filename string) *collectJob {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	return collectJobs[filename]
+}
+
+func listJobs() (list []*collectJob) {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	list = []*collectJob{}
+	for _, job := range collectJobs {
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Filename < list[j].Filename })
+	return list
+}
+
+func writeJobs(records []Record) {
+	collectJobsLock.Lock()
+	defer collectJobsLock.Unlock()
+	for filename, job := range collectJobs {
+		var e error
+		for _, rec := range records {
+			if job.nWritten >= job.Count {
+				break
+			}
+			if e = job.enc.Encode(rec); e != nil {
+				break
+			}
+			job.nWritten++
+		}
+		if e == nil {
+			e = job.w.Flush()
+		}
+
+		if e != nil || job.nWritten >= job.Count {
+			delete(collectJobs, filename)
+			if e1 := job.close(); e == nil {
+				e = e1
+			}
+			logEntry := log.WithFields(makeLogFields("filename", filename, "count", job.nWritten))
+			if e != nil {
+				logEntry.WithError(e).Warn("packet trace collection stopped due to error")
+			} else {
+				logEntry.Info("packet trace collection completed")
+			}
+		}
+	}
+}
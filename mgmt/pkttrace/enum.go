@@ -0,0 +1,104 @@
+package pkttrace
+
+//go:generate go run ../../mk/enumgen/ -guard=NDNDPDK_PKTTRACE_ENUM_H -out=../../csrc/pkttrace/enum.h .
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Stage identifies a packet processing stage in a trace record.
+type Stage int
+
+// Stage values.
+const (
+	_             Stage = iota
+	StageRx             // packet received on a face
+	StageDemux          // input demux dispatched packet to a forwarding thread
+	StageNdt            // NDT lookup chose a forwarding thread
+	StageFwd            // forwarding thread dequeued packet
+	StagePitCs          // forwarding thread processed packet in PIT-CS
+	StageStrategy       // strategy made a forwarding decision
+	StageTx             // packet transmitted on a face
+
+	_ = "enumgen:PktTraceStage:PktTrace"
+)
+
+var stageStrings = map[Stage]string{
+	StageRx:       "rx",
+	StageDemux:    "demux",
+	StageNdt:      "ndt",
+	StageFwd:      "fwd",
+	StagePitCs:    "pitcs",
+	StageStrategy: "strategy",
+	StageTx:       "tx",
+}
+
+func (stage Stage) String() string {
+	if s, ok := stageStrings[stage]; ok {
+		return s
+	}
+	return strconv.Itoa(int(stage))
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (stage Stage) MarshalText() (text []byte, e error) {
+	return []byte(stage.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (stage *Stage) UnmarshalText(text []byte) error {
+	for value, s := range stageStrings {
+		if s == string(text) {
+			*stage = value
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown Stage %s", text)
+}
+
+// Result indicates the outcome of PIT-CS processing, reported in StagePitCs records.
+type Result int
+
+// Result values.
+const (
+	_             Result = iota
+	ResultDropped        // dropped by face ACL, scope control, rate limiter, or loop detection
+	ResultNoRoute        // Interest has no FIB match
+	ResultPit            // Interest inserted into PIT entry, or Data/Nack matched PIT entry
+	ResultCsHit          // Interest satisfied by CS entry
+	ResultPitFull        // Interest rejected because PIT is full
+	ResultNoPit          // Data or Nack matched no PIT entry
+
+	_ = "enumgen:PktTraceResult:PktTrace"
+)
+
+var resultStrings = map[Result]string{
+	ResultDropped: "dropped",
+	ResultNoRoute: "no-route",
+	ResultPit:     "pit",
+	ResultCsHit:   "cs-hit",
+	ResultPitFull: "pit-full",
+	ResultNoPit:   "no-pit",
+}
+
+func (res Result) String() string {
+	if s, ok := resultStrings[res]; ok {
+		return s
+	}
+	return strconv.Itoa(int(res))
+}
+
+const (
+	// NameMax is the maximum TLV-VALUE length of a name in a trace record.
+	// Longer names are truncated, so that a trace record occupies 128 octets.
+	NameMax = 102
+
+	// DemuxRejected is set in StageDemux record value if the destination queue rejected the packet.
+	DemuxRejected = 0x100
+
+	// DemuxDropped is the StageDemux record value if the packet was dropped without a destination.
+	DemuxDropped = 0x200
+
+	_ = "enumgen::PktTrace"
+)
@@ -0,0 +1,257 @@
+package pkttrace
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// GraphQL types.
+var (
+	GqlFilterType  *graphql.Object
+	GqlStatusType  *graphql.Object
+	GqlRecordType  *graphql.Object
+	GqlJobNodeType *gqlserver.NodeType
+	GqlJobType     *graphql.Object
+)
+
+type gqlStatus struct{}
+
+func init() {
+	GqlFilterType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PktTraceFilter",
+		Description: "Packet trace filter.",
+		Fields: graphql.Fields{
+			"prefix": &graphql.Field{
+				Description: "Name prefix of traced packets.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Filter).Prefix, nil
+				},
+			},
+			"sampleRate": &graphql.Field{
+				Description: "Probability of tracing a matching packet.",
+				Type:        graphql.NewNonNull(graphql.Float),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Filter).SampleRate, nil
+				},
+			},
+		},
+	})
+
+	GqlStatusType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PktTraceStatus",
+		Description: "Packet tracer status.",
+		Fields: graphql.Fields{
+			"filter": &graphql.Field{
+				Description: "Current filter. null indicates tracing is disabled.",
+				Type:        GqlFilterType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if f := CurrentFilter(); f != nil {
+						return *f, nil
+					}
+					return nil, nil
+				},
+			},
+			"counters": &graphql.Field{
+				Description: "Packet tracer counters.",
+				Type:        gqlserver.NonNullJSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return ReadCounters(), nil
+				},
+			},
+		},
+	})
+
+	GqlRecordType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PktTraceRecord",
+		Description: "Packet trace record.",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Description: "Trace identifier.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(Record).ID), nil
+				},
+			},
+			"time": &graphql.Field{
+				Description: "Timestamp.",
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Record).Time, nil
+				},
+			},
+			"stage": &graphql.Field{
+				Description: "Processing stage: rx, demux, ndt, fwd, pitcs, strategy, or tx.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Record).Stage.String(), nil
+				},
+			},
+			"pktType": &graphql.Field{
+				Description: "Packet type: interest, data, or nack.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Record).PktType, nil
+				},
+			},
+			"face": &graphql.Field{
+				Description: "Incoming face, or outgoing face in tx stage.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(Record).Face), nil
+				},
+			},
+			"lcore": &graphql.Field{
+				Description: "Lcore that posted the record.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Record).LCore.ID(), nil
+				},
+			},
+			"name": &graphql.Field{
+				Description: "Packet name, possibly truncated. null if unknown.",
+				Type:        ndni.GqlNameType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := p.Source.(Record).Name
+					return gqlserver.Optional(name, name != nil), nil
+				},
+			},
+			"value": &graphql.Field{
+				Description: "Stage specific value, as a decimal string because it may exceed the range of Int.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return strconv.FormatUint(p.Source.(Record).Value, 10), nil
+				},
+			},
+			"detail": &graphql.Field{
+				Description: "Human readable interpretation of value.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(Record).Detail, nil
+				},
+			},
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "pktTrace",
+		Description: "Packet tracer status.",
+		Type:        graphql.NewNonNull(GqlStatusType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return gqlStatus{}, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "startPktTrace",
+		Description: "Start tracing packets under a name prefix, or replace the filter if tracing is already enabled.",
+		Args: graphql.FieldConfigArgument{
+			"prefix": &graphql.ArgumentConfig{
+				Description: "Name prefix of traced packets.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"sampleRate": &graphql.ArgumentConfig{
+				Description:  "Probability of tracing a matching packet, between 0 and 1.",
+				Type:         graphql.Float,
+				DefaultValue: 1.0,
+			},
+		},
+		Type: graphql.NewNonNull(GqlStatusType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var f Filter
+			f.Prefix = p.Args["prefix"].(ndn.Name)
+			f.SampleRate = p.Args["sampleRate"].(float64)
+			if e := Start(f); e != nil {
+				return nil, e
+			}
+			return gqlStatus{}, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "stopPktTrace",
+		Description: "Stop tracing packets.",
+		Type:        graphql.NewNonNull(GqlStatusType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			Stop()
+			return gqlStatus{}, nil
+		},
+	})
+
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "pktTraceRecords",
+		Description: "Packet trace records. Records are dropped if the subscriber is slow.",
+		Type:        graphql.NewNonNull(GqlRecordType),
+	}, func(p graphql.ResolveParams) (<-chan interface{}, error) {
+		return Subscribe(p.Context)
+	})
+
+	GqlJobNodeType = gqlserver.NewNodeTypeNamed("PktTraceJob", (*collectJob)(nil))
+	GqlJobNodeType.GetID = func(source interface{}) string {
+		return source.(*collectJob).Filename
+	}
+	GqlJobNodeType.Retrieve = func(id string) (interface{}, error) {
+		return findJob(id), nil
+	}
+	GqlJobNodeType.Delete = func(source interface{}) error {
+		return stopJob(source.(*collectJob).Filename)
+	}
+
+	GqlJobType = graphql.NewObject(GqlJobNodeType.Annotate(graphql.ObjectConfig{
+		Name:        "PktTraceJob",
+		Description: "Packet trace collection job. Deleting the job stops the collection and closes the file.",
+		Fields: graphql.Fields{
+			"filename": &graphql.Field{
+				Description: "Output filename.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*collectJob).Filename, nil
+				},
+			},
+			"count": &graphql.Field{
+				Description: "Maximum number of records.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*collectJob).Count, nil
+				},
+			},
+		},
+	}))
+	GqlJobNodeType.Register(GqlJobType)
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "pktTraceJobs",
+		Description: "Running packet trace collection jobs.",
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(GqlJobType))),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return listJobs(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "collectPktTrace",
+		Description: "Start collecting packet trace records to a file, written as JSON lines.",
+		Args: graphql.FieldConfigArgument{
+			"filename": &graphql.ArgumentConfig{
+				Description: "Output filename.",
+				Type:        gqlserver.NonNullString,
+			},
+			"count": &graphql.ArgumentConfig{
+				Description:  "Maximum number of records. 0 means 2^24 records.",
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+		},
+		Type: graphql.NewNonNull(GqlJobType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var args CollectArgs
+			args.Filename = p.Args["filename"].(string)
+			args.Count = p.Args["count"].(int)
+			return startJob(args)
+		},
+	})
+}
@@ -0,0 +1,10 @@
+package pkttrace
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/logger"
+)
+
+var (
+	log           = logger.New("PktTrace")
+	makeLogFields = logger.MakeFields
+)
@@ -0,0 +1,175 @@
+// Package pkttrace implements per-packet tracing in the forwarder.
+package pkttrace
+
+/*
+#include "../../csrc/pkttrace/pkttrace.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/mempool"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+const (
+	ringCapacity    = 65536
+	mempoolCapacity = 2*ringCapacity - 1
+
+	collectBurst    = 64
+	collectInterval = 10 * time.Millisecond
+)
+
+// Errors.
+var (
+	ErrNotInitialized = errors.New("packet tracer is not initialized")
+	ErrSampleRate     = errors.New("sampleRate must be between 0 and 1")
+	ErrPrefix         = errors.New("prefix too long")
+)
+
+var (
+	ring *ringbuffer.Ring
+	mp   *mempool.Mempool
+
+	filterLock sync.Mutex
+	filter     *Filter
+
+	publisher = gqlserver.NewPublisher()
+)
+
+// Init initializes the packet tracer.
+// Packets are not traced until Start is called.
+func Init() (e error) {
+	if ring != nil {
+		return nil
+	}
+
+	if ring, e = ringbuffer.New(ringCapacity, eal.NumaSocket{}, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle); e != nil {
+		ring = nil
+		return e
+	}
+	if mp, e = mempool.New(mempool.Config{
+		Capacity:    mempoolCapacity,
+		ElementSize: int(C.sizeof_PktTraceRecord),
+		Socket:      eal.NumaSocket{},
+	}); e != nil {
+		ring.Close()
+		ring = nil
+		return e
+	}
+
+	C.thePktTrace.ring = (*C.struct_rte_ring)(ring.Ptr())
+	C.thePktTrace.mp = (*C.struct_rte_mempool)(mp.Ptr())
+	go collectLoop()
+	return nil
+}
+
+// Filter selects packets to be traced.
+type Filter struct {
+	// Prefix is the name prefix of traced packets.
+	// A Nack is matched by its Interest name.
+	Prefix ndn.Name `json:"prefix"`
+
+	// SampleRate is the probability of tracing a matching packet, between 0 and 1.
+	// Zero is interpreted as 1.
+	SampleRate float64 `json:"sampleRate"`
+}
+
+func (f Filter) copyToC(c *C.PktTraceFilter) {
+	if f.SampleRate >= 1 {
+		c.sampleThreshold = math.MaxUint64
+	} else {
+		c.sampleThreshold = C.uint64_t(f.SampleRate * math.MaxUint64)
+	}
+	prefixV, _ := f.Prefix.MarshalBinary()
+	for i, b := range prefixV {
+		c.prefixV[i] = C.uint8_t(b)
+	}
+	c.prefixL = C.uint16_t(len(prefixV))
+}
+
+// Start enables tracing, or replaces the filter if tracing is already enabled.
+// This function waits for an RCU grace period, and should not be called from an RCU read-side thread.
+func Start(f Filter) error {
+	if ring == nil {
+		return ErrNotInitialized
+	}
+	if f.SampleRate == 0 {
+		f.SampleRate = 1
+	}
+	if f.SampleRate < 0 || f.SampleRate > 1 {
+		return ErrSampleRate
+	}
+	if f.Prefix.Length() > ndni.NameMaxLength {
+		return ErrPrefix
+	}
+
+	c := (*C.PktTraceFilter)(eal.Zmalloc("PktTraceFilter", C.sizeof_PktTraceFilter, eal.NumaSocket{}))
+	f.copyToC(c)
+	setFilter(&f, c)
+	log.WithFields(makeLogFields("prefix", f.Prefix, "sample-rate", f.SampleRate)).Info("packet tracing started")
+	return nil
+}
+
+// Stop disables tracing.
+// This function waits for an RCU grace period, and should not be called from an RCU read-side thread.
+func Stop() {
+	setFilter(nil, nil)
+	log.Info("packet tracing stopped")
+}
+
+func setFilter(f *Filter, c *C.PktTraceFilter) {
+	filterLock.Lock()
+	defer filterLock.Unlock()
+	filter = f
+	if old := C.PktTrace_SetFilter(c); old != nil {
+		urcu.Synchronize()
+		eal.Free(old)
+	}
+}
+
+// CurrentFilter returns the current filter, or nil if tracing is disabled.
+func CurrentFilter() *Filter {
+	filterLock.Lock()
+	defer filterLock.Unlock()
+	if filter == nil {
+		return nil
+	}
+	f := *filter
+	return &f
+}
+
+// Subscribe returns a channel that yields collected records as Record values.
+// The channel is closed when ctx is canceled.
+// Records are dropped if the subscriber is slow.
+func Subscribe(ctx context.Context) (<-chan interface{}, error) {
+	if ring == nil {
+		return nil, ErrNotInitialized
+	}
+	return publisher.Subscribe(ctx), nil
+}
+
+// Counters contains packet tracer counters.
+type Counters struct {
+	NTraced  uint32 `json:"nTraced"`  // packets selected for tracing, wraps around
+	NRecords uint64 `json:"nRecords"` // trace records posted
+	NDropped uint64 `json:"nDropped"` // trace records lost due to full queue
+}
+
+// ReadCounters returns packet tracer counters.
+func ReadCounters() Counters {
+	return Counters{
+		NTraced:  uint32(C.thePktTrace.lastId),
+		NRecords: uint64(C.thePktTrace.nRecords),
+		NDropped: uint64(C.thePktTrace.nDropped),
+	}
+}
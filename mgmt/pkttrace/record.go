@@ -0,0 +1,101 @@
+package pkttrace
+
+/*
+#include "../../csrc/pkttrace/pkttrace.h"
+*/
+import "C"
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Record is a packet trace record.
+//
+// Packets selected at StageRx are assigned a trace identifier, which is carried through input
+// demux and the forwarding thread, and copied onto outgoing packets, so that records of an Interest
+// and the Interests or Data sent on its behalf share the same ID.
+// The name is present in StageRx record, and filled into other records by the collector when the
+// StageRx record of the same ID has been seen recently.
+type Record struct {
+	ID      uint32    `json:"id"`
+	Time    time.Time `json:"time"`
+	Stage   Stage     `json:"stage"`
+	PktType string    `json:"pktType"` // "interest", "data", or "nack"
+	Face    iface.ID  `json:"face,omitempty"`
+	LCore   eal.LCore `json:"lcore"`
+	Name    ndn.Name  `json:"name,omitempty"`
+
+	// Value is the stage specific value, see Detail for interpretation.
+	Value uint64 `json:"value"`
+
+	// Detail is a human readable interpretation of Value.
+	Detail string `json:"detail,omitempty"`
+}
+
+var pktTypeStrings = map[ndni.PktType]string{
+	ndni.PktInterest: "interest",
+	ndni.PktData:     "data",
+	ndni.PktNack:     "nack",
+}
+
+func describeValue(stage Stage, value uint64) string {
+	switch stage {
+	case StageDemux:
+		switch {
+		case value&DemuxDropped != 0:
+			return "dropped"
+		case value&DemuxRejected != 0:
+			return fmt.Sprintf("dest=%d rejected", value&0xFF)
+		}
+		return fmt.Sprintf("dest=%d", value)
+	case StageNdt:
+		return fmt.Sprintf("index=%d fwd=%d", value>>8, value&0xFF)
+	case StageFwd, StageTx:
+		return fmt.Sprintf("latency=%s", eal.FromTscDuration(int64(value)))
+	case StagePitCs:
+		return Result(value).String()
+	case StageStrategy:
+		return fmt.Sprintf("forwarded=%d", value)
+	}
+	return ""
+}
+
+// nameCacheCapacity is the number of trace identifiers whose names are remembered by the collector.
+const nameCacheCapacity = 4096
+
+type nameCacheEntry struct {
+	id   uint32
+	name ndn.Name
+}
+
+// converter converts C records to Go records.
+type converter struct {
+	names [nameCacheCapacity]nameCacheEntry
+}
+
+func (cvt *converter) convert(c *C.PktTraceRecord, now time.Time, nowTsc eal.TscTime) (rec Record) {
+	rec.ID = uint32(c.id)
+	rec.Time = now.Add(-nowTsc.Sub(eal.TscTime(c.time)))
+	rec.Stage = Stage(c.stage)
+	rec.PktType = pktTypeStrings[ndni.PktType(c.pktType)]
+	rec.Face = iface.ID(c.face)
+	rec.LCore = eal.LCoreFromID(int(c.lcore))
+	rec.Value = uint64(c.value)
+	rec.Detail = describeValue(rec.Stage, rec.Value)
+
+	cached := &cvt.names[rec.ID%nameCacheCapacity]
+	if c.nameL > 0 {
+		// a name truncated at PktTraceNameMax has an incomplete last component, which is ignored
+		rec.Name.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&c.name[0]), C.int(c.nameL)))
+		cached.id, cached.name = rec.ID, rec.Name
+	} else if cached.id == rec.ID {
+		rec.Name = cached.name
+	}
+	return rec
+}
@@ -0,0 +1,386 @@
+package gqlmgmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Executor executes a GraphQL operation.
+// *gqlclient.Client, *Client, and gqlserver.Local satisfy this interface.
+type Executor interface {
+	Do(query string, vars interface{}, key string, res interface{}) error
+}
+
+var _ Executor = (*Client)(nil)
+
+// Step actions.
+const (
+	ActionLoadStrategy   = "load-strategy"
+	ActionCreateFace     = "create-face"
+	ActionSetPersistency = "set-persistency"
+	ActionInsertFib      = "insert-fib"
+	ActionEraseFib       = "erase-fib"
+	ActionDestroyFace    = "destroy-face"
+)
+
+// Step is a mutation in a Plan.
+type Step struct {
+	Action string
+	Target string      // face key, strategy name, or FIB entry name
+	Detail interface{} // additional information for display
+
+	run func(x Executor, st *applyState) error
+}
+
+func (step Step) String() string {
+	sign := "~"
+	switch step.Action {
+	case ActionLoadStrategy, ActionCreateFace:
+		sign = "+"
+	case ActionEraseFib, ActionDestroyFace:
+		sign = "-"
+	}
+	if step.Detail == nil {
+		return fmt.Sprintf("%s %s %s", sign, step.Action, step.Target)
+	}
+	detail, _ := json.Marshal(step.Detail)
+	return fmt.Sprintf("%s %s %s %s", sign, step.Action, step.Target, detail)
+}
+
+// Plan is a sequence of mutations that brings the forwarder to the state described in FwConfig.
+type Plan struct {
+	Steps []Step
+
+	faceIDs     map[string]string // face key => existing face ID
+	strategyIDs map[string]string // strategy name => existing strategy ID
+}
+
+type applyState struct {
+	faceIDs     map[string]string
+	strategyIDs map[string]string
+}
+
+// Empty determines whether the plan has no steps, i.e. the forwarder already matches the document.
+func (plan *Plan) Empty() bool {
+	return len(plan.Steps) == 0
+}
+
+func (plan *Plan) String() string {
+	var b strings.Builder
+	for _, step := range plan.Steps {
+		b.WriteString(step.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Apply executes the plan.
+// It stops at the first failed step.
+func (plan *Plan) Apply(x Executor) error {
+	st := &applyState{
+		faceIDs:     map[string]string{},
+		strategyIDs: map[string]string{},
+	}
+	for k, v := range plan.faceIDs {
+		st.faceIDs[k] = v
+	}
+	for k, v := range plan.strategyIDs {
+		st.strategyIDs[k] = v
+	}
+
+	for _, step := range plan.Steps {
+		if e := step.run(x, st); e != nil {
+			return fmt.Errorf("%s %s: %w", step.Action, step.Target, e)
+		}
+	}
+	return nil
+}
+
+type liveFace struct {
+	ID          string                 `json:"id"`
+	Locator     map[string]interface{} `json:"locator"`
+	Persistency string                 `json:"persistency"`
+}
+
+type liveStrategy struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type liveFibEntry struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Nexthops []*struct {
+		ID string `json:"id"`
+	} `json:"nexthops"`
+	Strategy *liveStrategy `json:"strategy"`
+}
+
+type liveState struct {
+	Faces      []liveFace     `json:"faces"`
+	Strategies []liveStrategy `json:"strategies"`
+	Fib        []liveFibEntry `json:"fib"`
+}
+
+// MakePlan compares FwConfig with the live state of the forwarder, and computes the mutations needed.
+// If prune is true, FIB entries and faces not described in the document are removed;
+// otherwise, they are left unchanged.
+func MakePlan(x Executor, cfg FwConfig, prune bool) (plan *Plan, e error) {
+	if e = cfg.Validate(); e != nil {
+		return nil, e
+	}
+
+	var live liveState
+	if e = x.Do(`
+		{
+			faces {
+				id
+				locator
+				persistency
+			}
+			strategies {
+				id
+				name
+			}
+			fib {
+				id
+				name
+				nexthops {
+					id
+				}
+				strategy {
+					id
+					name
+				}
+			}
+		}
+	`, nil, "", &live); e != nil {
+		return nil, e
+	}
+
+	plan = &Plan{
+		faceIDs:     map[string]string{},
+		strategyIDs: map[string]string{},
+	}
+	for _, sc := range live.Strategies {
+		plan.strategyIDs[sc.Name] = sc.ID
+	}
+	for _, sc := range cfg.Strategies {
+		if plan.strategyIDs[sc.Name] == "" {
+			plan.Steps = append(plan.Steps, makeLoadStrategyStep(sc))
+		}
+	}
+	for i, entry := range cfg.Fib {
+		if entry.Strategy == "" || plan.strategyIDs[entry.Strategy] != "" {
+			continue
+		}
+		found := false
+		for _, sc := range cfg.Strategies {
+			found = found || sc.Name == entry.Strategy
+		}
+		if !found {
+			return nil, fmt.Errorf("fib[%d].strategy refers to unknown strategy %s", i, entry.Strategy)
+		}
+	}
+
+	usedFaces := map[string]bool{}
+	for _, face := range cfg.Faces {
+		var matched *liveFace
+		for i := range live.Faces {
+			if lf := &live.Faces[i]; !usedFaces[lf.ID] && matchLocator(face.Locator, lf.Locator) {
+				matched = lf
+				break
+			}
+		}
+		if matched == nil {
+			plan.Steps = append(plan.Steps, makeCreateFaceStep(face))
+			continue
+		}
+		usedFaces[matched.ID] = true
+		plan.faceIDs[face.Key] = matched.ID
+		if face.Persistency != "" && !strings.EqualFold(face.Persistency, matched.Persistency) {
+			plan.Steps = append(plan.Steps, makeSetPersistencyStep(face))
+		}
+	}
+
+	liveFib := map[string]liveFibEntry{}
+	for i, entry := range live.Fib {
+		entry.Name = ndn.ParseName(entry.Name).String()
+		live.Fib[i] = entry
+		liveFib[entry.Name] = entry
+	}
+	wantFib := map[string]bool{}
+	for _, entry := range cfg.Fib {
+		name := ndn.ParseName(entry.Name).String()
+		wantFib[name] = true
+		lf, ok := liveFib[name]
+		if ok && entry.Strategy == "" && lf.Strategy != nil {
+			entry.Strategy = lf.Strategy.Name
+		}
+		if ok && plan.fibEntryEqual(entry, lf) {
+			continue
+		}
+		plan.Steps = append(plan.Steps, makeInsertFibStep(name, entry))
+	}
+
+	if prune {
+		for _, entry := range live.Fib {
+			if !wantFib[entry.Name] {
+				plan.Steps = append(plan.Steps, makeDeleteStep(ActionEraseFib, entry.Name, entry.ID))
+			}
+		}
+		for _, face := range live.Faces {
+			if !usedFaces[face.ID] {
+				plan.Steps = append(plan.Steps, makeDeleteStep(ActionDestroyFace, face.ID, face.ID))
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func (plan *Plan) fibEntryEqual(entry FwConfigFibEntry, lf liveFibEntry) bool {
+	if len(entry.Nexthops) != len(lf.Nexthops) {
+		return false
+	}
+	for i, nh := range entry.Nexthops {
+		faceID := plan.faceIDs[nh]
+		if faceID == "" || lf.Nexthops[i] == nil || lf.Nexthops[i].ID != faceID {
+			return false
+		}
+	}
+	if lf.Strategy == nil || (entry.Strategy != "" && lf.Strategy.Name != entry.Strategy) {
+		return false
+	}
+	return true
+}
+
+func makeLoadStrategyStep(sc FwConfigStrategy) Step {
+	return Step{
+		Action: ActionLoadStrategy,
+		Target: sc.Name,
+		Detail: map[string]interface{}{"elf": sc.Elf},
+		run: func(x Executor, st *applyState) error {
+			elf, e := ioutil.ReadFile(sc.Elf)
+			if e != nil {
+				return e
+			}
+			var reply liveStrategy
+			if e := x.Do(`
+				mutation loadStrategy($name: String!, $elf: Bytes!) {
+					loadStrategy(name: $name, elf: $elf) {
+						id
+					}
+				}
+			`, map[string]interface{}{
+				"name": sc.Name,
+				"elf":  elf,
+			}, "loadStrategy", &reply); e != nil {
+				return e
+			}
+			st.strategyIDs[sc.Name] = reply.ID
+			return nil
+		},
+	}
+}
+
+func makeCreateFaceStep(face FwConfigFace) Step {
+	persistency := face.Persistency
+	if persistency == "" {
+		persistency = "PERSISTENT"
+	}
+	return Step{
+		Action: ActionCreateFace,
+		Target: face.Key,
+		Detail: map[string]interface{}{"locator": face.Locator, "persistency": persistency},
+		run: func(x Executor, st *applyState) error {
+			var reply liveFace
+			if e := x.Do(`
+				mutation createFace($locator: JSON!, $persistency: FacePersistency) {
+					createFace(locator: $locator, persistency: $persistency) {
+						id
+					}
+				}
+			`, map[string]interface{}{
+				"locator":     face.Locator,
+				"persistency": strings.ToUpper(persistency),
+			}, "createFace", &reply); e != nil {
+				return e
+			}
+			st.faceIDs[face.Key] = reply.ID
+			return nil
+		},
+	}
+}
+
+func makeSetPersistencyStep(face FwConfigFace) Step {
+	return Step{
+		Action: ActionSetPersistency,
+		Target: face.Key,
+		Detail: map[string]interface{}{"persistency": face.Persistency},
+		run: func(x Executor, st *applyState) error {
+			return x.Do(`
+				mutation setFacePersistency($id: ID!, $persistency: FacePersistency!) {
+					setFacePersistency(id: $id, persistency: $persistency) {
+						id
+					}
+				}
+			`, map[string]interface{}{
+				"id":          st.faceIDs[face.Key],
+				"persistency": strings.ToUpper(face.Persistency),
+			}, "", nil)
+		},
+	}
+}
+
+func makeInsertFibStep(name string, entry FwConfigFibEntry) Step {
+	detail := map[string]interface{}{"nexthops": entry.Nexthops}
+	if entry.Strategy != "" {
+		detail["strategy"] = entry.Strategy
+	}
+	return Step{
+		Action: ActionInsertFib,
+		Target: name,
+		Detail: detail,
+		run: func(x Executor, st *applyState) error {
+			vars := map[string]interface{}{
+				"name": name,
+			}
+			var nexthops []string
+			for _, nh := range entry.Nexthops {
+				nexthops = append(nexthops, st.faceIDs[nh])
+			}
+			vars["nexthops"] = nexthops
+			if entry.Strategy != "" {
+				vars["strategy"] = st.strategyIDs[entry.Strategy]
+			}
+			return x.Do(`
+				mutation insertFibEntry($name: Name!, $nexthops: [ID!]!, $strategy: ID) {
+					insertFibEntry(name: $name, nexthops: $nexthops, strategy: $strategy) {
+						id
+					}
+				}
+			`, vars, "", nil)
+		},
+	}
+}
+
+func makeDeleteStep(action, target, id string) Step {
+	return Step{
+		Action: action,
+		Target: target,
+		run: func(x Executor, st *applyState) error {
+			return x.Do(`
+				mutation delete($id: ID!) {
+					delete(id: $id)
+				}
+			`, map[string]interface{}{
+				"id": id,
+			}, "", nil)
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package gqlmgmt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/gqlmgmt"
+)
+
+type fakeExecutor struct {
+	live      string
+	mutations []string
+	nFaces    int
+}
+
+func (x *fakeExecutor) Do(query string, vars interface{}, key string, res interface{}) error {
+	if !strings.Contains(query, "mutation") {
+		return json.Unmarshal([]byte(x.live), res)
+	}
+
+	varsJ, _ := json.Marshal(vars)
+	op := strings.Fields(query)[1]
+	op = op[:strings.IndexByte(op, '(')]
+	x.mutations = append(x.mutations, fmt.Sprintf("%s %s", op, varsJ))
+
+	if key == "createFace" {
+		x.nFaces++
+		return json.Unmarshal([]byte(fmt.Sprintf(`{"id":"new%d"}`, x.nFaces)), res)
+	}
+	return nil
+}
+
+const fwconfigDoc = `
+faces:
+  - key: up
+    locator:
+      Scheme: ether
+      port: net_af_packet0
+      local: "02:00:00:00:00:01"
+      remote: "01:00:5E:00:17:AA"
+  - key: app
+    locator:
+      scheme: unix
+      remote: /tmp/app.sock
+    persistency: permanent
+fib:
+  - name: /A
+    nexthops: [up]
+  - name: /B
+    nexthops: [up, app]
+    strategy: multicast
+`
+
+func TestApply(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var cfg gqlmgmt.FwConfig
+	require.NoError(yaml.Unmarshal([]byte(fwconfigDoc), &cfg))
+	require.NoError(cfg.Validate())
+	require.Len(cfg.Faces, 2)
+	assert.Equal("up", cfg.Faces[0].Key)
+	assert.Equal([]string{"up", "app"}, cfg.Fib[1].Nexthops)
+
+	x := &fakeExecutor{
+		live: `{
+			"faces": [
+				{"id": "f1", "locator": {"scheme": "ether", "port": "net_af_packet0", "local": "02:00:00:00:00:01", "remote": "01:00:5e:00:17:aa", "vlan": 0}, "persistency": "PERSISTENT"},
+				{"id": "f9", "locator": {"scheme": "udp", "remote": "192.0.2.1:6363"}, "persistency": "PERSISTENT"}
+			],
+			"strategies": [{"id": "s1", "name": "multicast"}],
+			"fib": [
+				{"id": "eA", "name": "/A", "nexthops": [{"id": "f1"}], "strategy": {"id": "s1", "name": "multicast"}},
+				{"id": "eZ", "name": "/Z", "nexthops": [{"id": "f9"}], "strategy": {"id": "s1", "name": "multicast"}}
+			]
+		}`,
+	}
+
+	plan, e := gqlmgmt.MakePlan(x, cfg, false)
+	require.NoError(e)
+	require.Len(plan.Steps, 2)
+	assert.Equal(gqlmgmt.ActionCreateFace, plan.Steps[0].Action)
+	assert.Equal("app", plan.Steps[0].Target)
+	assert.Equal(gqlmgmt.ActionInsertFib, plan.Steps[1].Action)
+	assert.Equal("/8=B", plan.Steps[1].Target)
+	assert.True(strings.HasPrefix(plan.String(), "+ create-face app "))
+	assert.Empty(x.mutations)
+
+	require.NoError(plan.Apply(x))
+	require.Len(x.mutations, 2)
+	assert.Contains(x.mutations[0], `"persistency":"PERMANENT"`)
+	assert.Contains(x.mutations[1], `"nexthops":["f1","new1"]`)
+	assert.Contains(x.mutations[1], `"strategy":"s1"`)
+
+	plan, e = gqlmgmt.MakePlan(x, cfg, true)
+	require.NoError(e)
+	require.Len(plan.Steps, 4)
+	assert.Equal(gqlmgmt.ActionEraseFib, plan.Steps[2].Action)
+	assert.Equal("/8=Z", plan.Steps[2].Target)
+	assert.Equal(gqlmgmt.ActionDestroyFace, plan.Steps[3].Action)
+	assert.Equal("f9", plan.Steps[3].Target)
+}
+
+func TestApplyKeepStrategy(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var cfg gqlmgmt.FwConfig
+	require.NoError(yaml.Unmarshal([]byte(`
+faces:
+  - key: up
+    locator:
+      scheme: unix
+      remote: /tmp/up.sock
+fib:
+  - name: /A
+    nexthops: [up]
+  - name: /C
+    nexthops: [up]
+  - name: /N
+    nexthops: [up]
+`), &cfg))
+
+	x := &fakeExecutor{
+		live: `{
+			"faces": [
+				{"id": "f1", "locator": {"scheme": "unix", "remote": "/tmp/up.sock"}, "persistency": "PERSISTENT"}
+			],
+			"strategies": [{"id": "s1", "name": "multicast"}, {"id": "s2", "name": "delay"}],
+			"fib": [
+				{"id": "eA", "name": "/A", "nexthops": [{"id": "f1"}], "strategy": {"id": "s2", "name": "delay"}},
+				{"id": "eC", "name": "/C", "nexthops": [], "strategy": {"id": "s2", "name": "delay"}}
+			]
+		}`,
+	}
+
+	plan, e := gqlmgmt.MakePlan(x, cfg, false)
+	require.NoError(e)
+	require.Len(plan.Steps, 2)
+	assert.Equal("/8=C", plan.Steps[0].Target)
+	assert.Equal("/8=N", plan.Steps[1].Target)
+
+	require.NoError(plan.Apply(x))
+	require.Len(x.mutations, 2)
+	assert.Contains(x.mutations[0], `"strategy":"s2"`)
+	assert.NotContains(x.mutations[1], `"strategy"`)
+}
+
+func TestFwConfigValidate(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	var cfg gqlmgmt.FwConfig
+	cfg.Faces = []gqlmgmt.FwConfigFace{{Key: "a", Locator: map[string]interface{}{"scheme": "unix"}}}
+	cfg.Fib = []gqlmgmt.FwConfigFibEntry{{Name: "/A", Nexthops: []string{"b"}}}
+	assert.Error(cfg.Validate())
+
+	cfg.Fib[0].Nexthops[0] = "a"
+	assert.NoError(cfg.Validate())
+
+	cfg.Fib = append(cfg.Fib, gqlmgmt.FwConfigFibEntry{Name: "/A", Nexthops: []string{"a"}})
+	assert.Error(cfg.Validate())
+}
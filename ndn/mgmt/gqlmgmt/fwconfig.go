@@ -0,0 +1,134 @@
+package gqlmgmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// FwConfig is a declarative forwarder configuration document.
+// It describes faces, strategies, and FIB entries that should exist in the forwarder.
+type FwConfig struct {
+	Faces      []FwConfigFace
+	Strategies []FwConfigStrategy
+	Fib        []FwConfigFibEntry
+}
+
+// FwConfigFace describes a face.
+type FwConfigFace struct {
+	// Key identifies the face within the document, and is referenced in FIB nexthops.
+	Key string
+
+	// Locator is the face locator, as accepted by createFace mutation.
+	// An existing face matches if its locator contains every field in this locator.
+	Locator map[string]interface{}
+
+	// Persistency is the face persistency level, such as "PERSISTENT".
+	// If empty, it is unchanged on an existing face, or defaults to "PERSISTENT" on a new face.
+	Persistency string
+}
+
+// FwConfigStrategy describes a strategy.
+type FwConfigStrategy struct {
+	// Name is the strategy short name, referenced in FIB entries.
+	// An existing strategy matches if it has the same name.
+	Name string
+
+	// Elf is the filename of strategy ELF program.
+	Elf string
+}
+
+// FwConfigFibEntry describes a FIB entry.
+type FwConfigFibEntry struct {
+	Name     string
+	Nexthops []string // face keys
+	Strategy string   // strategy name; if empty, keep the strategy of an existing entry, or use the forwarder's default strategy for a new entry
+}
+
+// Validate checks that the document is self-consistent.
+func (cfg FwConfig) Validate() error {
+	faceKeys := map[string]bool{}
+	for i, face := range cfg.Faces {
+		if face.Key == "" {
+			return fmt.Errorf("faces[%d].key is empty", i)
+		}
+		if faceKeys[face.Key] {
+			return fmt.Errorf("faces[%d].key %s is duplicate", i, face.Key)
+		}
+		if len(face.Locator) == 0 {
+			return fmt.Errorf("faces[%d].locator is empty", i)
+		}
+		faceKeys[face.Key] = true
+	}
+
+	strategyNames := map[string]bool{}
+	for i, sc := range cfg.Strategies {
+		if sc.Name == "" || sc.Elf == "" {
+			return fmt.Errorf("strategies[%d] must have name and elf", i)
+		}
+		if strategyNames[sc.Name] {
+			return fmt.Errorf("strategies[%d].name %s is duplicate", i, sc.Name)
+		}
+		strategyNames[sc.Name] = true
+	}
+
+	fibNames := map[string]bool{}
+	for i, entry := range cfg.Fib {
+		name := ndn.ParseName(entry.Name).String()
+		if fibNames[name] {
+			return fmt.Errorf("fib[%d].name %s is duplicate", i, name)
+		}
+		fibNames[name] = true
+		if len(entry.Nexthops) == 0 {
+			return fmt.Errorf("fib[%d].nexthops is empty", i)
+		}
+		for j, nh := range entry.Nexthops {
+			if !faceKeys[nh] {
+				return fmt.Errorf("fib[%d].nexthops[%d] refers to unknown face %s", i, j, nh)
+			}
+		}
+	}
+	return nil
+}
+
+// matchLocator determines whether live locator contains every field in want locator.
+// Field names are case insensitive, consistent with JSON decoding of locators.
+func matchLocator(want, live map[string]interface{}) bool {
+	for key, wantValue := range want {
+		liveValue, ok := live[key]
+		if !ok {
+			for liveKey, value := range live {
+				if strings.EqualFold(key, liveKey) {
+					liveValue, ok = value, true
+					break
+				}
+			}
+		}
+		if !ok || !matchLocatorValue(wantValue, liveValue) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchLocatorValue(want, live interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		return ok && matchLocator(w, l)
+	case string:
+		l, ok := live.(string)
+		return ok && strings.EqualFold(w, l)
+	}
+
+	// normalize numbers and other types through JSON
+	var wantN, liveN interface{}
+	wantJ, _ := json.Marshal(want)
+	liveJ, _ := json.Marshal(live)
+	json.Unmarshal(wantJ, &wantN)
+	json.Unmarshal(liveJ, &liveN)
+	return reflect.DeepEqual(wantN, liveN)
+}
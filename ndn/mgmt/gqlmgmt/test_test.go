@@ -0,0 +1,7 @@
+package gqlmgmt_test
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+)
+
+var makeAR = testenv.MakeAR
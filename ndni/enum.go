@@ -44,6 +44,10 @@ const (
 	// DefaultInterestLifetime is the default value of InterestLifetime.
 	DefaultInterestLifetime = 4000
 
+	// MaxInterestLifetime is the maximum InterestLifetime honored by the PIT.
+	// A longer InterestLifetime is truncated to this value.
+	MaxInterestLifetime = 120000
+
 	// InterestTemplateBufLen is the buffer length for InterestTemplate.
 	// It can accommodate two forwarding hints.
 	InterestTemplateBufLen = 2*NameMaxLength + 256